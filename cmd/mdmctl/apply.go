@@ -64,6 +64,14 @@ func (cmd *applyCommand) Run(args []string) error {
 		run = cmd.applyBlock
 	case "users":
 		run = cmd.applyUser
+	case "retry":
+		run = cmd.applyRetry
+	case "webhook-replay":
+		run = cmd.applyWebhookReplay
+	case "events-replay":
+		run = cmd.applyEventsReplay
+	case "push-provider":
+		run = cmd.applyPushProvider
 	default:
 		cmd.Usage()
 		os.Exit(1)
@@ -84,6 +92,10 @@ Valid resource types:
   * dep-profiles
   * app
   * block
+  * retry
+  * webhook-replay
+  * events-replay
+  * push-provider
 
 Examples:
   # Apply a Blueprint.
@@ -92,6 +104,18 @@ Examples:
   # Apply a DEP Profile.
   mdmctl apply dep-profiles -f /path/to/dep-profile.json
 
+  # Requeue a failed or expired command.
+  mdmctl apply retry -udid=UDID -uuid=COMMAND_UUID
+
+  # Send every failed webhook delivery again.
+  mdmctl apply webhook-replay -all
+
+  # Publish the checkin events of the last week again.
+  mdmctl apply events-replay -from=168h
+
+  # Authenticate with APNs using a .p8 auth key.
+  mdmctl apply push-provider -provider=token -auth-key=AuthKey.p8 -key-id=KEY_ID -team-id=TEAM_ID -topic=TOPIC
+
 `
 	fmt.Println(applyUsage)
 	return nil
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/archive"
+)
+
+func (cmd *applyCommand) applyEventsReplay(args []string) error {
+	flagset := flag.NewFlagSet("events-replay", flag.ExitOnError)
+	var (
+		flTopics listFlag
+		flFrom   = flagset.String("from", "", "replay events at or after this RFC 3339 time, or this long ago, like 24h")
+		flTo     = flagset.String("to", "", "replay events before this RFC 3339 time, or this long ago")
+		flUDID   = flagset.String("udid", "", "only replay events of the device with this UDID")
+	)
+	flagset.Var(&flTopics, "topic", "checkin topic to replay, can be repeated or comma separated. Defaults to mdm.Authenticate, mdm.TokenUpdate and mdm.CheckOut")
+	flagset.Usage = usageFor(flagset, "mdmctl apply events-replay [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	q := archive.Query{Topics: flTopics, UDID: *flUDID}
+	var err error
+	if q.From, err = parseTimeFlag(*flFrom); err != nil {
+		return errors.Wrap(err, "parse -from")
+	}
+	if q.To, err = parseTimeFlag(*flTo); err != nil {
+		return errors.Wrap(err, "parse -to")
+	}
+	if q.From.IsZero() {
+		flagset.Usage()
+		return errors.New("bad input: must provide the start of the window with -from")
+	}
+
+	ctx := context.Background()
+	result, err := cmd.archivesvc.ReplayEvents(ctx, q)
+	if result != nil {
+		topics := make([]string, 0, len(result.Topics))
+		for topic := range result.Topics {
+			topics = append(topics, topic)
+		}
+		sort.Strings(topics)
+		for _, topic := range topics {
+			fmt.Printf("%s: %d\n", topic, result.Topics[topic])
+		}
+		fmt.Printf("replayed %d events\n", result.Published)
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/config"
+)
+
+func (cmd *applyCommand) applyPushProvider(args []string) error {
+	flagset := flag.NewFlagSet("push-provider", flag.ExitOnError)
+	var (
+		flProvider    = flagset.String("provider", config.PushProviderCertificate, "APNs authentication, certificate or token")
+		flAuthKey     = flagset.String("auth-key", "", "path to the .p8 auth key of the token provider")
+		flKeyID       = flagset.String("key-id", "", "key ID of the auth key")
+		flTeamID      = flagset.String("team-id", "", "team ID of the auth key")
+		flTopic       = flagset.String("topic", "", "push topic of the token provider")
+		flEnvironment = flagset.String("environment", config.PushEnvironmentProduction, "APNs environment, production or sandbox")
+		flGatewayURL  = flagset.String("gateway-url", "", "URL of the APNs gateway, overrides -environment")
+		flForce       = flagset.Bool("force", false, "Replace the push topic with a different one. Enrolled devices stop receiving pushes and must re-enroll.")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl apply push-provider [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	provider := config.PushProvider{
+		Provider:    *flProvider,
+		KeyID:       *flKeyID,
+		TeamID:      *flTeamID,
+		Topic:       *flTopic,
+		Environment: *flEnvironment,
+		GatewayURL:  *flGatewayURL,
+	}
+	if *flAuthKey != "" {
+		authKey, err := ioutil.ReadFile(*flAuthKey)
+		if err != nil {
+			return errors.Wrap(err, "read auth key")
+		}
+		provider.AuthKey = authKey
+	}
+	if err := provider.Validate(); err != nil {
+		flagset.Usage()
+		return errors.Wrap(err, "bad input")
+	}
+
+	ctx := context.Background()
+	if err := cmd.configsvc.SavePushProvider(ctx, provider, *flForce); err != nil {
+		return err
+	}
+	fmt.Printf("saved %s push provider\n", provider.Provider)
+	return nil
+}
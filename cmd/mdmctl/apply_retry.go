@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (cmd *applyCommand) applyRetry(args []string) error {
+	flagset := flag.NewFlagSet("retry", flag.ExitOnError)
+	var (
+		flUDID = flagset.String("udid", "", "UDID of the device")
+		flUUID = flagset.String("uuid", "", "UUID of the failed or expired command to requeue")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl apply retry [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	if *flUDID == "" || *flUUID == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide a device UDID and a command UUID.")
+	}
+
+	ctx := context.Background()
+	if err := cmd.queuesvc.RetryCommand(ctx, *flUDID, *flUUID); err != nil {
+		return err
+	}
+
+	fmt.Println("success")
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (cmd *applyCommand) applyWebhookReplay(args []string) error {
+	flagset := flag.NewFlagSet("webhook-replay", flag.ExitOnError)
+	var (
+		flIDs listFlag
+		flAll = flagset.Bool("all", false, "replay every failed delivery")
+	)
+	flagset.Var(&flIDs, "id", "ID of a failed delivery to replay, can be repeated or comma separated")
+	flagset.Usage = usageFor(flagset, "mdmctl apply webhook-replay [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	if len(flIDs) == 0 && !*flAll {
+		flagset.Usage()
+		return errors.New("bad input: must provide delivery IDs or -all")
+	}
+	if len(flIDs) > 0 && *flAll {
+		return errors.New("bad input: -id and -all are mutually exclusive")
+	}
+
+	ctx := context.Background()
+	results, err := cmd.webhooksvc.ReplayFailedDeliveries(ctx, flIDs)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range results {
+		if r.Err != "" {
+			failed++
+			fmt.Printf("%s: %s\n", r.ID, r.Err)
+			continue
+		}
+		fmt.Printf("%s: delivered\n", r.ID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deliveries failed again", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+)
+
+type commandCommand struct {
+	config *ServerConfig
+	*remoteServices
+}
+
+func (cmd *commandCommand) setup() error {
+	cfg, err := LoadServerConfig()
+	if err != nil {
+		return err
+	}
+	cmd.config = cfg
+	logger := log.NewLogfmtLogger(os.Stderr)
+
+	remote, err := setupClient(logger)
+	if err != nil {
+		return err
+	}
+	cmd.remoteServices = remote
+	return nil
+}
+
+func (cmd *commandCommand) Run(args []string) error {
+	if len(args) < 1 {
+		cmd.Usage()
+		os.Exit(1)
+	}
+
+	if err := cmd.setup(); err != nil {
+		return err
+	}
+
+	var run func([]string) error
+	switch strings.ToLower(args[0]) {
+	case "device-information":
+		run = cmd.deviceInformation
+	case "lock":
+		run = cmd.lock
+	case "erase":
+		run = cmd.erase
+	case "install-profile":
+		run = cmd.installProfile
+	case "remove-profile":
+		run = cmd.removeProfile
+	case "install-app":
+		run = cmd.installApp
+	case "restart":
+		run = cmd.restart
+	case "shutdown":
+		run = cmd.shutdown
+	case "os-update":
+		run = cmd.osUpdate
+	case "os-update-scan":
+		run = cmd.osUpdateScan
+	case "settings":
+		run = cmd.settings
+	case "managed-app-list":
+		run = cmd.managedAppList
+	case "remove-app":
+		run = cmd.removeApp
+	case "invite-to-program":
+		run = cmd.inviteToProgram
+	case "validate-apps":
+		run = cmd.validateApps
+	case "app-configuration":
+		run = cmd.appConfiguration
+	case "managed-app-config":
+		run = cmd.managedAppConfig
+	case "managed-app-attributes":
+		run = cmd.managedAppAttributes
+	case "managed-app-feedback":
+		run = cmd.managedAppFeedback
+	default:
+		cmd.Usage()
+		os.Exit(1)
+	}
+
+	return run(args[1:])
+}
+
+func (cmd *commandCommand) Usage() error {
+	const commandUsage = `
+Send an MDM command to a device.
+
+Valid commands:
+
+  * device-information
+  * lock
+  * erase
+  * install-profile
+  * remove-profile
+  * install-app
+  * restart
+  * shutdown
+  * os-update
+  * os-update-scan
+  * settings
+  * managed-app-list
+  * remove-app
+  * invite-to-program
+  * validate-apps
+  * app-configuration
+  * managed-app-config
+  * managed-app-attributes
+  * managed-app-feedback
+
+Every command accepts the -udid, -ttl and -priority flags.
+The UUID of the queued command is printed on success.
+
+Examples:
+  # Query the OS version and serial number of a device
+  mdmctl command device-information -udid=564D38A0-4C3B-AD69-803B-DAC58A298191 -queries=OSVersion,SerialNumber
+
+  # Lock a device with a message
+  mdmctl command lock -udid=564D38A0-4C3B-AD69-803B-DAC58A298191 -pin=123456 -message="Return to IT"
+
+  # Install a configuration profile
+  mdmctl command install-profile -udid=564D38A0-4C3B-AD69-803B-DAC58A298191 -f=wifi.mobileconfig
+
+  # List the managed apps of a device
+  mdmctl command managed-app-list -udid=564D38A0-4C3B-AD69-803B-DAC58A298191
+
+  # Remove a managed app
+  mdmctl command remove-app -udid=564D38A0-4C3B-AD69-803B-DAC58A298191 -identifier=com.example.app
+`
+	fmt.Print(commandUsage)
+	return nil
+}
+
+// requestFlags are the flags shared by every command.
+type requestFlags struct {
+	udid     *string
+	ttl      *time.Duration
+	priority *int
+}
+
+func addRequestFlags(flagset *flag.FlagSet) *requestFlags {
+	return &requestFlags{
+		udid:     flagset.String("udid", "", "UDID of the device"),
+		ttl:      flagset.Duration("ttl", 0, "expire the command if it is not acknowledged within this duration"),
+		priority: flagset.Int("priority", 0, "commands with a higher priority are sent to the device first"),
+	}
+}
+
+// request returns a CommandRequest for the command.
+func (f *requestFlags) request(command mdm.Command) (*mdm.CommandRequest, error) {
+	if *f.udid == "" {
+		return nil, errors.New("bad input: must provide a device UDID")
+	}
+	if *f.ttl < 0 {
+		return nil, errors.New("bad input: ttl must not be negative")
+	}
+	return &mdm.CommandRequest{
+		UDID:     *f.udid,
+		TTL:      int64(f.ttl.Seconds()),
+		Priority: *f.priority,
+		Command:  command,
+	}, nil
+}
+
+// newCommand queues the command for the device and prints the CommandUUID.
+func (cmd *commandCommand) newCommand(flags *requestFlags, command mdm.Command) error {
+	req, err := flags.request(command)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	payload, err := cmd.commandsvc.NewCommand(ctx, req)
+	if err != nil {
+		return errors.Wrapf(err, "queue %s command", command.RequestType)
+	}
+	fmt.Println(payload.CommandUUID)
+	return nil
+}
+
+// listFlag is a comma separated list of values.
+type listFlag []string
+
+func (f *listFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*f = append(*f, v)
+		}
+	}
+	return nil
+}
+
+// keyValueFlag collects repeated key=value flags.
+type keyValueFlag map[string]string
+
+func (f keyValueFlag) String() string {
+	var pairs []string
+	for k, v := range f {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f keyValueFlag) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	f[kv[0]] = kv[1]
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+)
+
+func (cmd *commandCommand) managedAppList(args []string) error {
+	flagset := flag.NewFlagSet("managed-app-list", flag.ExitOnError)
+	var (
+		flRequest     = addRequestFlags(flagset)
+		flIdentifiers listFlag
+	)
+	flagset.Var(&flIdentifiers, "identifiers", "comma separated app identifiers, all managed apps if empty")
+	flagset.Usage = usageFor(flagset, "mdmctl command managed-app-list [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "ManagedApplicationList",
+		ManagedApplicationList: mdm.ManagedApplicationList{
+			Identifiers: flIdentifiers,
+		},
+	})
+}
+
+func (cmd *commandCommand) removeApp(args []string) error {
+	flagset := flag.NewFlagSet("remove-app", flag.ExitOnError)
+	var (
+		flRequest    = addRequestFlags(flagset)
+		flIdentifier = flagset.String("identifier", "", "identifier of the managed app to remove")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command remove-app [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flIdentifier == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide an app identifier")
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "RemoveApplication",
+		RemoveApplication: mdm.RemoveApplication{
+			Identifier: *flIdentifier,
+		},
+	})
+}
+
+func (cmd *commandCommand) inviteToProgram(args []string) error {
+	flagset := flag.NewFlagSet("invite-to-program", flag.ExitOnError)
+	var (
+		flRequest       = addRequestFlags(flagset)
+		flProgramID     = flagset.String("program-id", "com.apple.cloudvpp", "ID of the program")
+		flInvitationURL = flagset.String("invitation-url", "", "URL of the invitation")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command invite-to-program [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flInvitationURL == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide an invitation URL")
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "InviteToProgram",
+		InviteToProgram: mdm.InviteToProgram{
+			ProgramID:     *flProgramID,
+			InvitationURL: *flInvitationURL,
+		},
+	})
+}
+
+func (cmd *commandCommand) validateApps(args []string) error {
+	flagset := flag.NewFlagSet("validate-apps", flag.ExitOnError)
+	var (
+		flRequest     = addRequestFlags(flagset)
+		flIdentifiers listFlag
+	)
+	flagset.Var(&flIdentifiers, "identifiers", "comma separated app identifiers, all enterprise apps if empty")
+	flagset.Usage = usageFor(flagset, "mdmctl command validate-apps [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "ValidateApplications",
+		ValidateApplications: mdm.ValidateApplications{
+			Identifiers: flIdentifiers,
+		},
+	})
+}
+
+func (cmd *commandCommand) appConfiguration(args []string) error {
+	flagset := flag.NewFlagSet("app-configuration", flag.ExitOnError)
+	var (
+		flRequest    = addRequestFlags(flagset)
+		flIdentifier = flagset.String("identifier", "", "identifier of the managed app")
+		flConfig     = make(keyValueFlag)
+	)
+	flagset.Var(flConfig, "config", "configuration key=value, may be repeated")
+	flagset.Usage = usageFor(flagset, "mdmctl command app-configuration [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flIdentifier == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide an app identifier")
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "ApplicationConfiguration",
+		ApplicationConfiguration: mdm.ApplicationConfiguration{
+			Identifier:    *flIdentifier,
+			Configuration: flConfig,
+		},
+	})
+}
+
+func (cmd *commandCommand) managedAppConfig(args []string) error {
+	flagset := flag.NewFlagSet("managed-app-config", flag.ExitOnError)
+	var (
+		flRequest     = addRequestFlags(flagset)
+		flIdentifiers listFlag
+	)
+	flagset.Var(&flIdentifiers, "identifiers", "comma separated app identifiers")
+	flagset.Usage = usageFor(flagset, "mdmctl command managed-app-config [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "ManagedApplicationConfiguration",
+		ManagedApplicationConfiguration: mdm.ManagedApplicationConfiguration{
+			Identifiers: flIdentifiers,
+		},
+	})
+}
+
+func (cmd *commandCommand) managedAppAttributes(args []string) error {
+	flagset := flag.NewFlagSet("managed-app-attributes", flag.ExitOnError)
+	var (
+		flRequest     = addRequestFlags(flagset)
+		flIdentifiers listFlag
+	)
+	flagset.Var(&flIdentifiers, "identifiers", "comma separated app identifiers")
+	flagset.Usage = usageFor(flagset, "mdmctl command managed-app-attributes [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "ManagedApplicationAttributes",
+		ManagedApplicationAttributes: mdm.ManagedApplicationAttributes{
+			Identifiers: flIdentifiers,
+		},
+	})
+}
+
+func (cmd *commandCommand) managedAppFeedback(args []string) error {
+	flagset := flag.NewFlagSet("managed-app-feedback", flag.ExitOnError)
+	var (
+		flRequest     = addRequestFlags(flagset)
+		flDelete      = flagset.Bool("delete", false, "delete the feedback on the device after it is reported")
+		flIdentifiers listFlag
+	)
+	flagset.Var(&flIdentifiers, "identifiers", "comma separated app identifiers")
+	flagset.Usage = usageFor(flagset, "mdmctl command managed-app-feedback [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "ManagedApplicationFeedback",
+		ManagedApplicationFeedback: mdm.ManagedApplicationFeedback{
+			Identifiers:    flIdentifiers,
+			DeleteFeedback: *flDelete,
+		},
+	})
+}
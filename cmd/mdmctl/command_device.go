@@ -0,0 +1,337 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+)
+
+// defaultQueries are sent by device-information when no queries are specified.
+var defaultQueries = []string{
+	"UDID", "DeviceName", "OSVersion", "BuildVersion", "ModelName", "Model",
+	"ProductName", "SerialNumber", "DeviceCapacity", "AvailableDeviceCapacity",
+	"BatteryLevel", "IsSupervised", "WiFiMAC", "BluetoothMAC",
+}
+
+func (cmd *commandCommand) deviceInformation(args []string) error {
+	flagset := flag.NewFlagSet("device-information", flag.ExitOnError)
+	var (
+		flRequest = addRequestFlags(flagset)
+		flQueries listFlag
+	)
+	flagset.Var(&flQueries, "queries", "comma separated list of queries, a set of common queries if empty")
+	flagset.Usage = usageFor(flagset, "mdmctl command device-information [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if len(flQueries) == 0 {
+		flQueries = defaultQueries
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "DeviceInformation",
+		DeviceInformation: mdm.DeviceInformation{
+			Queries: flQueries,
+		},
+	})
+}
+
+func (cmd *commandCommand) lock(args []string) error {
+	flagset := flag.NewFlagSet("lock", flag.ExitOnError)
+	var (
+		flRequest = addRequestFlags(flagset)
+		flPIN     = flagset.String("pin", "", "six digit PIN required to unlock a macOS device")
+		flMessage = flagset.String("message", "", "message displayed on the lock screen")
+		flPhone   = flagset.String("phone", "", "phone number displayed on the lock screen")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command lock [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if err := validatePIN(*flPIN); err != nil {
+		flagset.Usage()
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "DeviceLock",
+		DeviceLock: mdm.DeviceLock{
+			PIN:         *flPIN,
+			Message:     *flMessage,
+			PhoneNumber: *flPhone,
+		},
+	})
+}
+
+func (cmd *commandCommand) erase(args []string) error {
+	flagset := flag.NewFlagSet("erase", flag.ExitOnError)
+	var (
+		flRequest = addRequestFlags(flagset)
+		flPIN     = flagset.String("pin", "", "six digit Find My Mac PIN of a macOS device")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command erase [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if err := validatePIN(*flPIN); err != nil {
+		flagset.Usage()
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "EraseDevice",
+		EraseDevice: mdm.EraseDevice{
+			PIN: *flPIN,
+		},
+	})
+}
+
+func (cmd *commandCommand) installProfile(args []string) error {
+	flagset := flag.NewFlagSet("install-profile", flag.ExitOnError)
+	var (
+		flRequest = addRequestFlags(flagset)
+		flPath    = flagset.String("f", "", "path to the profile (.mobileconfig)")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command install-profile [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flPath == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide the path to a profile")
+	}
+	payload, err := ioutil.ReadFile(*flPath)
+	if err != nil {
+		return errors.Wrap(err, "read profile")
+	}
+	if len(payload) == 0 {
+		return errors.Errorf("bad input: profile %s is empty", *flPath)
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "InstallProfile",
+		InstallProfile: mdm.InstallProfile{
+			Payload: payload,
+		},
+	})
+}
+
+func (cmd *commandCommand) removeProfile(args []string) error {
+	flagset := flag.NewFlagSet("remove-profile", flag.ExitOnError)
+	var (
+		flRequest    = addRequestFlags(flagset)
+		flIdentifier = flagset.String("identifier", "", "PayloadIdentifier of the profile to remove")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command remove-profile [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flIdentifier == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide a profile identifier")
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "RemoveProfile",
+		RemoveProfile: mdm.RemoveProfile{
+			Identifier: *flIdentifier,
+		},
+	})
+}
+
+func (cmd *commandCommand) installApp(args []string) error {
+	flagset := flag.NewFlagSet("install-app", flag.ExitOnError)
+	var (
+		flRequest         = addRequestFlags(flagset)
+		flManifestURL     = flagset.String("manifest-url", "", "URL of the app manifest")
+		flITunesStoreID   = flagset.Int("itunes-store-id", 0, "iTunes Store ID of the app")
+		flIdentifier      = flagset.String("identifier", "", "bundle identifier of the app")
+		flManagementFlags = flagset.Int("management-flags", 1, "1 removes the app when the MDM profile is removed, 4 prevents backup of app data")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command install-app [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	install, err := installApplication(*flManifestURL, *flITunesStoreID, *flIdentifier)
+	if err != nil {
+		flagset.Usage()
+		return err
+	}
+	install.ManagementFlags = *flManagementFlags
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType:        "InstallApplication",
+		InstallApplication: install,
+	})
+}
+
+// installApplication requires exactly one source for the app.
+func installApplication(manifestURL string, iTunesStoreID int, identifier string) (mdm.InstallApplication, error) {
+	var sources int
+	for _, set := range []bool{manifestURL != "", iTunesStoreID != 0, identifier != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return mdm.InstallApplication{}, errors.New("bad input: must provide one of -manifest-url, -itunes-store-id or -identifier")
+	}
+	return mdm.InstallApplication{
+		ManifestURL:   manifestURL,
+		ITunesStoreID: iTunesStoreID,
+		Identifier:    identifier,
+	}, nil
+}
+
+func (cmd *commandCommand) restart(args []string) error {
+	flagset := flag.NewFlagSet("restart", flag.ExitOnError)
+	flRequest := addRequestFlags(flagset)
+	flagset.Usage = usageFor(flagset, "mdmctl command restart [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	return cmd.newCommand(flRequest, mdm.Command{RequestType: "RestartDevice"})
+}
+
+func (cmd *commandCommand) shutdown(args []string) error {
+	flagset := flag.NewFlagSet("shutdown", flag.ExitOnError)
+	flRequest := addRequestFlags(flagset)
+	flagset.Usage = usageFor(flagset, "mdmctl command shutdown [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	return cmd.newCommand(flRequest, mdm.Command{RequestType: "ShutDownDevice"})
+}
+
+func (cmd *commandCommand) osUpdate(args []string) error {
+	flagset := flag.NewFlagSet("os-update", flag.ExitOnError)
+	var (
+		flRequest     = addRequestFlags(flagset)
+		flProductKeys listFlag
+		flAction      = flagset.String("action", "Default", "one of Default, DownloadOnly, InstallASAP, NotifyOnly, InstallLater")
+	)
+	flagset.Var(&flProductKeys, "product-keys", "comma separated product keys of the updates")
+	flagset.Usage = usageFor(flagset, "mdmctl command os-update [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	update, err := scheduleOSUpdate(flProductKeys, *flAction)
+	if err != nil {
+		flagset.Usage()
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType:      "ScheduleOSUpdate",
+		ScheduleOSUpdate: update,
+	})
+}
+
+func scheduleOSUpdate(productKeys []string, action string) (mdm.ScheduleOSUpdate, error) {
+	switch action {
+	case "Default", "DownloadOnly", "InstallASAP", "NotifyOnly", "InstallLater":
+	default:
+		return mdm.ScheduleOSUpdate{}, errors.Errorf("bad input: unknown install action %q", action)
+	}
+	if len(productKeys) == 0 {
+		return mdm.ScheduleOSUpdate{}, errors.New("bad input: must provide at least one product key")
+	}
+	var update mdm.ScheduleOSUpdate
+	for _, key := range productKeys {
+		update.Updates = append(update.Updates, mdm.OSUpdate{
+			ProductKey:    key,
+			InstallAction: action,
+		})
+	}
+	return update, nil
+}
+
+func (cmd *commandCommand) osUpdateScan(args []string) error {
+	flagset := flag.NewFlagSet("os-update-scan", flag.ExitOnError)
+	var (
+		flRequest = addRequestFlags(flagset)
+		flForce   = flagset.Bool("force", false, "force a scan even if one was performed recently (macOS only)")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command os-update-scan [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "ScheduleOSUpdateScan",
+		ScheduleOSUpdateScan: mdm.ScheduleOSUpdateScan{
+			Force: *flForce,
+		},
+	})
+}
+
+func (cmd *commandCommand) settings(args []string) error {
+	flagset := flag.NewFlagSet("settings", flag.ExitOnError)
+	var (
+		flRequest         = addRequestFlags(flagset)
+		flDeviceName      = flagset.String("device-name", "", "set the device name")
+		flHostName        = flagset.String("hostname", "", "set the host name (macOS only)")
+		flVoiceRoaming    = flagset.String("voice-roaming", "", "enable or disable voice roaming (true or false)")
+		flDataRoaming     = flagset.String("data-roaming", "", "enable or disable data roaming (true or false)")
+		flPersonalHotspot = flagset.String("personal-hotspot", "", "enable or disable personal hotspot (true or false)")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl command settings [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	var settings []mdm.Setting
+	if *flDeviceName != "" {
+		settings = append(settings, mdm.Setting{Item: "DeviceName", DeviceName: flDeviceName})
+	}
+	if *flHostName != "" {
+		settings = append(settings, mdm.Setting{Item: "HostName", HostName: flHostName})
+	}
+	for _, item := range []struct {
+		name, value string
+	}{
+		{"VoiceRoaming", *flVoiceRoaming},
+		{"DataRoaming", *flDataRoaming},
+		{"PersonalHotspot", *flPersonalHotspot},
+	} {
+		if item.value == "" {
+			continue
+		}
+		enabled, err := strconv.ParseBool(item.value)
+		if err != nil {
+			return errors.Wrapf(err, "bad input: %s", item.name)
+		}
+		settings = append(settings, mdm.Setting{Item: item.name, Enabled: &enabled})
+	}
+	if len(settings) == 0 {
+		flagset.Usage()
+		return errors.New("bad input: must provide at least one setting")
+	}
+
+	return cmd.newCommand(flRequest, mdm.Command{
+		RequestType: "Settings",
+		Settings: mdm.Settings{
+			Settings: settings,
+		},
+	})
+}
+
+// validatePIN checks that an optional lock or erase PIN has six digits.
+func validatePIN(pin string) error {
+	if pin == "" {
+		return nil
+	}
+	if len(pin) != 6 {
+		return errors.Errorf("bad input: PIN must have six digits, got %d", len(pin))
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return errors.New("bad input: PIN must only contain digits")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/as/micromdm/mdm"
+)
+
+func TestValidatePIN(t *testing.T) {
+	tests := []struct {
+		pin   string
+		valid bool
+	}{
+		{"", true},
+		{"123456", true},
+		{"12345", false},
+		{"12345a", false},
+	}
+	for _, tt := range tests {
+		if err := validatePIN(tt.pin); (err == nil) != tt.valid {
+			t.Errorf("pin %q: have err %v, want valid %t", tt.pin, err, tt.valid)
+		}
+	}
+}
+
+func TestInstallApplication(t *testing.T) {
+	if _, err := installApplication("", 0, ""); err == nil {
+		t.Error("expected error without an app source")
+	}
+	if _, err := installApplication("https://mdm.acme.co/app.plist", 1234, ""); err == nil {
+		t.Error("expected error with two app sources")
+	}
+	install, err := installApplication("", 0, "com.apple.Keynote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := install.Identifier, "com.apple.Keynote"; have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+}
+
+func TestScheduleOSUpdate(t *testing.T) {
+	if _, err := scheduleOSUpdate([]string{"041-88800"}, "Later"); err == nil {
+		t.Error("expected error for unknown install action")
+	}
+	if _, err := scheduleOSUpdate(nil, "Default"); err == nil {
+		t.Error("expected error without product keys")
+	}
+	update, err := scheduleOSUpdate([]string{"041-88800", "041-88801"}, "InstallASAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(update.Updates), 2; have != want {
+		t.Errorf("have %d updates, want %d", have, want)
+	}
+}
+
+func TestRequestFlags(t *testing.T) {
+	flagset := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := addRequestFlags(flagset)
+	var queries listFlag
+	flagset.Var(&queries, "queries", "")
+	if err := flagset.Parse([]string{"-udid=abcd", "-ttl=1h", "-priority=5", "-queries=UDID, OSVersion"}); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := []string(queries), []string{"UDID", "OSVersion"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+
+	req, err := flags.request(mdm.Command{RequestType: "DeviceInformation"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.UDID != "abcd" || req.TTL != 3600 || req.Priority != 5 {
+		t.Errorf("unexpected request %+v", req)
+	}
+
+	*flags.udid = ""
+	if _, err := flags.request(mdm.Command{RequestType: "DeviceInformation"}); err == nil {
+		t.Error("expected error without UDID")
+	}
+}
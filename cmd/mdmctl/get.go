@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -72,6 +73,18 @@ func (cmd *getCommand) Run(args []string) error {
 		run = cmd.getUsers
 	case "apps":
 		run = cmd.getApps
+	case "commands":
+		run = cmd.getCommands
+	case "batches":
+		run = cmd.getBatches
+	case "failed-webhooks":
+		run = cmd.getFailedWebhooks
+	case "pushes":
+		run = cmd.getPushes
+	case "events":
+		run = cmd.getEvents
+	case "config":
+		run = cmd.getConfig
 	default:
 		cmd.Usage()
 		os.Exit(1)
@@ -95,13 +108,40 @@ Valid resource types:
   * users
   * profiles
   * apps
+  * commands
+  * batches
+  * failed-webhooks
+  * pushes
+  * events
+  * config
 
 Examples:
   # Get a list of devices
   mdmctl get devices
 
-  # Get a device by serial (TODO implement filtering)
+  # Get a device by serial
   mdmctl get devices -serial=C02ABCDEF
+
+  # Get the 50 enrolled devices which were seen last
+  mdmctl get devices -enrolled=true -sort=last_seen -desc -limit=50
+
+  # Get the failed commands of a device
+  mdmctl get commands -udid=564D38A0-4C3B-AD69-803B-DAC58A298191 -status=failed
+
+  # Wait for every command of a batch to finish
+  mdmctl get batches -id=5e1f7f6c-64a6-4f3a-9a3a-0f7c5a7e0c2d -wait
+
+  # List webhook deliveries which failed after every retry
+  mdmctl get failed-webhooks
+
+  # Get the archived TokenUpdate events of the last day
+  mdmctl get events -topic=mdm.TokenUpdate -from=24h
+
+  # Show the latest pushes to a device
+  mdmctl get pushes -udid=564D38A0-4C3B-AD69-803B-DAC58A298191
+
+  # Show the push topic and when the push certificate expires
+  mdmctl get config
 `
 	fmt.Println(getUsage)
 	return nil
@@ -110,7 +150,7 @@ Examples:
 type devicesTableOutput struct{ w *tabwriter.Writer }
 
 func (out *devicesTableOutput) BasicHeader() {
-	fmt.Fprintf(out.w, "UDID\tSerialNumber\tEnrollmentStatus\tLastSeen\n")
+	fmt.Fprintf(out.w, "UDID\tSerialNumber\tDeviceName\tModel\tOSVersion\tEnrollmentStatus\tDEPProfileStatus\tAssetTag\tLastSeen\tPush\n")
 }
 
 func (out *devicesTableOutput) BasicFooter() {
@@ -119,25 +159,89 @@ func (out *devicesTableOutput) BasicFooter() {
 
 func (cmd *getCommand) getDevices(args []string) error {
 	flagset := flag.NewFlagSet("devices", flag.ExitOnError)
+	var (
+		flSerials  listFlag
+		flUDIDs    listFlag
+		flModel    = flagset.String("model", "", "only show devices with this model, model name or product name")
+		flOS       = flagset.String("os-version", "", "only show devices with this OS version, 11 matches 11.2.1")
+		flEnrolled = flagset.String("enrolled", "", "only show enrolled (true) or unenrolled (false) devices")
+		flDEP      = flagset.String("dep", "", "only show DEP (true) or non-DEP (false) devices")
+		flUnreach  = flagset.String("unreachable", "", "only show devices whose push token APNs rejected (true) or accepted (false)")
+		flSort     = flagset.String("sort", device.SortByUDID, "sort by one of "+strings.Join(device.SortFields, ", "))
+		flDesc     = flagset.Bool("desc", false, "sort in descending order")
+		flLimit    = flagset.Int("limit", 0, "maximum number of devices to show, 0 shows all")
+		flCursor   = flagset.String("cursor", "", "continue after the last device of a previous page")
+	)
+	flagset.Var(&flSerials, "serial", "only show the devices with this serial number, can be repeated or comma separated")
+	flagset.Var(&flUDIDs, "udid", "only show the devices with this UDID, can be repeated or comma separated")
 	flagset.Usage = usageFor(flagset, "mdmctl get devices [flags]")
 	if err := flagset.Parse(args); err != nil {
 		return err
 	}
-	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-	out := &devicesTableOutput{w}
-	out.BasicHeader()
-	defer out.BasicFooter()
+
+	opts := device.ListDevicesOption{
+		PerPage:      *flLimit,
+		Cursor:       *flCursor,
+		SortBy:       *flSort,
+		SortDesc:     *flDesc,
+		FilterSerial: flSerials,
+		FilterUDID:   flUDIDs,
+		Selector: device.Selector{
+			Model:     *flModel,
+			OSVersion: *flOS,
+		},
+	}
+	var err error
+	if opts.Selector.Enrolled, err = parseBoolFlag(*flEnrolled); err != nil {
+		return errors.Wrap(err, "parse -enrolled")
+	}
+	if opts.Selector.DEPDevice, err = parseBoolFlag(*flDEP); err != nil {
+		return errors.Wrap(err, "parse -dep")
+	}
+	if opts.Selector.PushUnreachable, err = parseBoolFlag(*flUnreach); err != nil {
+		return errors.Wrap(err, "parse -unreachable")
+	}
+
 	ctx := context.Background()
-	devices, err := cmd.devicesvc.ListDevices(ctx, device.ListDevicesOption{})
+	devices, err := cmd.devicesvc.ListDevices(ctx, opts)
 	if err != nil {
 		return err
 	}
-	for _, d := range devices {
-		fmt.Fprintf(out.w, "%s\t%s\t%v\t%s\n", d.UDID, d.SerialNumber, d.EnrollmentStatus, d.LastSeen)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	out := &devicesTableOutput{w}
+	out.BasicHeader()
+	for _, d := range devices.Devices {
+		fmt.Fprintf(out.w, "%s\t%s\t%s\t%s\t%s\t%v\t%s\t%s\t%s\t%s\n",
+			d.UDID, d.SerialNumber, d.DeviceName, d.Model, d.OSVersion, d.EnrollmentStatus,
+			d.DEPProfileStatus, d.AssetTag, formatTime(d.LastSeen), pushHealth(d))
+	}
+	out.BasicFooter()
+	if devices.NextCursor != "" {
+		fmt.Fprintf(os.Stderr, "more devices, repeat the command with -cursor=%s\n", devices.NextCursor)
 	}
 	return nil
 }
 
+// pushHealth describes whether the device can be reached with a push.
+func pushHealth(d device.DeviceDTO) string {
+	if d.PushUnreachable {
+		return fmt.Sprintf("unreachable (%s since %s)", d.PushFailureReason, formatTime(d.PushFailedAt))
+	}
+	return "ok"
+}
+
+// parseBoolFlag parses an optional boolean flag. Empty values return nil.
+func parseBoolFlag(s string) (*bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
 const defaultmdmctlFilesPath = "mdm-files"
 
 func (cmd *getCommand) getDepTokens(args []string) error {
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/as/micromdm/platform/queue"
+)
+
+type batchTableOutput struct{ w *tabwriter.Writer }
+
+func (out *batchTableOutput) BasicHeader() {
+	fmt.Fprintf(out.w, "UDID\tCommandUUID\tStatus\tLastStatus\n")
+}
+
+func (out *batchTableOutput) BasicFooter() {
+	out.w.Flush()
+}
+
+func (cmd *getCommand) getBatches(args []string) error {
+	flagset := flag.NewFlagSet("batches", flag.ExitOnError)
+	var (
+		flID       = flagset.String("id", "", "ID of the command batch")
+		flWait     = flagset.Bool("wait", false, "poll the batch until every command is completed, failed or expired")
+		flInterval = flagset.Duration("interval", 10*time.Second, "poll interval used with -wait")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl get batches [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flID == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide a batch ID")
+	}
+
+	ctx := context.Background()
+	batch, err := cmd.queuesvc.GetBatch(ctx, *flID)
+	if err != nil {
+		return err
+	}
+	for *flWait && !batch.Done {
+		fmt.Fprintf(os.Stderr, "%s\n", batchSummary(batch))
+		time.Sleep(*flInterval)
+		if batch, err = cmd.queuesvc.GetBatch(ctx, *flID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("ID: %s\nRequestType: %s\nCreatedAt: %s\n%s\n\n",
+		batch.ID, batch.RequestType, formatTime(batch.CreatedAt), batchSummary(batch))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	out := &batchTableOutput{w}
+	out.BasicHeader()
+	defer out.BasicFooter()
+	for _, c := range batch.Commands {
+		fmt.Fprintf(out.w, "%s\t%s\t%s\t%s\n", c.UDID, c.CommandUUID, c.Status, c.LastStatus)
+	}
+	return nil
+}
+
+// batchSummary formats the number of commands of a batch in each status.
+func batchSummary(batch *queue.BatchDTO) string {
+	statuses := make([]string, 0, len(batch.Counts))
+	for status := range batch.Counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	summary := fmt.Sprintf("Total: %d, Done: %t", batch.Total, batch.Done)
+	for _, status := range statuses {
+		summary += fmt.Sprintf(", %s: %d", status, batch.Counts[status])
+	}
+	return summary
+}
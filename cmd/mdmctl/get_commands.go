@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/platform/queue"
+)
+
+type commandsTableOutput struct{ w *tabwriter.Writer }
+
+func (out *commandsTableOutput) BasicHeader() {
+	fmt.Fprintf(out.w, "UUID\tRequestType\tStatus\tLastStatus\tTimesSent\tCreatedAt\tLastSentAt\tError\n")
+}
+
+func (out *commandsTableOutput) BasicFooter() {
+	out.w.Flush()
+}
+
+func (cmd *getCommand) getCommands(args []string) error {
+	flagset := flag.NewFlagSet("commands", flag.ExitOnError)
+	var (
+		flUDID        = flagset.String("udid", "", "UDID of the device")
+		flStatus      = flagset.String("status", "", "only show commands with this status (pending, not_now, completed, failed, expired)")
+		flRequestType = flagset.String("type", "", "only show commands of this RequestType")
+		flAfter       = flagset.String("after", "", "only show commands created after this time (RFC3339)")
+		flBefore      = flagset.String("before", "", "only show commands created before this time (RFC3339)")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl get commands [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flUDID == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide a device UDID")
+	}
+
+	opts := queue.GetCommandsOption{
+		Status:      *flStatus,
+		RequestType: *flRequestType,
+	}
+	var err error
+	if *flAfter != "" {
+		if opts.After, err = time.Parse(time.RFC3339, *flAfter); err != nil {
+			return err
+		}
+	}
+	if *flBefore != "" {
+		if opts.Before, err = time.Parse(time.RFC3339, *flBefore); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	commands, err := cmd.queuesvc.GetCommands(ctx, *flUDID, opts)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	out := &commandsTableOutput{w}
+	out.BasicHeader()
+	defer out.BasicFooter()
+	for _, c := range commands {
+		fmt.Fprintf(out.w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			c.UUID, c.RequestType, c.Status, c.LastStatus, c.TimesSent,
+			formatTime(c.CreatedAt), formatTime(c.LastSentAt), errorChainDescription(c.ErrorChain))
+	}
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// errorChainDescription returns the first description in an ErrorChain.
+func errorChainDescription(chain mdm.ErrorChain) string {
+	for _, item := range chain {
+		if item.USEnglishDescription != "" {
+			return item.USEnglishDescription
+		}
+		if item.LocalizedDescription != "" {
+			return item.LocalizedDescription
+		}
+	}
+	return ""
+}
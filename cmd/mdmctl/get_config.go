@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+func (cmd *getCommand) getConfig(args []string) error {
+	flagset := flag.NewFlagSet("config", flag.ExitOnError)
+	flagset.Usage = usageFor(flagset, "mdmctl get config [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	info, err := cmd.configsvc.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintf(w, "PushProvider\t%s\n", orDash(info.PushProvider))
+	fmt.Fprintf(w, "PushEnvironment\t%s\n", orDash(info.PushEnvironment))
+	fmt.Fprintf(w, "PushTopic\t%s\n", orDash(info.PushTopic))
+	cert := info.PushCertificate
+	if cert == nil {
+		fmt.Fprintf(w, "PushCertificate\t-\n")
+		return nil
+	}
+	fmt.Fprintf(w, "PushCertificateSubject\t%s\n", cert.Subject)
+	fmt.Fprintf(w, "PushCertificateTopic\t%s\n", orDash(cert.Topic))
+	fmt.Fprintf(w, "PushCertificateSerial\t%s\n", cert.SerialNumber)
+	fmt.Fprintf(w, "PushCertificateNotBefore\t%s\n", formatTime(cert.NotBefore))
+	fmt.Fprintf(w, "PushCertificateNotAfter\t%s (%s)\n", formatTime(cert.NotAfter), expiresIn(cert.NotAfter, time.Now()))
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// expiresIn describes the time left until the certificate expires in days.
+func expiresIn(notAfter, now time.Time) string {
+	left := notAfter.Sub(now)
+	if left <= 0 {
+		return "expired"
+	}
+	days := int(left / (24 * time.Hour))
+	switch days {
+	case 0:
+		return "expires in less than a day"
+	case 1:
+		return "expires in 1 day"
+	default:
+		return fmt.Sprintf("expires in %d days", days)
+	}
+}
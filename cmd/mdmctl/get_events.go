@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/archive"
+)
+
+type eventsTableOutput struct{ w *tabwriter.Writer }
+
+func (out *eventsTableOutput) BasicHeader() {
+	fmt.Fprintf(out.w, "CreatedAt\tTopic\tUDID\tDetails\n")
+}
+
+func (out *eventsTableOutput) BasicFooter() {
+	out.w.Flush()
+}
+
+func (cmd *getCommand) getEvents(args []string) error {
+	flagset := flag.NewFlagSet("events", flag.ExitOnError)
+	var (
+		flTopics listFlag
+		flFrom   = flagset.String("from", "", "only show events at or after this RFC 3339 time, or this long ago, like 24h")
+		flTo     = flagset.String("to", "", "only show events before this RFC 3339 time, or this long ago")
+		flUDID   = flagset.String("udid", "", "only show events of the device with this UDID")
+		flLimit  = flagset.Int("limit", archive.DefaultLimit, "maximum number of events to show")
+		flCursor = flagset.String("cursor", "", "continue after the last event of a previous page")
+		flJSON   = flagset.Bool("json", false, "print the JSON event envelopes, one per line")
+	)
+	flagset.Var(&flTopics, "topic", "only show events of this topic, can be repeated or comma separated")
+	flagset.Usage = usageFor(flagset, "mdmctl get events [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	q := archive.Query{
+		Topics: flTopics,
+		UDID:   *flUDID,
+		Limit:  *flLimit,
+		Cursor: *flCursor,
+	}
+	var err error
+	if q.From, err = parseTimeFlag(*flFrom); err != nil {
+		return errors.Wrap(err, "parse -from")
+	}
+	if q.To, err = parseTimeFlag(*flTo); err != nil {
+		return errors.Wrap(err, "parse -to")
+	}
+
+	ctx := context.Background()
+	page, err := cmd.archivesvc.ListEvents(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	if *flJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, ev := range page.Events {
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		out := &eventsTableOutput{w}
+		out.BasicHeader()
+		for _, ev := range page.Events {
+			data, err := json.Marshal(ev)
+			if err != nil {
+				return err
+			}
+			var env watchEnvelope
+			if err := json.Unmarshal(data, &env); err != nil {
+				return err
+			}
+			fmt.Fprintf(out.w, "%s\t%s\t%s\t%s\n",
+				formatTime(env.CreatedAt), env.Topic, env.UDID, eventDetails(env))
+		}
+		out.BasicFooter()
+	}
+
+	if page.NextCursor != "" {
+		fmt.Fprintf(os.Stderr, "more events, repeat the command with -cursor=%s\n", page.NextCursor)
+	}
+	return nil
+}
+
+// parseTimeFlag parses an RFC 3339 time, or a duration before now.
+func parseTimeFlag(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
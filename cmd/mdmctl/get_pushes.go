@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/as/micromdm/platform/apns"
+)
+
+type pushesTableOutput struct{ w *tabwriter.Writer }
+
+func (out *pushesTableOutput) BasicHeader() {
+	fmt.Fprintf(out.w, "Time\tPushNotificationID\tOutcome\tStatus\tAttempts\tError\n")
+}
+
+func (out *pushesTableOutput) BasicFooter() {
+	out.w.Flush()
+}
+
+func (cmd *getCommand) getPushes(args []string) error {
+	flagset := flag.NewFlagSet("pushes", flag.ExitOnError)
+	var (
+		flUDID  = flagset.String("udid", "", "UDID of the device")
+		flLimit = flagset.Int("limit", 20, "maximum number of pushes to show, newest first. 0 shows all")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl get pushes [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+	if *flUDID == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide a device UDID")
+	}
+
+	ctx := context.Background()
+	pushes, err := cmd.pushsvc.PushHistory(ctx, *flUDID, *flLimit)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	out := &pushesTableOutput{w}
+	out.BasicHeader()
+	defer out.BasicFooter()
+	for _, p := range pushes {
+		fmt.Fprintf(out.w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			formatTime(p.Time), p.PushNotificationID, pushOutcome(p), pushStatus(p), p.Attempts, p.Err)
+	}
+	return nil
+}
+
+// pushOutcome summarizes whether APNs accepted the push.
+func pushOutcome(p apns.PushEvent) string {
+	switch {
+	case p.Err == "":
+		return "sent"
+	case apns.TokenRejected(p.Reason):
+		return "token rejected"
+	default:
+		return "failed"
+	}
+}
+
+func pushStatus(p apns.PushEvent) string {
+	if p.Status == 0 {
+		return "-"
+	}
+	if p.Reason != "" {
+		return fmt.Sprintf("%d %s", p.Status, p.Reason)
+	}
+	return fmt.Sprint(p.Status)
+}
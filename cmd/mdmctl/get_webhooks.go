@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+type webhookTableOutput struct{ w *tabwriter.Writer }
+
+func (out *webhookTableOutput) BasicHeader() {
+	fmt.Fprintf(out.w, "ID\tTopic\tEventID\tURL\tAttempts\tFailedAt\tLastError\n")
+}
+
+func (out *webhookTableOutput) BasicFooter() {
+	out.w.Flush()
+}
+
+func (cmd *getCommand) getFailedWebhooks(args []string) error {
+	flagset := flag.NewFlagSet("failed-webhooks", flag.ExitOnError)
+	var (
+		flID = flagset.String("id", "", "print the request body of the failed delivery with this ID")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl get failed-webhooks [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	deliveries, err := cmd.webhooksvc.ListFailedDeliveries(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *flID != "" {
+		for _, d := range deliveries {
+			if d.ID == *flID {
+				fmt.Printf("URL: %s\nContentType: %s\n\n%s\n", d.URL, d.ContentType, d.Body)
+				return nil
+			}
+		}
+		return fmt.Errorf("failed delivery %s not found", *flID)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	out := &webhookTableOutput{w}
+	out.BasicHeader()
+	defer out.BasicFooter()
+	for _, d := range deliveries {
+		fmt.Fprintf(out.w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			d.ID, d.Topic, d.EventID, d.URL, d.Attempts, formatTime(d.FailedAt), d.LastError)
+	}
+	return nil
+}
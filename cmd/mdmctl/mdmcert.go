@@ -206,6 +206,7 @@ func (cmd *mdmcertCommand) runUpload(args []string) error {
 		flKeyPass  = flagset.String("password", "", "Password to encrypt/read the RSA key.")
 		flKeyPath  = flagset.String("private-key", filepath.Join(mdmcertdir, pushCertificatePrivateKeyFilename), "Path to the push certificate private key.")
 		flCertPath = flagset.String("cert", "", "Path to the MDM Push Certificate.")
+		flForce    = flagset.Bool("force", false, "Replace a push certificate with a different topic. Enrolled devices stop receiving pushes and must re-enroll.")
 	)
 	if err := flagset.Parse(args); err != nil {
 		return err
@@ -216,7 +217,7 @@ func (cmd *mdmcertCommand) runUpload(args []string) error {
 		return errors.Wrap(err, "load push certificate")
 	}
 
-	if err := cmd.configsvc.SavePushCertificate(context.Background(), cert, key); err != nil {
+	if err := cmd.configsvc.SavePushCertificate(context.Background(), cert, key, *flForce); err != nil {
 		return errors.Wrap(err, "upload push certificate and key to server")
 	}
 
@@ -35,9 +35,15 @@ func main() {
 	case "remove":
 		cmd := new(removeCommand)
 		run = cmd.Run
+	case "command":
+		cmd := new(commandCommand)
+		run = cmd.Run
 	case "mdmcert":
 		cmd := new(mdmcertCommand)
 		run = cmd.Run
+	case "watch":
+		cmd := new(watchCommand)
+		run = cmd.Run
 	default:
 		usage()
 		os.Exit(1)
@@ -57,7 +63,9 @@ Available Commands:
 	apply
 	config
 	remove
+	command
 	mdmcert
+	watch
 	version
 
 Use micromdm <command> -h for additional usage of each command.
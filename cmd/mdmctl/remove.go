@@ -46,6 +46,10 @@ func (cmd *removeCommand) Run(args []string) error {
 		run = cmd.removeProfiles
 	case "block":
 		run = cmd.removeBlock
+	case "commands":
+		run = cmd.removeCommands
+	case "push-failure":
+		run = cmd.removePushFailure
 	default:
 		cmd.Usage()
 		os.Exit(1)
@@ -63,6 +67,18 @@ Valid resource types:
   * blueprints
   * profiles
   * block
+  * commands
+  * push-failure
+
+Examples:
+  # Cancel a pending command.
+  mdmctl remove commands -udid=UDID -uuid=COMMAND_UUID
+
+  # Remove all pending commands of a device.
+  mdmctl remove commands -udid=UDID -all
+
+  # Resume pushes to a device whose push token APNs rejected.
+  mdmctl remove push-failure -udid=UDID
 `
 
 	fmt.Println(getUsage)
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (cmd *removeCommand) removeCommands(args []string) error {
+	flagset := flag.NewFlagSet("commands", flag.ExitOnError)
+	var (
+		flUDID = flagset.String("udid", "", "UDID of the device to remove commands from")
+		flUUID = flagset.String("uuid", "", "UUID of the pending command to cancel")
+		flAll  = flagset.Bool("all", false, "remove all pending commands of the device")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl remove commands [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	if *flUDID == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide a device UDID.")
+	}
+	if (*flUUID == "") == !*flAll {
+		flagset.Usage()
+		return errors.New("bad input: must provide either a command UUID or the -all flag.")
+	}
+
+	ctx := context.Background()
+	if *flAll {
+		if err := cmd.queuesvc.ClearCommands(ctx, *flUDID); err != nil {
+			return err
+		}
+	} else {
+		if err := cmd.queuesvc.CancelCommand(ctx, *flUDID, *flUUID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("success")
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (cmd *removeCommand) removePushFailure(args []string) error {
+	flagset := flag.NewFlagSet("push-failure", flag.ExitOnError)
+	var (
+		flUDID = flagset.String("udid", "", "UDID of the device to resume pushes to")
+	)
+	flagset.Usage = usageFor(flagset, "mdmctl remove push-failure [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	if *flUDID == "" {
+		flagset.Usage()
+		return errors.New("bad input: must provide a device UDID.")
+	}
+
+	ctx := context.Background()
+	if err := cmd.pushsvc.ResetPushFailure(ctx, *flUDID); err != nil {
+		return err
+	}
+
+	fmt.Println("success")
+
+	return nil
+}
@@ -4,14 +4,19 @@ import (
 	"github.com/go-kit/kit/log"
 	httptransport "github.com/go-kit/kit/transport/http"
 
+	"github.com/as/micromdm/platform/apns"
 	"github.com/as/micromdm/platform/appstore"
+	"github.com/as/micromdm/platform/archive"
 	"github.com/as/micromdm/platform/blueprint"
+	"github.com/as/micromdm/platform/command"
 	"github.com/as/micromdm/platform/config"
 	"github.com/as/micromdm/platform/dep"
 	"github.com/as/micromdm/platform/device"
 	"github.com/as/micromdm/platform/profile"
+	"github.com/as/micromdm/platform/queue"
 	"github.com/as/micromdm/platform/remove"
 	"github.com/as/micromdm/platform/user"
+	"github.com/as/micromdm/workflow/webhook"
 )
 
 type remoteServices struct {
@@ -23,6 +28,11 @@ type remoteServices struct {
 	configsvc    config.Service
 	appsvc       appstore.Service
 	depsvc       dep.Service
+	queuesvc     queue.Service
+	commandsvc   command.Service
+	webhooksvc   webhook.Service
+	archivesvc   archive.Service
+	pushsvc      apns.Service
 }
 
 func setupClient(logger log.Logger) (*remoteServices, error) {
@@ -87,6 +97,41 @@ func setupClient(logger log.Logger) (*remoteServices, error) {
 		return nil, err
 	}
 
+	queuesvc, err := queue.NewHTTPClient(
+		cfg.ServerURL, cfg.APIToken, logger,
+		httptransport.SetClient(skipVerifyHTTPClient(cfg.SkipVerify)))
+	if err != nil {
+		return nil, err
+	}
+
+	commandsvc, err := command.NewHTTPClient(
+		cfg.ServerURL, cfg.APIToken, logger,
+		httptransport.SetClient(skipVerifyHTTPClient(cfg.SkipVerify)))
+	if err != nil {
+		return nil, err
+	}
+
+	webhooksvc, err := webhook.NewHTTPClient(
+		cfg.ServerURL, cfg.APIToken, logger,
+		httptransport.SetClient(skipVerifyHTTPClient(cfg.SkipVerify)))
+	if err != nil {
+		return nil, err
+	}
+
+	archivesvc, err := archive.NewHTTPClient(
+		cfg.ServerURL, cfg.APIToken, logger,
+		httptransport.SetClient(skipVerifyHTTPClient(cfg.SkipVerify)))
+	if err != nil {
+		return nil, err
+	}
+
+	pushsvc, err := apns.NewHTTPClient(
+		cfg.ServerURL, cfg.APIToken, logger,
+		httptransport.SetClient(skipVerifyHTTPClient(cfg.SkipVerify)))
+	if err != nil {
+		return nil, err
+	}
+
 	return &remoteServices{
 		profilesvc:   profilesvc,
 		blueprintsvc: blueprintsvc,
@@ -96,5 +141,10 @@ func setupClient(logger log.Logger) (*remoteServices, error) {
 		configsvc:    configsvc,
 		appsvc:       appsvc,
 		depsvc:       depsvc,
+		queuesvc:     queuesvc,
+		commandsvc:   commandsvc,
+		webhooksvc:   webhooksvc,
+		archivesvc:   archivesvc,
+		pushsvc:      pushsvc,
 	}, nil
 }
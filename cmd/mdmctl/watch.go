@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/backoff"
+)
+
+type watchCommand struct {
+	config *ServerConfig
+}
+
+func (cmd *watchCommand) Run(args []string) error {
+	flagset := flag.NewFlagSet("watch", flag.ExitOnError)
+	var (
+		flTopics listFlag
+		flUDID   = flagset.String("udid", "", "only show events of the device with this UDID")
+		flJSON   = flagset.Bool("json", false, "print the JSON envelope of every event")
+	)
+	flagset.Var(&flTopics, "topic", "only show events of this topic, can be repeated or comma separated")
+	flagset.Usage = usageFor(flagset, "mdmctl watch [flags]")
+	if err := flagset.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := LoadServerConfig()
+	if err != nil {
+		return err
+	}
+	cmd.config = cfg
+
+	u, err := url.Parse(cfg.ServerURL)
+	if err != nil {
+		return errors.Wrap(err, "parse server url")
+	}
+	u.Path = "/v1/events"
+	q := url.Values{}
+	for _, topic := range flTopics {
+		q.Add("topic", topic)
+	}
+	if *flUDID != "" {
+		q.Set("udid", *flUDID)
+	}
+	u.RawQuery = q.Encode()
+
+	show := func(event, data string) error {
+		if *flJSON {
+			fmt.Println(data)
+			return nil
+		}
+		line, err := formatEvent(data)
+		if err != nil {
+			return err
+		}
+		fmt.Println(line)
+		return nil
+	}
+
+	// The stream ends when the server or a proxy closes the connection.
+	// Reconnect and resume after the last event received.
+	client := skipVerifyHTTPClient(cfg.SkipVerify)
+	reconnect := backoff.Policy{Backoff: time.Second, MaxBackoff: 30 * time.Second}
+	var lastEventID string
+	connected := false
+	for retry := 0; ; {
+		req, err := http.NewRequest("GET", u.String(), nil)
+		if err != nil {
+			return err
+		}
+		req.SetBasicAuth("micromdm", cfg.APIToken)
+		req.Header.Set("Accept", "text/event-stream")
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := client.Do(req)
+		if err != nil && !connected {
+			return errors.Wrap(err, "connect to event stream")
+		}
+		if err == nil {
+			if resp.StatusCode != http.StatusOK {
+				body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+				resp.Body.Close()
+				return fmt.Errorf("event stream: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+			}
+			connected, retry = true, 0
+			var printErr error
+			err = readEvents(resp.Body, func(id, event, data string) error {
+				if id != "" {
+					lastEventID = id
+				}
+				printErr = show(event, data)
+				return printErr
+			})
+			resp.Body.Close()
+			if printErr != nil {
+				return printErr
+			}
+		}
+		retry++
+		delay := reconnect.Delay(retry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "event stream: %s, reconnecting in %s\n", err, delay)
+		} else {
+			fmt.Fprintf(os.Stderr, "event stream closed, reconnecting in %s\n", delay)
+		}
+		time.Sleep(delay)
+	}
+}
+
+// readEvents calls fn with the last event ID, the name and the data of
+// every Server-Sent Event.
+func readEvents(r io.Reader, fn func(id, event, data string) error) error {
+	var id, event string
+	var data []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if err := fn(id, event, strings.Join(data, "\n")); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, ":"): // comment
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return scanner.Err()
+}
+
+type watchEnvelope struct {
+	Topic     string                 `json:"topic"`
+	CreatedAt time.Time              `json:"created_at"`
+	UDID      string                 `json:"udid"`
+	Payload   map[string]interface{} `json:"payload"`
+}
+
+// formatEvent returns a one line summary of a JSON event envelope.
+func formatEvent(data string) (string, error) {
+	var env watchEnvelope
+	if err := json.Unmarshal([]byte(data), &env); err != nil {
+		return "", errors.Wrap(err, "decode event")
+	}
+	udid := env.UDID
+	if udid == "" {
+		udid = "-"
+	}
+	return fmt.Sprintf("%s  %-20s  %-36s  %s",
+		env.CreatedAt.Local().Format("15:04:05"), env.Topic, udid, eventDetails(env)), nil
+}
+
+func eventDetails(env watchEnvelope) string {
+	p := env.Payload
+	str := func(key string) string {
+		s, _ := p[key].(string)
+		return s
+	}
+	switch env.Topic {
+	case "mdm.Authenticate", "mdm.TokenUpdate", "mdm.CheckOut", "mdm.DeviceEnrolled":
+		details := str("message_type")
+		if serial := str("serial_number"); serial != "" {
+			details += " serial=" + serial
+		}
+		if user := str("user_id"); user != "" {
+			details += " user=" + user
+		}
+		return details
+	case "mdm.Connect":
+		resp, _ := p["response"].(map[string]interface{})
+		status, _ := resp["Status"].(string)
+		uuid, _ := resp["CommandUUID"].(string)
+		details := status
+		if uuid != "" {
+			details += " command=" + uuid
+		}
+		if rt, _ := resp["request_type"].(string); rt != "" {
+			details += " " + rt
+		}
+		return details
+	case "mdm.Command":
+		return str("request_type") + " command=" + str("command_uuid")
+	case "mdm.CommandQueued":
+		return "queued command=" + str("command_uuid")
+	case "mdm.Push":
+		if e := str("error"); e != "" {
+			return "push failed: " + e
+		}
+		return "push id=" + str("push_notification_id")
+	case "mdm.DepSync":
+		devices, _ := p["devices"].([]interface{})
+		return fmt.Sprintf("%d devices", len(devices))
+	}
+	return ""
+}
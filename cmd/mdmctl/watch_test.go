@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadEvents(t *testing.T) {
+	stream := ": connected\n\n" +
+		"id: 1\nevent: mdm.Connect\ndata: {\"a\":1}\n\n" +
+		": keepalive\n\n" +
+		"event: mdm.Push\ndata: line1\ndata: line2\n\n"
+
+	type event struct{ id, name, data string }
+	var have []event
+	err := readEvents(strings.NewReader(stream), func(id, name, data string) error {
+		have = append(have, event{id, name, data})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []event{
+		{"1", "mdm.Connect", `{"a":1}`},
+		{"1", "mdm.Push", "line1\nline2"},
+	}
+	if len(have) != len(want) {
+		t.Fatalf("have %d events, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Errorf("event %d: have %+v, want %+v", i, have[i], want[i])
+		}
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{
+			data: `{"topic":"mdm.Authenticate","udid":"UDID-1","payload":{"message_type":"Authenticate","serial_number":"C02"}}`,
+			want: "Authenticate serial=C02",
+		},
+		{
+			data: `{"topic":"mdm.Connect","udid":"UDID-1","payload":{"response":{"Status":"Acknowledged","CommandUUID":"cmd-1","request_type":"DeviceInformation"}}}`,
+			want: "Acknowledged command=cmd-1 DeviceInformation",
+		},
+		{
+			data: `{"topic":"mdm.DepSync","payload":{"devices":[{},{}]}}`,
+			want: "2 devices",
+		},
+	}
+	for _, tt := range tests {
+		have, err := formatEvent(tt.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(have, tt.want) {
+			t.Errorf("have %q, want suffix %q", have, tt.want)
+		}
+	}
+}
@@ -110,31 +110,38 @@ func (w *watcher) updateClient(pubsub pubsub.Subscriber) error {
 	}
 
 	go func() {
-		for {
-			select {
-			case event := <-tokenAdded:
-				var token conf.DEPToken
-				if err := json.Unmarshal(event.Message, &token); err != nil {
-					log.Printf("unmarshalling tokenAdded to token: %s\n", err)
-					continue
-				}
-
-				client, err := token.Client()
-				if err != nil {
-					log.Printf("creating new DEP client: %s\n", err)
-					continue
-				}
-
-				w.mtx.Lock()
-				w.client = client
-				w.mtx.Unlock()
-				go func() { w.startSync <- true }() // unblock Run	//TODO(as): fix
+		for event := range tokenAdded {
+			if err := w.setClient(event); err != nil {
+				log.Println(err)
+			}
+			if err := event.Ack(); err != nil {
+				log.Println(err)
 			}
 		}
 	}()
 	return nil
 }
 
+// setClient replaces the DEP client with a client for the added token and
+// starts the sync.
+func (w *watcher) setClient(event pubsub.Event) error {
+	var token conf.DEPToken
+	if err := json.Unmarshal(event.Message, &token); err != nil {
+		return errors.Wrap(err, "unmarshalling tokenAdded to token")
+	}
+
+	client, err := token.Client()
+	if err != nil {
+		return errors.Wrap(err, "creating new DEP client")
+	}
+
+	w.mtx.Lock()
+	w.client = client
+	w.mtx.Unlock()
+	go func() { w.startSync <- true }() // unblock Run	//TODO(as): fix
+	return nil
+}
+
 // TODO this is private temporarily until the interface can be defined
 func (w *watcher) privateDEPSyncer() bool {
 	return true
@@ -21,6 +21,25 @@ const (
 	CheckoutTopic     = "mdm.CheckOut"
 )
 
+// ReplayTopic receives archived checkin events which are replayed to rebuild
+// derived stores such as devices and users. Subscribers update their state
+// like they do for the original checkin, but must not cause side effects
+// such as DeviceEnrolled events, queued commands, pushes or webhooks.
+const ReplayTopic = "mdm.CheckinReplay"
+
+// EventTopic returns the topic the checkin event was published to.
+func EventTopic(ev *Event) (string, error) {
+	switch ev.Command.MessageType {
+	case "Authenticate":
+		return AuthenticateTopic, nil
+	case "TokenUpdate":
+		return TokenUpdateTopic, nil
+	case "CheckOut":
+		return CheckoutTopic, nil
+	}
+	return "", fmt.Errorf("unknown checkin MessageType %q", ev.Command.MessageType)
+}
+
 type Checkin struct {
 	db        *bolt.DB
 	publisher pubsub.Publisher
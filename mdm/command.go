@@ -10,6 +10,20 @@ import (
 // CommandRequest represents an MDM command request
 type CommandRequest struct {
 	UDID string `json:"udid"`
+
+	// TTL is the number of seconds after which a command which was not
+	// acknowledged by the device expires. Zero means the command never expires.
+	TTL int64 `json:"ttl,omitempty"`
+
+	// Priority orders the commands in the queue of a device. Commands with a
+	// higher priority are sent first.
+	Priority int `json:"priority,omitempty"`
+
+	// After is a list of CommandUUIDs which must be acknowledged by the
+	// device before this command is sent. The command fails if one of them
+	// fails or expires, and waits for commands which are not queued.
+	After []string `json:"after,omitempty"`
+
 	Command
 }
 
@@ -41,6 +55,14 @@ type Command struct {
 	InstallMedia
 	RemoveMedia
 	Settings
+	ManagedApplicationList
+	RemoveApplication
+	InviteToProgram
+	ValidateApplications
+	ApplicationConfiguration
+	ManagedApplicationConfiguration
+	ManagedApplicationAttributes
+	ManagedApplicationFeedback
 }
 
 // The following commands are in the order provided by the apple documentation.
@@ -135,7 +157,7 @@ type ApplyRedemptionCode struct {
 }
 
 type ManagedApplicationList struct {
-	Identifiers []string `plist:",omitempty" json:"identifiers,omitempty"`
+	Identifiers []string `plist:",omitempty" json:"managed_app_identifiers,omitempty"`
 }
 
 type RemoveApplication struct {
@@ -148,7 +170,7 @@ type InviteToProgram struct {
 }
 
 type ValidateApplications struct {
-	Identifiers []string `plist:",omitempty" json:"identifiers,omitempty"`
+	Identifiers []string `plist:",omitempty" json:"validate_identifiers,omitempty"`
 }
 
 type InstallMedia struct {
@@ -169,7 +191,7 @@ type Settings struct {
 }
 
 type ManagedApplicationConfiguration struct {
-	Identifiers []string `plist:",omitempty" json:"identifiers,omitempty"`
+	Identifiers []string `plist:",omitempty" json:"configuration_identifiers,omitempty"`
 }
 
 type ApplicationConfiguration struct {
@@ -178,11 +200,11 @@ type ApplicationConfiguration struct {
 }
 
 type ManagedApplicationAttributes struct {
-	Identifiers []string `plist:",omitempty" json:"identifiers,omitempty"`
+	Identifiers []string `plist:",omitempty" json:"attribute_identifiers,omitempty"`
 }
 
 type ManagedApplicationFeedback struct {
-	Identifiers    []string `plist:",omitempty" json:"identifiers,omitempty"`
+	Identifiers    []string `plist:",omitempty" json:"feedback_identifiers,omitempty"`
 	DeleteFeedback bool     `plist:",omitempty" json:"delete_feedback,omitempty"`
 }
 
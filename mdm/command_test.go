@@ -135,6 +135,42 @@ func TestApplyRedemptionCode(t *testing.T) {
 	testMarshalPlist(t, cmd)
 }
 
+func TestManagedApplicationList(t *testing.T) {
+	cmd := ManagedApplicationList{Identifiers: []string{"io.micromdm.application"}}
+	testMarshalJSON(t, cmd)
+	testMarshalPlist(t, cmd)
+}
+
+func TestRemoveApplication(t *testing.T) {
+	cmd := RemoveApplication{Identifier: "io.micromdm.application"}
+	testMarshalJSON(t, cmd)
+	testMarshalPlist(t, cmd)
+}
+
+func TestInviteToProgram(t *testing.T) {
+	cmd := InviteToProgram{ProgramID: "com.apple.cloudvpp", InvitationURL: "https://example.com/invite"}
+	testMarshalJSON(t, cmd)
+	testMarshalPlist(t, cmd)
+}
+
+func TestValidateApplications(t *testing.T) {
+	cmd := ValidateApplications{Identifiers: []string{"io.micromdm.application"}}
+	testMarshalJSON(t, cmd)
+	testMarshalPlist(t, cmd)
+}
+
+func TestApplicationConfiguration(t *testing.T) {
+	cmd := ApplicationConfiguration{Identifier: "io.micromdm.application", Configuration: map[string]string{"key": "value"}}
+	testMarshalJSON(t, cmd)
+	testMarshalPlist(t, cmd)
+}
+
+func TestManagedApplicationFeedback(t *testing.T) {
+	cmd := ManagedApplicationFeedback{Identifiers: []string{"io.micromdm.application"}, DeleteFeedback: true}
+	testMarshalJSON(t, cmd)
+	testMarshalPlist(t, cmd)
+}
+
 func TestMarshalWithOverlappingKeys(t *testing.T) {
 	rp := RemoveProfile{Identifier: "io.micromdm.test.profile"}
 
@@ -168,6 +204,85 @@ func TestUnmarshalOverlaplingKeys(t *testing.T) {
 	}
 }
 
+func TestMarshalJSONOverlappingKeys(t *testing.T) {
+	tests := []CommandRequest{
+		{UDID: "abcd", Command: Command{
+			RequestType:       "RemoveApplication",
+			RemoveApplication: RemoveApplication{Identifier: "io.micromdm.application"},
+		}},
+		{UDID: "abcd", Command: Command{
+			RequestType:                "ManagedApplicationFeedback",
+			ManagedApplicationFeedback: ManagedApplicationFeedback{Identifiers: []string{"io.micromdm.application"}, DeleteFeedback: true},
+		}},
+		{UDID: "abcd", TTL: 60, Priority: 1, After: []string{"aaaa"}, Command: Command{
+			RequestType: "DeviceLock",
+			DeviceLock:  DeviceLock{PIN: "123456", Message: "Lost!"},
+		}},
+		{UDID: "abcd", Command: Command{RequestType: "RestartDevice"}},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var have CommandRequest
+		if err := json.Unmarshal(data, &have); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(have, tt) {
+			t.Errorf("%s: have %+v, want %+v", tt.RequestType, have, tt)
+		}
+	}
+}
+
+func TestCommandJSONAppIdentifiers(t *testing.T) {
+	ids := func(id string) []string { return []string{id} }
+	cmd := Command{
+		InstalledApplicationList:        InstalledApplicationList{Identifiers: ids("installed")},
+		ManagedApplicationList:          ManagedApplicationList{Identifiers: ids("managed")},
+		ValidateApplications:            ValidateApplications{Identifiers: ids("validate")},
+		ManagedApplicationConfiguration: ManagedApplicationConfiguration{Identifiers: ids("configuration")},
+		ManagedApplicationAttributes:    ManagedApplicationAttributes{Identifiers: ids("attributes")},
+		ManagedApplicationFeedback:      ManagedApplicationFeedback{Identifiers: ids("feedback")},
+	}
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have Command
+	if err := json.Unmarshal(data, &have); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(have, cmd) {
+		t.Errorf("have %+v, want %+v", have, cmd)
+	}
+}
+
+func TestUnmarshalQueueOptions(t *testing.T) {
+	data := []byte(`{
+		"request_type":"EraseDevice",
+		"udid":"abcd",
+		"ttl":3600,
+		"priority":10,
+		"after":["aaaa","bbbb"]
+	}`)
+
+	var req CommandRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := req.TTL, int64(3600); have != want {
+		t.Errorf("have %d, want %d", have, want)
+	}
+	if have, want := req.Priority, 10; have != want {
+		t.Errorf("have %d, want %d", have, want)
+	}
+	if have, want := req.After, []string{"aaaa", "bbbb"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+}
+
 func Test_PayloadWithNoFields(t *testing.T) {
 	data := []byte(`{
 		"request_type":"ProfileList",
@@ -92,23 +92,19 @@ func updateTopic(svc *service, sub pubsub.Subscriber) error {
 		return errors.Wrap(err, "update enrollment service")
 	}
 	go func() {
-		for {
-			select {
-			case <-configEvents:
-				topic, err := svc.topicProvier.PushTopic()
-				if err != nil {
-					log.Println("enroll: get push topic %s", topic)
-				}
-				svc.mu.Lock()	// TODO(as): fix
+		for event := range configEvents {
+			topic, err := svc.topicProvier.PushTopic()
+			if err != nil {
+				log.Printf("enroll: get push topic %s\n", err)
+			} else {
+				svc.mu.Lock() // TODO(as): fix
 				svc.Topic = topic
 				svc.mu.Unlock()
-
-				// terminate the loop here because the topic should never change
-				goto exit
+			}
+			if err := event.Ack(); err != nil {
+				log.Println(err)
 			}
 		}
-	exit:
-		return
 	}()
 	return nil
 }
@@ -212,6 +212,78 @@ func (c *Command) MarshalPlist() (interface{}, error) {
 			Settings:    c.Settings,
 		}, nil
 
+	case "ManagedApplicationList":
+		return &struct {
+			RequestType string
+			ManagedApplicationList
+		}{
+			RequestType:            c.RequestType,
+			ManagedApplicationList: c.ManagedApplicationList,
+		}, nil
+
+	case "RemoveApplication":
+		return &struct {
+			RequestType string
+			RemoveApplication
+		}{
+			RequestType:       c.RequestType,
+			RemoveApplication: c.RemoveApplication,
+		}, nil
+
+	case "InviteToProgram":
+		return &struct {
+			RequestType string
+			InviteToProgram
+		}{
+			RequestType:     c.RequestType,
+			InviteToProgram: c.InviteToProgram,
+		}, nil
+
+	case "ValidateApplications":
+		return &struct {
+			RequestType string
+			ValidateApplications
+		}{
+			RequestType:          c.RequestType,
+			ValidateApplications: c.ValidateApplications,
+		}, nil
+
+	case "ApplicationConfiguration":
+		return &struct {
+			RequestType string
+			ApplicationConfiguration
+		}{
+			RequestType:              c.RequestType,
+			ApplicationConfiguration: c.ApplicationConfiguration,
+		}, nil
+
+	case "ManagedApplicationConfiguration":
+		return &struct {
+			RequestType string
+			ManagedApplicationConfiguration
+		}{
+			RequestType:                     c.RequestType,
+			ManagedApplicationConfiguration: c.ManagedApplicationConfiguration,
+		}, nil
+
+	case "ManagedApplicationAttributes":
+		return &struct {
+			RequestType string
+			ManagedApplicationAttributes
+		}{
+			RequestType:                  c.RequestType,
+			ManagedApplicationAttributes: c.ManagedApplicationAttributes,
+		}, nil
+
+	case "ManagedApplicationFeedback":
+		return &struct {
+			RequestType string
+			ManagedApplicationFeedback
+		}{
+			RequestType:                c.RequestType,
+			ManagedApplicationFeedback: c.ManagedApplicationFeedback,
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("unknown request type: %s", c.RequestType)
 	}
@@ -219,11 +291,17 @@ func (c *Command) MarshalPlist() (interface{}, error) {
 
 func (c *CommandRequest) UnmarshalJSON(d []byte) error {
 	var j struct {
-		RequestType string `json:"request_type"`
+		RequestType string   `json:"request_type"`
+		TTL         int64    `json:"ttl"`
+		Priority    int      `json:"priority"`
+		After       []string `json:"after"`
 	}
 	if err := json.Unmarshal(d, &j); err != nil {
 		return err
 	}
+	c.TTL = j.TTL
+	c.Priority = j.Priority
+	c.After = j.After
 	switch j.RequestType {
 	case "ProfileList",
 		"ProvisioningProfileList",
@@ -533,7 +611,662 @@ func (c *CommandRequest) UnmarshalJSON(d []byte) error {
 		c.Settings = x.Settings
 		return nil
 
+	case "ManagedApplicationList":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			ManagedApplicationList
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.ManagedApplicationList = x.ManagedApplicationList
+		return nil
+
+	case "RemoveApplication":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			RemoveApplication
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.RemoveApplication = x.RemoveApplication
+		return nil
+
+	case "InviteToProgram":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			InviteToProgram
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.InviteToProgram = x.InviteToProgram
+		return nil
+
+	case "ValidateApplications":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			ValidateApplications
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.ValidateApplications = x.ValidateApplications
+		return nil
+
+	case "ApplicationConfiguration":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			ApplicationConfiguration
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.ApplicationConfiguration = x.ApplicationConfiguration
+		return nil
+
+	case "ManagedApplicationConfiguration":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			ManagedApplicationConfiguration
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.ManagedApplicationConfiguration = x.ManagedApplicationConfiguration
+		return nil
+
+	case "ManagedApplicationAttributes":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			ManagedApplicationAttributes
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.ManagedApplicationAttributes = x.ManagedApplicationAttributes
+		return nil
+
+	case "ManagedApplicationFeedback":
+		var x struct {
+			RequestType string `json:"request_type"`
+			UDID        string `json:"udid"`
+			ManagedApplicationFeedback
+		}
+		if err := json.Unmarshal(d, &x); err != nil {
+			return err
+		}
+		c.RequestType = x.RequestType
+		c.UDID = x.UDID
+		c.ManagedApplicationFeedback = x.ManagedApplicationFeedback
+		return nil
+
 	default:
 		return fmt.Errorf("mdm: unknown request_type %s", j.RequestType)
 	}
 }
+
+func (c CommandRequest) MarshalJSON() ([]byte, error) {
+	switch c.RequestType {
+	case "ProfileList",
+		"ProvisioningProfileList",
+		"CertificateList",
+		"SecurityInfo",
+		"StopMirroring",
+		"ClearRestrictionsPassword",
+		"LogOutUser",
+		"DisableLostMode",
+		"DeviceLocation",
+		"ManagedMediaList",
+		"OSUpdateStatus",
+		"DeviceConfigured",
+		"AvailableOSUpdates",
+		"Restrictions",
+		"UserList",
+		"ShutDownDevice",
+		"RestartDevice":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+		}{
+			RequestType: c.RequestType,
+			UDID:        c.UDID,
+			TTL:         c.TTL,
+			Priority:    c.Priority,
+			After:       c.After,
+		}
+		return json.Marshal(&x)
+
+	case "DeviceInformation":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			DeviceInformation
+		}{
+			RequestType:       c.RequestType,
+			UDID:              c.UDID,
+			TTL:               c.TTL,
+			Priority:          c.Priority,
+			After:             c.After,
+			DeviceInformation: c.DeviceInformation,
+		}
+		return json.Marshal(&x)
+
+	case "InstallApplication":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			InstallApplication
+		}{
+			RequestType:        c.RequestType,
+			UDID:               c.UDID,
+			TTL:                c.TTL,
+			Priority:           c.Priority,
+			After:              c.After,
+			InstallApplication: c.InstallApplication,
+		}
+		return json.Marshal(&x)
+
+	case "AccountConfiguration":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			AccountConfiguration
+		}{
+			RequestType:          c.RequestType,
+			UDID:                 c.UDID,
+			TTL:                  c.TTL,
+			Priority:             c.Priority,
+			After:                c.After,
+			AccountConfiguration: c.AccountConfiguration,
+		}
+		return json.Marshal(&x)
+
+	case "ScheduleOSUpdateScan":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ScheduleOSUpdateScan
+		}{
+			RequestType:          c.RequestType,
+			UDID:                 c.UDID,
+			TTL:                  c.TTL,
+			Priority:             c.Priority,
+			After:                c.After,
+			ScheduleOSUpdateScan: c.ScheduleOSUpdateScan,
+		}
+		return json.Marshal(&x)
+
+	case "ScheduleOSUpdate":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ScheduleOSUpdate
+		}{
+			RequestType:      c.RequestType,
+			UDID:             c.UDID,
+			TTL:              c.TTL,
+			Priority:         c.Priority,
+			After:            c.After,
+			ScheduleOSUpdate: c.ScheduleOSUpdate,
+		}
+		return json.Marshal(&x)
+
+	case "InstallProfile":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			InstallProfile
+		}{
+			RequestType:    c.RequestType,
+			UDID:           c.UDID,
+			TTL:            c.TTL,
+			Priority:       c.Priority,
+			After:          c.After,
+			InstallProfile: c.InstallProfile,
+		}
+		return json.Marshal(&x)
+
+	case "RemoveProfile":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			RemoveProfile
+		}{
+			RequestType:   c.RequestType,
+			UDID:          c.UDID,
+			TTL:           c.TTL,
+			Priority:      c.Priority,
+			After:         c.After,
+			RemoveProfile: c.RemoveProfile,
+		}
+		return json.Marshal(&x)
+
+	case "InstallProvisioningProfile":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			InstallProvisioningProfile
+		}{
+			RequestType:                c.RequestType,
+			UDID:                       c.UDID,
+			TTL:                        c.TTL,
+			Priority:                   c.Priority,
+			After:                      c.After,
+			InstallProvisioningProfile: c.InstallProvisioningProfile,
+		}
+		return json.Marshal(&x)
+
+	case "RemoveProvisioningProfile":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			RemoveProvisioningProfile
+		}{
+			RequestType:               c.RequestType,
+			UDID:                      c.UDID,
+			TTL:                       c.TTL,
+			Priority:                  c.Priority,
+			After:                     c.After,
+			RemoveProvisioningProfile: c.RemoveProvisioningProfile,
+		}
+		return json.Marshal(&x)
+
+	case "InstalledApplicationList":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			InstalledApplicationList
+		}{
+			RequestType:              c.RequestType,
+			UDID:                     c.UDID,
+			TTL:                      c.TTL,
+			Priority:                 c.Priority,
+			After:                    c.After,
+			InstalledApplicationList: c.InstalledApplicationList,
+		}
+		return json.Marshal(&x)
+
+	case "DeviceLock":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			DeviceLock
+		}{
+			RequestType: c.RequestType,
+			UDID:        c.UDID,
+			TTL:         c.TTL,
+			Priority:    c.Priority,
+			After:       c.After,
+			DeviceLock:  c.DeviceLock,
+		}
+		return json.Marshal(&x)
+
+	case "ClearPasscode":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ClearPasscode
+		}{
+			RequestType:   c.RequestType,
+			UDID:          c.UDID,
+			TTL:           c.TTL,
+			Priority:      c.Priority,
+			After:         c.After,
+			ClearPasscode: c.ClearPasscode,
+		}
+		return json.Marshal(&x)
+
+	case "EraseDevice":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			EraseDevice
+		}{
+			RequestType: c.RequestType,
+			UDID:        c.UDID,
+			TTL:         c.TTL,
+			Priority:    c.Priority,
+			After:       c.After,
+			EraseDevice: c.EraseDevice,
+		}
+		return json.Marshal(&x)
+
+	case "RequestMirroring":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			RequestMirroring
+		}{
+			RequestType:      c.RequestType,
+			UDID:             c.UDID,
+			TTL:              c.TTL,
+			Priority:         c.Priority,
+			After:            c.After,
+			RequestMirroring: c.RequestMirroring,
+		}
+		return json.Marshal(&x)
+
+	case "DeleteUser":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			DeleteUser
+		}{
+			RequestType: c.RequestType,
+			UDID:        c.UDID,
+			TTL:         c.TTL,
+			Priority:    c.Priority,
+			After:       c.After,
+			DeleteUser:  c.DeleteUser,
+		}
+		return json.Marshal(&x)
+
+	case "EnableLostMode":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			EnableLostMode
+		}{
+			RequestType:    c.RequestType,
+			UDID:           c.UDID,
+			TTL:            c.TTL,
+			Priority:       c.Priority,
+			After:          c.After,
+			EnableLostMode: c.EnableLostMode,
+		}
+		return json.Marshal(&x)
+
+	case "ApplyRedemptionCode":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ApplyRedemptionCode
+		}{
+			RequestType:         c.RequestType,
+			UDID:                c.UDID,
+			TTL:                 c.TTL,
+			Priority:            c.Priority,
+			After:               c.After,
+			ApplyRedemptionCode: c.ApplyRedemptionCode,
+		}
+		return json.Marshal(&x)
+
+	case "InstallMedia":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			InstallMedia
+		}{
+			RequestType:  c.RequestType,
+			UDID:         c.UDID,
+			TTL:          c.TTL,
+			Priority:     c.Priority,
+			After:        c.After,
+			InstallMedia: c.InstallMedia,
+		}
+		return json.Marshal(&x)
+
+	case "RemoveMedia":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			RemoveMedia
+		}{
+			RequestType: c.RequestType,
+			UDID:        c.UDID,
+			TTL:         c.TTL,
+			Priority:    c.Priority,
+			After:       c.After,
+			RemoveMedia: c.RemoveMedia,
+		}
+		return json.Marshal(&x)
+
+	case "Settings":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			Settings
+		}{
+			RequestType: c.RequestType,
+			UDID:        c.UDID,
+			TTL:         c.TTL,
+			Priority:    c.Priority,
+			After:       c.After,
+			Settings:    c.Settings,
+		}
+		return json.Marshal(&x)
+
+	case "ManagedApplicationList":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ManagedApplicationList
+		}{
+			RequestType:            c.RequestType,
+			UDID:                   c.UDID,
+			TTL:                    c.TTL,
+			Priority:               c.Priority,
+			After:                  c.After,
+			ManagedApplicationList: c.ManagedApplicationList,
+		}
+		return json.Marshal(&x)
+
+	case "RemoveApplication":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			RemoveApplication
+		}{
+			RequestType:       c.RequestType,
+			UDID:              c.UDID,
+			TTL:               c.TTL,
+			Priority:          c.Priority,
+			After:             c.After,
+			RemoveApplication: c.RemoveApplication,
+		}
+		return json.Marshal(&x)
+
+	case "InviteToProgram":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			InviteToProgram
+		}{
+			RequestType:     c.RequestType,
+			UDID:            c.UDID,
+			TTL:             c.TTL,
+			Priority:        c.Priority,
+			After:           c.After,
+			InviteToProgram: c.InviteToProgram,
+		}
+		return json.Marshal(&x)
+
+	case "ValidateApplications":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ValidateApplications
+		}{
+			RequestType:          c.RequestType,
+			UDID:                 c.UDID,
+			TTL:                  c.TTL,
+			Priority:             c.Priority,
+			After:                c.After,
+			ValidateApplications: c.ValidateApplications,
+		}
+		return json.Marshal(&x)
+
+	case "ApplicationConfiguration":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ApplicationConfiguration
+		}{
+			RequestType:              c.RequestType,
+			UDID:                     c.UDID,
+			TTL:                      c.TTL,
+			Priority:                 c.Priority,
+			After:                    c.After,
+			ApplicationConfiguration: c.ApplicationConfiguration,
+		}
+		return json.Marshal(&x)
+
+	case "ManagedApplicationConfiguration":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ManagedApplicationConfiguration
+		}{
+			RequestType:                     c.RequestType,
+			UDID:                            c.UDID,
+			TTL:                             c.TTL,
+			Priority:                        c.Priority,
+			After:                           c.After,
+			ManagedApplicationConfiguration: c.ManagedApplicationConfiguration,
+		}
+		return json.Marshal(&x)
+
+	case "ManagedApplicationAttributes":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ManagedApplicationAttributes
+		}{
+			RequestType:                  c.RequestType,
+			UDID:                         c.UDID,
+			TTL:                          c.TTL,
+			Priority:                     c.Priority,
+			After:                        c.After,
+			ManagedApplicationAttributes: c.ManagedApplicationAttributes,
+		}
+		return json.Marshal(&x)
+
+	case "ManagedApplicationFeedback":
+		x := struct {
+			RequestType string   `json:"request_type"`
+			UDID        string   `json:"udid"`
+			TTL         int64    `json:"ttl,omitempty"`
+			Priority    int      `json:"priority,omitempty"`
+			After       []string `json:"after,omitempty"`
+			ManagedApplicationFeedback
+		}{
+			RequestType:                c.RequestType,
+			UDID:                       c.UDID,
+			TTL:                        c.TTL,
+			Priority:                   c.Priority,
+			After:                      c.After,
+			ManagedApplicationFeedback: c.ManagedApplicationFeedback,
+		}
+		return json.Marshal(&x)
+
+	default:
+		return nil, fmt.Errorf("mdm: unknown request_type %s", c.RequestType)
+	}
+}
@@ -69,6 +69,30 @@ func NewPayload(request *CommandRequest) (*Payload, error) {
 	case "Settings":
 		payload.Command.Settings = request.Settings
 
+	case "ManagedApplicationList":
+		payload.Command.ManagedApplicationList = request.ManagedApplicationList
+
+	case "RemoveApplication":
+		payload.Command.RemoveApplication = request.RemoveApplication
+
+	case "InviteToProgram":
+		payload.Command.InviteToProgram = request.InviteToProgram
+
+	case "ValidateApplications":
+		payload.Command.ValidateApplications = request.ValidateApplications
+
+	case "ApplicationConfiguration":
+		payload.Command.ApplicationConfiguration = request.ApplicationConfiguration
+
+	case "ManagedApplicationConfiguration":
+		payload.Command.ManagedApplicationConfiguration = request.ManagedApplicationConfiguration
+
+	case "ManagedApplicationAttributes":
+		payload.Command.ManagedApplicationAttributes = request.ManagedApplicationAttributes
+
+	case "ManagedApplicationFeedback":
+		payload.Command.ManagedApplicationFeedback = request.ManagedApplicationFeedback
+
 	case "ProfileList",
 		"ProvisioningProfileList",
 		"CertificateList",
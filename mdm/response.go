@@ -18,6 +18,12 @@ type Response struct {
 	OSUpdateStatus           OSUpdateStatusResponse           `json:"os_update_status,omitempty" plist:",omitempty"`
 	AvailableOSUpdates       AvailableOSUpdatesResponse       `json:"available_os_updates,omitempty" plist:",omitempty"`
 	ProfileList              ProfileList                      `json:"profile_list,omitempty" plist:",omitempty"`
+
+	ManagedApplicationList     ManagedApplicationListResponse     `json:"managed_application_list,omitempty" plist:",omitempty"`
+	InvitationResult           string                             `json:"invitation_result,omitempty" plist:",omitempty"`
+	ApplicationConfigurations  ApplicationConfigurationsResponse  `json:"application_configurations,omitempty" plist:",omitempty"`
+	ApplicationAttributes      ApplicationAttributesResponse      `json:"application_attributes,omitempty" plist:",omitempty"`
+	ManagedApplicationFeedback ManagedApplicationFeedbackResponse `json:"managed_application_feedback,omitempty" plist:",omitempty"`
 }
 
 type AvailableOSUpdatesResponse []AvailableOSUpdatesResponseItem
@@ -89,6 +95,45 @@ type InstalledApplicationListItem struct {
 
 type InstalledApplicationListResponse []InstalledApplicationListItem
 
+// ManagedApplicationListItem is the status of a managed app.
+type ManagedApplicationListItem struct {
+	Status                    string `plist:",omitempty" json:"status,omitempty"`
+	ManagementFlags           int    `plist:",omitempty" json:"management_flags,omitempty"`
+	UnusedRedemptionCode      string `plist:",omitempty" json:"unused_redemption_code,omitempty"`
+	HasConfiguration          bool   `plist:",omitempty" json:"has_configuration,omitempty"`
+	HasFeedback               bool   `plist:",omitempty" json:"has_feedback,omitempty"`
+	IsValidated               bool   `plist:",omitempty" json:"is_validated,omitempty"`
+	ExternalVersionIdentifier int    `plist:",omitempty" json:"external_version_identifier,omitempty"`
+}
+
+// ManagedApplicationListResponse is the ManagedApplicationList MDM Command Response,
+// keyed by app identifier.
+type ManagedApplicationListResponse map[string]ManagedApplicationListItem
+
+type ApplicationConfigurationItem struct {
+	Identifier    string                 `json:"identifier"`
+	Configuration map[string]interface{} `plist:",omitempty" json:"configuration,omitempty"`
+}
+
+// ApplicationConfigurationsResponse is the ManagedApplicationConfiguration MDM Command Response.
+type ApplicationConfigurationsResponse []ApplicationConfigurationItem
+
+type ApplicationAttributesItem struct {
+	Identifier string            `json:"identifier"`
+	Attributes map[string]string `plist:",omitempty" json:"attributes,omitempty"`
+}
+
+// ApplicationAttributesResponse is the ManagedApplicationAttributes MDM Command Response.
+type ApplicationAttributesResponse []ApplicationAttributesItem
+
+type ManagedApplicationFeedbackItem struct {
+	Identifier string                 `json:"identifier"`
+	Feedback   map[string]interface{} `plist:",omitempty" json:"feedback,omitempty"`
+}
+
+// ManagedApplicationFeedbackResponse is the ManagedApplicationFeedback MDM Command Response.
+type ManagedApplicationFeedbackResponse []ManagedApplicationFeedbackItem
+
 // CommonQueryResponses has a list of query responses common to all device types
 type CommonQueryResponses struct {
 	UDID                  string            `json:"udid"`
@@ -141,3 +141,40 @@ func TestProfileListResponse(t *testing.T) {
 
 	fmt.Printf("%v\n", response)
 }
+
+func TestManagedApplicationListResponse(t *testing.T) {
+	body, err := ioutil.ReadFile("./test/responses/managed_application_list.plist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := &Response{}
+	if err := plist.Unmarshal(body, response); err != nil {
+		t.Fatal(err)
+	}
+
+	app, ok := response.ManagedApplicationList["com.apple.Keynote"]
+	if !ok {
+		t.Fatal("No com.apple.Keynote in ManagedApplicationList response")
+	}
+	if app.Status != "Managed" || !app.HasFeedback || app.ManagementFlags != 1 {
+		t.Errorf("unexpected managed application status %+v", app)
+	}
+}
+
+func TestManagedApplicationFeedbackResponse(t *testing.T) {
+	body, err := ioutil.ReadFile("./test/responses/managed_application_feedback.plist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := &Response{}
+	if err := plist.Unmarshal(body, response); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(response.ManagedApplicationFeedback) != 1 {
+		t.Fatalf("have %d feedback items, want 1", len(response.ManagedApplicationFeedback))
+	}
+	if feedback := response.ManagedApplicationFeedback[0]; feedback.Feedback["LastSync"] != "2018-05-01T10:00:00Z" {
+		t.Errorf("unexpected feedback %+v", feedback)
+	}
+}
@@ -0,0 +1,58 @@
+// Package backoff implements exponential backoff for operations which are
+// retried after a failure.
+package backoff
+
+import (
+	"context"
+	"time"
+)
+
+// Policy controls how often and how quickly a failed operation is retried.
+type Policy struct {
+	// MaxAttempts is the number of times the operation is tried,
+	// including the first attempt.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. The delay doubles
+	// with each retry.
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between retries. Zero means no cap.
+	MaxBackoff time.Duration
+}
+
+// Attempts returns MaxAttempts, but at least one attempt.
+func (p Policy) Attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// Delay returns the delay before the retry, counting from one.
+func (p Policy) Delay(retry int) time.Duration {
+	if p.Backoff <= 0 || retry <= 0 {
+		return 0
+	}
+	d := p.Backoff
+	for i := 1; i < retry && d < 1<<62; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+// Sleep waits for the duration, or returns the error of the context if it
+// is done first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
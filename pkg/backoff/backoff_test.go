@@ -0,0 +1,55 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{Backoff: time.Minute, MaxBackoff: 10 * time.Minute}
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{0, 0},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{5, 10 * time.Minute},
+		{100, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if have := p.Delay(tt.retry); have != tt.want {
+			t.Errorf("retry %d: have %s, want %s", tt.retry, have, tt.want)
+		}
+	}
+
+	uncapped := Policy{Backoff: time.Second}
+	if have, want := uncapped.Delay(100), time.Duration(1<<62); have < want {
+		t.Errorf("have %s, want at least %s", have, want)
+	}
+	if have := (Policy{}).Delay(3); have != 0 {
+		t.Errorf("have %s without backoff, want 0", have)
+	}
+}
+
+func TestPolicy_Attempts(t *testing.T) {
+	if have := (Policy{}).Attempts(); have != 1 {
+		t.Errorf("have %d attempts, want 1", have)
+	}
+	if have := (Policy{MaxAttempts: 3}).Attempts(); have != 3 {
+		t.Errorf("have %d attempts, want 3", have)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Sleep(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("have error %v, want %v", err, context.Canceled)
+	}
+	if err := Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Error(err)
+	}
+}
@@ -2,36 +2,67 @@ package builtin
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/pkg/errors"
 
 	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/mdm/connect"
 	"github.com/as/micromdm/platform/apns"
 	"github.com/as/micromdm/platform/pubsub"
 )
 
 const PushBucket = "mdm.PushInfo"
 
+// PushHistoryBucket holds a nested bucket of push events for every device.
+const PushHistoryBucket = "mdm.PushHistory"
+
+// DefaultHistoryMax is the number of pushes kept per device by a DB created
+// without WithHistoryMax.
+const DefaultHistoryMax = 100
+
 type DB struct {
 	*bolt.DB
+	historyMax int
+}
+
+type Option func(*DB)
+
+// WithHistoryMax sets the number of pushes kept per device.
+// Zero keeps every push.
+func WithHistoryMax(n int) Option {
+	return func(db *DB) {
+		db.historyMax = n
+	}
 }
 
-func NewDB(db *bolt.DB, sub pubsub.Subscriber) (*DB, error) {
+func NewDB(db *bolt.DB, sub pubsub.Subscriber, opts ...Option) (*DB, error) {
 	err := db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte(PushBucket))
+		if _, err := tx.CreateBucketIfNotExists([]byte(PushBucket)); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists([]byte(PushHistoryBucket))
 		return err
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "creating %s bucket", PushBucket)
 	}
 	datastore := &DB{
-		DB: db,
+		DB:         db,
+		historyMax: DefaultHistoryMax,
+	}
+	for _, opt := range opts {
+		opt(datastore)
 	}
 	if err := datastore.pollCheckin(sub); err != nil {
 		return nil, err
 	}
+	if err := datastore.pollPushes(sub); err != nil {
+		return nil, err
+	}
 	return datastore, nil
 }
 
@@ -80,39 +111,238 @@ func (db *DB) Save(info *apns.PushInfo) error {
 	return tx.Commit()
 }
 
+// MarkUnreachable records that APNs rejected the token of the device.
+// A TokenUpdate saves new PushInfo, which clears the failure, as does a
+// Connect of the device.
+func (db *DB) MarkUnreachable(udid, token, reason string, at time.Time) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(PushBucket))
+		v := b.Get([]byte(udid))
+		if v == nil {
+			return &notFound{"PushInfo", fmt.Sprintf("udid %s", udid)}
+		}
+		var info apns.PushInfo
+		if err := apns.UnmarshalPushInfo(v, &info); err != nil {
+			return err
+		}
+		if info.Token != token {
+			return nil // the device sent a new token.
+		}
+		info.FailureReason, info.FailedAt = reason, at
+		data, err := apns.MarshalPushInfo(&info)
+		if err != nil {
+			return errors.Wrap(err, "marshalling PushInfo")
+		}
+		return b.Put([]byte(udid), data)
+	})
+}
+
+// ClearFailure resumes the pushes to a device whose token was rejected.
+func (db *DB) ClearFailure(udid string) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(PushBucket))
+		v := b.Get([]byte(udid))
+		if v == nil {
+			return &notFound{"PushInfo", fmt.Sprintf("udid %s", udid)}
+		}
+		var info apns.PushInfo
+		if err := apns.UnmarshalPushInfo(v, &info); err != nil {
+			return err
+		}
+		if !info.Unreachable() {
+			return nil
+		}
+		info.FailureReason, info.FailedAt = "", time.Time{}
+		data, err := apns.MarshalPushInfo(&info)
+		if err != nil {
+			return errors.Wrap(err, "marshalling PushInfo")
+		}
+		return b.Put([]byte(udid), data)
+	})
+}
+
 func (db *DB) pollCheckin(sub pubsub.Subscriber) error {
 	tokenUpdateEvents, err := sub.Subscribe(context.TODO(), "push-info", checkin.TokenUpdateTopic)
 	if err != nil {
 		return errors.Wrapf(err,
 			"subscribing push to %s topic", checkin.TokenUpdateTopic)
 	}
+	replayEvents, err := sub.Subscribe(context.TODO(), "push-info", checkin.ReplayTopic)
+	if err != nil {
+		return errors.Wrapf(err,
+			"subscribing push to %s topic", checkin.ReplayTopic)
+	}
+	connectEvents, err := sub.Subscribe(context.TODO(), "push-info", connect.ConnectTopic)
+	if err != nil {
+		return errors.Wrapf(err,
+			"subscribing push to %s topic", connect.ConnectTopic)
+	}
 	go func() {
 		for {
+			var (
+				event pubsub.Event
+				err   error
+			)
 			select {
-			case event := <-tokenUpdateEvents:
-				var ev checkin.Event
-				if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				info := apns.PushInfo{
-					UDID:      ev.Command.UDID,
-					Token:     ev.Command.Token.String(),
-					PushMagic: ev.Command.PushMagic,
-					MDMTopic:  ev.Command.Topic,
-				}
-				if ev.Command.UserID != "" {
-					// use the GUID if this is a user TokenUpdate.
-					info.UDID = ev.Command.UserID
-				}
-				if err := db.Save(&info); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				fmt.Printf("updated pushinfo for udid %s\n", info.UDID)
+			case event = <-tokenUpdateEvents:
+				err = db.handleTokenUpdate(event, false)
+			case event = <-replayEvents:
+				err = db.handleTokenUpdate(event, true)
+			case event = <-connectEvents:
+				err = db.handleConnect(event)
+			}
+			if err != nil {
+				fmt.Println(err)
+			}
+			if err := event.Ack(); err != nil {
+				fmt.Println(err)
 			}
 		}
 	}()
 
 	return nil
 }
+
+// handleTokenUpdate saves the push token of a device or user.
+// Replayed checkins of other message types are ignored, as are replayed
+// TokenUpdates older than the stored PushInfo. A replayed TokenUpdate keeps
+// the push failure of the stored PushInfo.
+func (db *DB) handleTokenUpdate(event pubsub.Event, replay bool) error {
+	var ev checkin.Event
+	if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	if ev.Command.MessageType != "TokenUpdate" {
+		return nil
+	}
+	info := apns.PushInfo{
+		UDID:      ev.Command.UDID,
+		Token:     ev.Command.Token.String(),
+		PushMagic: ev.Command.PushMagic,
+		MDMTopic:  ev.Command.Topic,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if ev.Command.UserID != "" {
+		// use the GUID if this is a user TokenUpdate.
+		info.UDID = ev.Command.UserID
+	}
+	if replay {
+		info.UpdatedAt = ev.Time
+		stored, err := db.PushInfo(info.UDID)
+		if err == nil {
+			// PushInfo saved before the time was stored has the latest token.
+			if stored.UpdatedAt.IsZero() || !stored.UpdatedAt.Before(ev.Time) {
+				return nil
+			}
+			info.FailureReason, info.FailedAt = stored.FailureReason, stored.FailedAt
+		} else if _, ok := err.(*notFound); !ok {
+			return err
+		}
+	}
+	if err := db.Save(&info); err != nil {
+		return err
+	}
+	fmt.Printf("updated pushinfo for udid %s\n", info.UDID)
+	return nil
+}
+
+// handleConnect clears the push failure of a device which connected, as
+// the device received a push with its token.
+func (db *DB) handleConnect(event pubsub.Event) error {
+	var ev connect.Event
+	if err := connect.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	udid := ev.Response.UDID
+	if ev.Response.UserID != nil && *ev.Response.UserID != "" {
+		udid = *ev.Response.UserID
+	}
+	err := db.ClearFailure(udid)
+	if _, ok := err.(*notFound); ok {
+		return nil
+	}
+	return err
+}
+
+// SavePush adds the result of a push to the history of the device and
+// removes the oldest pushes above the history limit.
+func (db *DB) SavePush(ev *apns.PushEvent) error {
+	data, err := apns.MarshalPushEvent(ev)
+	if err != nil {
+		return errors.Wrap(err, "marshal push event")
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket([]byte(PushHistoryBucket)).CreateBucketIfNotExists([]byte(ev.DeviceUDID))
+		if err != nil {
+			return err
+		}
+		if err := b.Put(historyKey(ev), data); err != nil {
+			return err
+		}
+		if db.historyMax <= 0 {
+			return nil
+		}
+		var kept int
+		c := b.Cursor()
+		for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+			if kept++; kept <= db.historyMax {
+				continue
+			}
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// historyKey orders the pushes of a device by time.
+func historyKey(ev *apns.PushEvent) []byte {
+	key := make([]byte, 8, 8+len(ev.ID))
+	binary.BigEndian.PutUint64(key, uint64(ev.Time.UnixNano()))
+	return append(key, ev.ID...)
+}
+
+// PushHistory returns up to limit of the latest pushes to the device,
+// newest first. A limit of zero returns every stored push.
+func (db *DB) PushHistory(udid string, limit int) ([]apns.PushEvent, error) {
+	history := []apns.PushEvent{}
+	err := db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(PushHistoryBucket)).Bucket([]byte(udid))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil && (limit <= 0 || len(history) < limit); k, v = c.Prev() {
+			var ev apns.PushEvent
+			if err := apns.UnmarshalPushEvent(v, &ev); err != nil {
+				return err
+			}
+			history = append(history, ev)
+		}
+		return nil
+	})
+	return history, err
+}
+
+func (db *DB) pollPushes(sub pubsub.Subscriber) error {
+	pushEvents, err := sub.Subscribe(context.TODO(), "push-history", apns.PushTopic)
+	if err != nil {
+		return errors.Wrapf(err,
+			"subscribing push history to %s topic", apns.PushTopic)
+	}
+	go func() {
+		for event := range pushEvents {
+			var ev apns.PushEvent
+			if err := apns.UnmarshalPushEvent(event.Message, &ev); err != nil {
+				fmt.Println(err)
+			} else if err := db.SavePush(&ev); err != nil {
+				fmt.Println(errors.Wrapf(err, "save push to %s", ev.DeviceUDID))
+			}
+			if err := event.Ack(); err != nil {
+				fmt.Println(err)
+			}
+		}
+	}()
+	return nil
+}
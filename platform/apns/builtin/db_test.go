@@ -0,0 +1,175 @@
+package builtin
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/platform/apns"
+	"github.com/as/micromdm/platform/pubsub"
+	"github.com/as/micromdm/platform/pubsub/inmem"
+)
+
+func TestPushHistory(t *testing.T) {
+	db := setupDB(t, WithHistoryMax(3))
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		ev := apns.NewPushEvent("udid", "", nil)
+		ev.Time = start.Add(time.Duration(i) * time.Minute)
+		ev.PushNotificationID = ev.Time.Format(time.Kitchen)
+		if err := db.SavePush(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.SavePush(apns.NewPushEvent("other", "", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := db.PushHistory("udid", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have []string
+	for _, ev := range history {
+		have = append(have, ev.PushNotificationID)
+	}
+	want := []string{"12:04AM", "12:03AM", "12:02AM"}
+	if len(have) != len(want) {
+		t.Fatalf("have pushes %v, want %v", have, want)
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Fatalf("have pushes %v, want %v", have, want)
+		}
+	}
+
+	if history, _ := db.PushHistory("udid", 1); len(history) != 1 || history[0].PushNotificationID != "12:04AM" {
+		t.Errorf("expected the latest push, got %v", history)
+	}
+	if history, _ := db.PushHistory("unknown", 0); len(history) != 0 {
+		t.Errorf("expected no pushes, got %d", len(history))
+	}
+}
+
+func TestMarkUnreachable(t *testing.T) {
+	db := setupDB(t)
+	if err := db.Save(&apns.PushInfo{UDID: "udid", Token: "new"}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	if err := db.MarkUnreachable("udid", "old", "Unregistered", now); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := db.PushInfo("udid"); info.Unreachable() {
+		t.Error("expected the rejection of a replaced token to be ignored")
+	}
+	if err := db.MarkUnreachable("udid", "new", "Unregistered", now); err != nil {
+		t.Fatal(err)
+	}
+	info, err := db.PushInfo("udid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Unreachable() || info.FailureReason != "Unregistered" || !info.FailedAt.Equal(now) {
+		t.Errorf("unexpected push info %+v", info)
+	}
+}
+
+func TestClearFailure(t *testing.T) {
+	db := setupDB(t)
+	if err := db.Save(&apns.PushInfo{UDID: "udid", Token: "token"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkUnreachable("udid", "token", "Unregistered", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ClearFailure("udid"); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := db.PushInfo("udid"); info.Unreachable() || !info.FailedAt.IsZero() || info.Token != "token" {
+		t.Errorf("unexpected push info %+v", info)
+	}
+	if err := db.ClearFailure("unknown"); err == nil {
+		t.Error("expected an error for unknown push info")
+	}
+
+	if err := db.MarkUnreachable("udid", "token", "Unregistered", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := connect.MarshalEvent(connect.NewEvent(connect.MDMConnectRequest{
+		MDMResponse: mdm.Response{UDID: "udid", Status: "Idle"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.handleConnect(pubsub.Event{Topic: connect.ConnectTopic, Message: msg}); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := db.PushInfo("udid"); info.Unreachable() {
+		t.Error("expected a connect to clear the push failure")
+	}
+}
+
+func TestReplayTokenUpdate(t *testing.T) {
+	db := setupDB(t)
+	updated := time.Now().Add(-time.Hour).UTC()
+	if err := db.Save(&apns.PushInfo{UDID: "udid", Token: "01", UpdatedAt: updated}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkUnreachable("udid", "01", "Unregistered", updated); err != nil {
+		t.Fatal(err)
+	}
+
+	replay := func(token []byte, at time.Time) *apns.PushInfo {
+		t.Helper()
+		ev := checkin.NewEvent(mdm.CheckinCommand{MessageType: "TokenUpdate", UDID: "udid"})
+		ev.Command.Token = token
+		ev.Time = at
+		msg, err := checkin.MarshalEvent(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.handleTokenUpdate(pubsub.Event{Topic: checkin.ReplayTopic, Message: msg}, true); err != nil {
+			t.Fatal(err)
+		}
+		info, err := db.PushInfo("udid")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+
+	if info := replay([]byte{0x02}, updated.Add(-time.Minute)); info.Token != "01" {
+		t.Errorf("an older replayed TokenUpdate replaced the token with %s", info.Token)
+	}
+	info := replay([]byte{0x03}, updated.Add(time.Minute))
+	if info.Token != "03" {
+		t.Errorf("have token %s after a newer replayed TokenUpdate, want 03", info.Token)
+	}
+	if !info.Unreachable() {
+		t.Error("a replayed TokenUpdate cleared the push failure")
+	}
+}
+
+func setupDB(t *testing.T, opts ...Option) *DB {
+	t.Helper()
+	f, _ := ioutil.TempFile("", "bolt-")
+	f.Close()
+	os.Remove(f.Name())
+
+	db, err := bolt.Open(f.Name(), 0777, nil)
+	if err != nil {
+		t.Fatalf("couldn't open bolt, err %s\n", err)
+	}
+	pushDB, err := NewDB(db, inmem.NewPubSub(), opts...)
+	if err != nil {
+		t.Fatalf("couldn't create push DB, err %s\n", err)
+	}
+	return pushDB
+}
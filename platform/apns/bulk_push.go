@@ -0,0 +1,149 @@
+package apns
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+	"github.com/as/micromdm/platform/device"
+)
+
+// BulkPushRequest targets the union of the UDIDs and the devices matched
+// by the Selector.
+type BulkPushRequest struct {
+	UDIDs    []string         `json:"udids,omitempty"`
+	Selector *device.Selector `json:"selector,omitempty"`
+}
+
+// BulkPushResult lists the devices of a BulkPushRequest. The pushes are sent
+// by the Dispatcher, and their results are published to PushTopic.
+type BulkPushResult struct {
+	// Queued are the devices a push was scheduled for.
+	Queued []string `json:"queued"`
+
+	// Coalesced are the devices which already had a push waiting to be sent.
+	Coalesced []string `json:"coalesced,omitempty"`
+}
+
+func (svc *PushService) BulkPush(ctx context.Context, request BulkPushRequest) (*BulkPushResult, error) {
+	udids, err := svc.bulkTargets(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(udids) == 0 {
+		return nil, errors.New("bulk push request matched no devices")
+	}
+	result := BulkPushResult{Queued: []string{}}
+	for _, udid := range udids {
+		if svc.dispatcher.Dispatch(udid, nil) {
+			result.Queued = append(result.Queued, udid)
+		} else {
+			result.Coalesced = append(result.Coalesced, udid)
+		}
+	}
+	return &result, nil
+}
+
+// bulkTargets returns the UDIDs of the devices targeted by a request,
+// without duplicates.
+func (svc *PushService) bulkTargets(request BulkPushRequest) ([]string, error) {
+	var udids []string
+	seen := make(map[string]bool)
+	add := func(udid string) {
+		if udid == "" || seen[udid] {
+			return
+		}
+		seen[udid] = true
+		udids = append(udids, udid)
+	}
+
+	for _, udid := range request.UDIDs {
+		add(udid)
+	}
+	if request.Selector == nil {
+		return udids, nil
+	}
+	if svc.devices == nil {
+		return nil, errors.New("targeting devices by selector is not supported")
+	}
+	devices, err := svc.devices.List()
+	if err != nil {
+		return nil, errors.Wrap(err, "list devices for bulk push selector")
+	}
+	for _, dev := range devices {
+		if request.Selector.Match(dev) {
+			add(dev.UDID)
+		}
+	}
+	return udids, nil
+}
+
+type bulkPushRequest struct {
+	BulkPushRequest
+}
+
+type bulkPushResponse struct {
+	*BulkPushResult
+	Err error `json:"err,omitempty"`
+}
+
+func (r bulkPushResponse) Failed() error { return r.Err }
+
+func decodeBulkPushRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req bulkPushRequest
+	err := httputil.DecodeJSONRequest(r, &req)
+	return req, err
+}
+
+func encodeBulkPushRequest(ctx context.Context, r *http.Request, request interface{}) error {
+	r.Method, r.URL.Path = "POST", "/v1/push"
+	return httptransport.EncodeJSONRequest(ctx, r, request)
+}
+
+func decodeBulkPushResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp bulkPushResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeBulkPushEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bulkPushRequest)
+		result, err := svc.BulkPush(ctx, req.BulkPushRequest)
+		return bulkPushResponse{BulkPushResult: result, Err: err}, nil
+	}
+}
+
+func (e Endpoints) BulkPush(ctx context.Context, request BulkPushRequest) (*BulkPushResult, error) {
+	resp, err := e.BulkPushEndpoint(ctx, bulkPushRequest{request})
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(bulkPushResponse)
+	return response.BulkPushResult, response.Err
+}
+
+func (mw loggingMiddleware) BulkPush(ctx context.Context, request BulkPushRequest) (result *BulkPushResult, err error) {
+	defer func(begin time.Time) {
+		var queued int
+		if result != nil {
+			queued = len(result.Queued)
+		}
+		_ = mw.logger.Log(
+			"method", "BulkPush",
+			"udids", len(request.UDIDs),
+			"selector", request.Selector != nil,
+			"queued", queued,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	result, err = mw.next.BulkPush(ctx, request)
+	return
+}
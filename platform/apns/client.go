@@ -0,0 +1,70 @@
+package apns
+
+import (
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransport.ClientOption) (Service, error) {
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var pushEndpoint endpoint.Endpoint
+	{
+		pushEndpoint = httptransport.NewClient(
+			"POST",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodePushRequest),
+			decodePushResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var bulkPushEndpoint endpoint.Endpoint
+	{
+		bulkPushEndpoint = httptransport.NewClient(
+			"POST",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeBulkPushRequest),
+			decodeBulkPushResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var pushHistoryEndpoint endpoint.Endpoint
+	{
+		pushHistoryEndpoint = httptransport.NewClient(
+			"GET",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodePushHistoryRequest),
+			decodePushHistoryResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var resetPushFailureEndpoint endpoint.Endpoint
+	{
+		resetPushFailureEndpoint = httptransport.NewClient(
+			"DELETE",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeResetPushFailureRequest),
+			decodeResetPushFailureResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	return Endpoints{
+		PushEndpoint:        pushEndpoint,
+		BulkPushEndpoint:    bulkPushEndpoint,
+		PushHistoryEndpoint: pushHistoryEndpoint,
+
+		ResetPushFailureEndpoint: resetPushFailureEndpoint,
+	}, nil
+}
@@ -0,0 +1,226 @@
+package apns
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RobotsAndPencils/buford/push"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/backoff"
+)
+
+// RetryPolicy controls how often a push which failed with a transient APNs
+// error is sent again.
+type RetryPolicy = backoff.Policy
+
+// DefaultRetryPolicy is used by a Dispatcher created without WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     time.Second,
+	MaxBackoff:  30 * time.Second,
+}
+
+// Defaults of a Dispatcher created without options.
+const (
+	DefaultPushWorkers    = 8
+	DefaultCoalesceWindow = time.Second
+	DefaultPushRate       = 100
+)
+
+// Dispatcher sends the push notifications of queued commands. Pushes to the
+// same device within the coalesce window are sent once, by a bounded pool of
+// workers which share a rate limit.
+type Dispatcher struct {
+	push    func(udid string) (string, error)
+	publish func(context.Context, *PushEvent)
+	workers int
+	window  time.Duration
+	rate    float64
+	retry   RetryPolicy
+	sleep   func(context.Context, time.Duration) error
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string][]func() // done functions, by device
+
+	wake    chan struct{}
+	jobs    chan string
+	limiter <-chan time.Time
+}
+
+type DispatcherOption func(*Dispatcher)
+
+// WithWorkers sets the number of pushes sent concurrently.
+func WithWorkers(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.workers = n
+	}
+}
+
+// WithCoalesceWindow sets how long a push waits for more pushes to the same
+// device before it is sent.
+func WithCoalesceWindow(window time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.window = window
+	}
+}
+
+// WithRateLimit limits the pushes sent per second by all workers.
+// Zero does not limit the rate. Rates above one push per nanosecond
+// are rejected.
+func WithRateLimit(perSecond float64) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.rate = perSecond
+	}
+}
+
+// WithRetryPolicy sets the retry policy of pushes which failed with a
+// transient error.
+func WithRetryPolicy(policy RetryPolicy) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.retry = policy
+	}
+}
+
+// newDispatcher creates a Dispatcher which sends pushes with push and
+// publishes the result of every push with publish.
+func newDispatcher(push func(string) (string, error), publish func(context.Context, *PushEvent), opts ...DispatcherOption) (*Dispatcher, error) {
+	d := &Dispatcher{
+		push:    push,
+		publish: publish,
+		workers: DefaultPushWorkers,
+		window:  DefaultCoalesceWindow,
+		rate:    DefaultPushRate,
+		retry:   DefaultRetryPolicy,
+		sleep:   backoff.Sleep,
+		queued:  make(map[string][]func()),
+		wake:    make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.workers < 1 {
+		d.workers = 1
+	}
+	if d.rate < 0 || math.IsNaN(d.rate) {
+		return nil, errors.Errorf("invalid push rate %v", d.rate)
+	}
+	if d.rate > 0 {
+		interval := time.Duration(float64(time.Second) / d.rate)
+		if interval <= 0 {
+			return nil, errors.Errorf("push rate %v exceeds one push per nanosecond", d.rate)
+		}
+		d.limiter = time.NewTicker(interval).C
+	}
+	d.jobs = make(chan string, d.workers)
+	go d.batches()
+	for i := 0; i < d.workers; i++ {
+		go d.worker()
+	}
+	return d, nil
+}
+
+// Dispatch schedules a push to the device. It reports false if a push to
+// the device is already waiting to be sent. done, if not nil, is called once
+// the waiting push was sent or finally failed.
+func (d *Dispatcher) Dispatch(udid string, done func()) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	dones, queued := d.queued[udid]
+	if done != nil {
+		dones = append(dones, done)
+	}
+	d.queued[udid] = dones
+	if queued {
+		return false
+	}
+	d.pending = append(d.pending, udid)
+	if len(d.pending) == 1 {
+		select {
+		case d.wake <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+// batches hands the pending pushes to the workers once the coalesce window
+// of the first pending push has passed.
+func (d *Dispatcher) batches() {
+	for range d.wake {
+		if d.window > 0 {
+			d.sleep(context.Background(), d.window)
+		}
+		d.mu.Lock()
+		batch := d.pending
+		d.pending = nil
+		d.mu.Unlock()
+		for _, udid := range batch {
+			d.jobs <- udid
+		}
+	}
+}
+
+func (d *Dispatcher) worker() {
+	for udid := range d.jobs {
+		// a push requested from now on is sent again, because it may be
+		// for a command queued after this push reached the device.
+		d.mu.Lock()
+		dones := d.queued[udid]
+		delete(d.queued, udid)
+		d.mu.Unlock()
+		d.publish(context.Background(), d.send(context.Background(), udid))
+		for _, done := range dones {
+			done()
+		}
+	}
+}
+
+// send pushes to the device, retrying transient errors with exponential
+// backoff.
+func (d *Dispatcher) send(ctx context.Context, udid string) *PushEvent {
+	var (
+		id   string
+		err  error
+		sent int
+	)
+	for i := 0; i < d.retry.Attempts(); i++ {
+		if i > 0 {
+			if err := d.sleep(ctx, d.retry.Delay(i)); err != nil {
+				break
+			}
+		}
+		if d.limiter != nil {
+			<-d.limiter
+		}
+		sent++
+		if id, err = d.push(udid); err == nil || !temporary(err) {
+			break
+		}
+	}
+	event := NewPushEvent(udid, id, err)
+	event.Attempts = sent
+	return event
+}
+
+// temporary reports whether a push which failed with err may succeed if it
+// is sent again.
+func temporary(err error) bool {
+	switch e := errors.Cause(err).(type) {
+	case *push.Error:
+		switch e.Reason {
+		case push.ErrTooManyRequests, push.ErrIdleTimeout, push.ErrShutdown,
+			push.ErrInternalServerError, push.ErrServiceUnavailable:
+			return true
+		}
+		return e.Status == http.StatusTooManyRequests || e.Status >= http.StatusInternalServerError
+	case net.Error:
+		return true
+	}
+	return false
+}
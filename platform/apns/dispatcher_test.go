@@ -0,0 +1,186 @@
+package apns
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RobotsAndPencils/buford/push"
+)
+
+type fakePusher struct {
+	mu     sync.Mutex
+	calls  map[string]int
+	errors map[string][]error
+}
+
+func (f *fakePusher) push(udid string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[udid]++
+	if errs := f.errors[udid]; len(errs) > 0 {
+		f.errors[udid] = errs[1:]
+		return "", errs[0]
+	}
+	return "id-" + udid, nil
+}
+
+func (f *fakePusher) count(udid string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[udid]
+}
+
+func newTestDispatcher(t *testing.T, f *fakePusher, opts ...DispatcherOption) (*Dispatcher, <-chan *PushEvent) {
+	t.Helper()
+	events := make(chan *PushEvent, 10)
+	publish := func(_ context.Context, ev *PushEvent) { events <- ev }
+	opts = append([]DispatcherOption{
+		WithRateLimit(0),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}),
+	}, opts...)
+	d, err := newDispatcher(f.push, publish, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, events
+}
+
+func receiveEvent(t *testing.T, events <-chan *PushEvent) *PushEvent {
+	t.Helper()
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a push result")
+		return nil
+	}
+}
+
+func TestDispatcherCoalesce(t *testing.T) {
+	f := &fakePusher{calls: make(map[string]int)}
+	d, events := newTestDispatcher(t, f, WithCoalesceWindow(100*time.Millisecond))
+
+	for i, want := range []bool{true, false, false} {
+		if have := d.Dispatch("a", nil); have != want {
+			t.Errorf("dispatch %d: have %v, want %v", i, have, want)
+		}
+	}
+	if !d.Dispatch("b", nil) {
+		t.Error("expected a push to another device to be scheduled")
+	}
+
+	results := make(map[string]*PushEvent)
+	for i := 0; i < 2; i++ {
+		ev := receiveEvent(t, events)
+		results[ev.DeviceUDID] = ev
+	}
+	if have := f.count("a"); have != 1 {
+		t.Errorf("have %d pushes to a, want 1", have)
+	}
+	if ev := results["a"]; ev == nil || ev.PushNotificationID != "id-a" || ev.Status != http.StatusOK {
+		t.Errorf("unexpected push result %+v", ev)
+	}
+
+	// once the push was sent, the device is pushed again.
+	if !d.Dispatch("a", nil) {
+		t.Error("expected a new push to be scheduled after the first push was sent")
+	}
+	receiveEvent(t, events)
+	if have := f.count("a"); have != 2 {
+		t.Errorf("have %d pushes to a, want 2", have)
+	}
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	unavailable := &push.Error{Reason: push.ErrServiceUnavailable, Status: http.StatusServiceUnavailable}
+	badToken := &push.Error{Reason: push.ErrBadDeviceToken, Status: http.StatusBadRequest}
+	f := &fakePusher{
+		calls: make(map[string]int),
+		errors: map[string][]error{
+			"transient": {unavailable, unavailable},
+			"permanent": {badToken},
+			"exhausted": {unavailable, unavailable, unavailable},
+			"local":     {errors.New("invalid push token")},
+		},
+	}
+	d, events := newTestDispatcher(t, f, WithCoalesceWindow(0))
+
+	tests := []struct {
+		udid     string
+		attempts int
+		status   int
+		reason   string
+	}{
+		{udid: "transient", attempts: 3, status: http.StatusOK},
+		{udid: "permanent", attempts: 1, status: http.StatusBadRequest, reason: "BadDeviceToken"},
+		{udid: "exhausted", attempts: 3, status: http.StatusServiceUnavailable, reason: "ServiceUnavailable"},
+		{udid: "local", attempts: 1},
+	}
+	for _, tt := range tests {
+		d.Dispatch(tt.udid, nil)
+		ev := receiveEvent(t, events)
+		if ev.DeviceUDID != tt.udid {
+			t.Fatalf("have result for %s, want %s", ev.DeviceUDID, tt.udid)
+		}
+		if ev.Attempts != tt.attempts {
+			t.Errorf("%s: have %d attempts, want %d", tt.udid, ev.Attempts, tt.attempts)
+		}
+		if ev.Status != tt.status || ev.Reason != tt.reason {
+			t.Errorf("%s: have status %d %q, want %d %q", tt.udid, ev.Status, ev.Reason, tt.status, tt.reason)
+		}
+	}
+}
+
+func TestDispatcherDone(t *testing.T) {
+	f := &fakePusher{
+		calls:  make(map[string]int),
+		errors: map[string][]error{"a": {errors.New("invalid push token")}},
+	}
+	d, events := newTestDispatcher(t, f, WithCoalesceWindow(50*time.Millisecond))
+
+	done := make(chan string, 2)
+	d.Dispatch("a", func() { done <- "first" })
+	d.Dispatch("a", func() { done <- "second" })
+	select {
+	case <-done:
+		t.Fatal("done was called before the push was sent")
+	default:
+	}
+	receiveEvent(t, events)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("done was not called after the push finally failed")
+		}
+	}
+}
+
+func TestDispatcherRate(t *testing.T) {
+	for _, rate := range []float64{-1, 2e9, math.Inf(1), math.NaN()} {
+		if _, err := newDispatcher(nil, nil, WithRateLimit(rate)); err == nil {
+			t.Errorf("expected an error for the push rate %v", rate)
+		}
+	}
+}
+
+func TestPushEventProto(t *testing.T) {
+	ev := NewPushEvent("udid", "", &push.Error{Reason: push.ErrTooManyRequests, Status: http.StatusTooManyRequests})
+	ev.Attempts = 2
+	data, err := MarshalPushEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have PushEvent
+	if err := UnmarshalPushEvent(data, &have); err != nil {
+		t.Fatal(err)
+	}
+	if have.Status != http.StatusTooManyRequests || have.Reason != "TooManyRequests" || have.Attempts != 2 || have.Err == "" {
+		t.Errorf("unexpected push event %+v", have)
+	}
+}
@@ -3,6 +3,7 @@ package apns
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -17,11 +18,22 @@ import (
 )
 
 func (svc *PushService) Push(ctx context.Context, deviceUDID string) (string, error) {
+	result, err := svc.push(deviceUDID)
+	svc.publishPushEvent(ctx, NewPushEvent(deviceUDID, result, err))
+	return result, err
+}
+
+func (svc *PushService) push(deviceUDID string) (string, error) {
 	info, err := svc.store.PushInfo(deviceUDID)
 	if err != nil {
 		return "", errors.Wrap(err, "retrieving PushInfo by UDID")
 	}
 
+	if info.Unreachable() {
+		return "", errors.Errorf("push suppressed: APNs rejected the token of %s at %s with %s, waiting for a TokenUpdate",
+			deviceUDID, info.FailedAt.Format(time.RFC3339), info.FailureReason)
+	}
+
 	p := payload.MDM{Token: info.PushMagic}
 	valid := push.IsDeviceTokenValid(info.Token)
 	if !valid {
@@ -31,7 +43,18 @@ func (svc *PushService) Push(ctx context.Context, deviceUDID string) (string, er
 	if err != nil {
 		return "", errors.Wrap(err, "marshalling push notification payload")
 	}
-	result, err := svc.pushsvc.Push(info.Token, nil, jsonPayload)
+	svc.mu.RLock()
+	pushsvc := svc.pushsvc
+	svc.mu.RUnlock()
+	if pushsvc == nil {
+		return "", errors.New("push service is not configured")
+	}
+	result, err := pushsvc.Push(info.Token, nil, jsonPayload)
+	if perr, ok := err.(*push.Error); ok && perr.Reason != nil && TokenRejected(perr.Reason.Error()) {
+		if err := svc.store.MarkUnreachable(deviceUDID, info.Token, perr.Reason.Error(), time.Now().UTC()); err != nil {
+			fmt.Println(errors.Wrapf(err, "mark %s unreachable", deviceUDID))
+		}
+	}
 	if err != nil && strings.HasSuffix(err.Error(), "remote error: tls: internal error") {
 		// TODO: yuck, error substring searching. see:
 		// https://github.com/as/micromdm/issues/150
@@ -40,6 +63,20 @@ func (svc *PushService) Push(ctx context.Context, deviceUDID string) (string, er
 	return result, err
 }
 
+func (svc *PushService) publishPushEvent(ctx context.Context, event *PushEvent) {
+	if svc.publisher == nil {
+		return
+	}
+	msg, err := MarshalPushEvent(event)
+	if err != nil {
+		fmt.Println(errors.Wrap(err, "marshal push event"))
+		return
+	}
+	if err := svc.publisher.Publish(ctx, PushTopic, msg); err != nil {
+		fmt.Println(errors.Wrapf(err, "publish push event on topic: %s", PushTopic))
+	}
+}
+
 type pushRequest struct {
 	UDID string
 }
@@ -63,6 +100,12 @@ func decodePushRequest(ctx context.Context, r *http.Request) (interface{}, error
 	}, nil
 }
 
+func encodePushRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(pushRequest)
+	r.Method, r.URL.Path = "POST", "/v1/push/"+req.UDID
+	return nil
+}
+
 func decodePushResponse(_ context.Context, r *http.Response) (interface{}, error) {
 	var resp pushResponse
 	err := httputil.DecodeJSONResponse(r, &resp)
@@ -80,6 +123,15 @@ func MakePushEndpoint(svc Service) endpoint.Endpoint {
 	}
 }
 
+func (e Endpoints) Push(ctx context.Context, udid string) (string, error) {
+	resp, err := e.PushEndpoint(ctx, pushRequest{UDID: udid})
+	if err != nil {
+		return "", err
+	}
+	response := resp.(pushResponse)
+	return response.ID, response.Err
+}
+
 func (mw loggingMiddleware) Push(ctx context.Context, udid string) (id string, err error) {
 	defer func(begin time.Time) {
 		_ = mw.logger.Log(
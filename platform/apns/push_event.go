@@ -0,0 +1,87 @@
+package apns
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RobotsAndPencils/buford/push"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/as/micromdm/platform/apns/internal/pushproto"
+)
+
+// PushTopic is a PubSub topic that the result of every push
+// notification is published to.
+const PushTopic = "mdm.Push"
+
+// PushEvent is the result of sending a push notification to a device.
+type PushEvent struct {
+	ID                 string    `json:"id"`
+	Time               time.Time `json:"time"`
+	DeviceUDID         string    `json:"udid"`
+	PushNotificationID string    `json:"push_notification_id,omitempty"`
+	Err                string    `json:"error,omitempty"`
+
+	// Status is the HTTP status of the APNs response, or zero if the
+	// push did not get a response. Reason is the APNs error reason,
+	// like BadDeviceToken.
+	Status int    `json:"status,omitempty"`
+	Reason string `json:"reason,omitempty"`
+
+	// Attempts is the number of times the push was sent.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// NewPushEvent returns a PushEvent with a unique ID and the current time.
+func NewPushEvent(udid, pushID string, err error) *PushEvent {
+	event := PushEvent{
+		ID:                 uuid.NewV4().String(),
+		Time:               time.Now().UTC(),
+		DeviceUDID:         udid,
+		PushNotificationID: pushID,
+		Attempts:           1,
+	}
+	if err == nil {
+		event.Status = http.StatusOK
+		return &event
+	}
+	event.Err = err.Error()
+	if perr, ok := errors.Cause(err).(*push.Error); ok {
+		event.Status = perr.Status
+		if perr.Reason != nil {
+			event.Reason = perr.Reason.Error()
+		}
+	}
+	return &event
+}
+
+func MarshalPushEvent(e *PushEvent) ([]byte, error) {
+	return proto.Marshal(&pushproto.PushEvent{
+		Id:                 e.ID,
+		Time:               e.Time.UnixNano(),
+		Udid:               e.DeviceUDID,
+		PushNotificationId: e.PushNotificationID,
+		Error:              e.Err,
+		Status:             int64(e.Status),
+		Reason:             e.Reason,
+		Attempts:           int64(e.Attempts),
+	})
+}
+
+func UnmarshalPushEvent(data []byte, e *PushEvent) error {
+	var pb pushproto.PushEvent
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal proto to PushEvent")
+	}
+	e.ID = pb.GetId()
+	e.Time = time.Unix(0, pb.GetTime()).UTC()
+	e.DeviceUDID = pb.GetUdid()
+	e.PushNotificationID = pb.GetPushNotificationId()
+	e.Err = pb.GetError()
+	e.Status = int(pb.GetStatus())
+	e.Reason = pb.GetReason()
+	e.Attempts = int(pb.GetAttempts())
+	return nil
+}
@@ -0,0 +1,96 @@
+package apns
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// PushHistory returns up to limit of the latest pushes to the device,
+// newest first. A limit of zero returns every stored push.
+func (svc *PushService) PushHistory(ctx context.Context, udid string, limit int) ([]PushEvent, error) {
+	history, err := svc.store.PushHistory(udid, limit)
+	return history, errors.Wrapf(err, "get push history of %s", udid)
+}
+
+type pushHistoryRequest struct {
+	UDID  string
+	Limit int
+}
+
+type pushHistoryResponse struct {
+	Pushes []PushEvent `json:"pushes"`
+	Err    error       `json:"err,omitempty"`
+}
+
+func (r pushHistoryResponse) Failed() error { return r.Err }
+
+func decodePushHistoryRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	udid, ok := mux.Vars(r)["udid"]
+	if !ok {
+		return nil, errors.New("apns: bad route")
+	}
+	req := pushHistoryRequest{UDID: udid}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return nil, errors.Wrap(err, "parse limit")
+		}
+	}
+	return req, nil
+}
+
+func encodePushHistoryRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(pushHistoryRequest)
+	values := url.Values{}
+	if req.Limit > 0 {
+		values.Set("limit", strconv.Itoa(req.Limit))
+	}
+	r.Method, r.URL.Path, r.URL.RawQuery = "GET", "/v1/devices/"+req.UDID+"/pushes", values.Encode()
+	return nil
+}
+
+func decodePushHistoryResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp pushHistoryResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakePushHistoryEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(pushHistoryRequest)
+		pushes, err := svc.PushHistory(ctx, req.UDID, req.Limit)
+		return pushHistoryResponse{Pushes: pushes, Err: err}, nil
+	}
+}
+
+func (e Endpoints) PushHistory(ctx context.Context, udid string, limit int) ([]PushEvent, error) {
+	resp, err := e.PushHistoryEndpoint(ctx, pushHistoryRequest{UDID: udid, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(pushHistoryResponse)
+	return response.Pushes, response.Err
+}
+
+func (mw loggingMiddleware) PushHistory(ctx context.Context, udid string, limit int) (pushes []PushEvent, err error) {
+	defer func(begin time.Time) {
+		_ = mw.logger.Log(
+			"method", "PushHistory",
+			"udid", udid,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	pushes, err = mw.next.PushHistory(ctx, udid, limit)
+	return
+}
@@ -1,6 +1,9 @@
 package apns
 
 import (
+	"time"
+
+	"github.com/RobotsAndPencils/buford/push"
 	"github.com/pkg/errors"
 
 	"github.com/as/micromdm/platform/apns/internal/pushproto"
@@ -12,6 +15,28 @@ type PushInfo struct {
 	PushMagic string
 	Token     string
 	MDMTopic  string
+
+	// FailureReason is the APNs reason, Unregistered, for rejecting the
+	// token at FailedAt. Pushes to the device are suppressed until a
+	// TokenUpdate replaces the token, the device connects or the failure
+	// is reset.
+	FailureReason string
+	FailedAt      time.Time
+
+	// UpdatedAt is the time of the TokenUpdate which saved the token.
+	UpdatedAt time.Time
+}
+
+// Unreachable reports whether APNs rejected the push token of the device.
+func (p *PushInfo) Unreachable() bool {
+	return p.FailureReason != ""
+}
+
+// TokenRejected reports whether the APNs error reason means that the push
+// token will not be accepted again. BadDeviceToken is not a rejection,
+// APNs also returns it for a token of the other APNs environment.
+func TokenRejected(reason string) bool {
+	return reason == push.ErrUnregistered.Error()
 }
 
 func MarshalPushInfo(p *PushInfo) ([]byte, error) {
@@ -20,6 +45,14 @@ func MarshalPushInfo(p *PushInfo) ([]byte, error) {
 		PushMagic: p.PushMagic,
 		Token:     p.Token,
 		MdmTopic:  p.MDMTopic,
+
+		FailureReason: p.FailureReason,
+	}
+	if !p.FailedAt.IsZero() {
+		protopush.FailedAt = p.FailedAt.UnixNano()
+	}
+	if !p.UpdatedAt.IsZero() {
+		protopush.UpdatedAt = p.UpdatedAt.UnixNano()
 	}
 	return proto.Marshal(&protopush)
 }
@@ -33,5 +66,12 @@ func UnmarshalPushInfo(data []byte, p *PushInfo) error {
 	p.Token = pb.GetToken()
 	p.PushMagic = pb.GetPushMagic()
 	p.MDMTopic = pb.GetMdmTopic()
+	p.FailureReason = pb.GetFailureReason()
+	if nano := pb.GetFailedAt(); nano != 0 {
+		p.FailedAt = time.Unix(0, nano).UTC()
+	}
+	if nano := pb.GetUpdatedAt(); nano != 0 {
+		p.UpdatedAt = time.Unix(0, nano).UTC()
+	}
 	return nil
 }
@@ -0,0 +1,125 @@
+package apns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RobotsAndPencils/buford/push"
+
+	"github.com/as/micromdm/platform/device"
+)
+
+type mockStore struct {
+	info *PushInfo
+}
+
+func (s *mockStore) PushInfo(udid string) (*PushInfo, error) {
+	info := *s.info
+	return &info, nil
+}
+
+func (s *mockStore) MarkUnreachable(udid, token, reason string, at time.Time) error {
+	if s.info.Token == token {
+		s.info.FailureReason, s.info.FailedAt = reason, at
+	}
+	return nil
+}
+
+func (s *mockStore) ClearFailure(udid string) error {
+	s.info.FailureReason, s.info.FailedAt = "", time.Time{}
+	return nil
+}
+
+func (s *mockStore) PushHistory(udid string, limit int) ([]PushEvent, error) {
+	return nil, nil
+}
+
+func TestPushRejectedToken(t *testing.T) {
+	var requests int
+	reason := "BadDeviceToken"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusGone)
+		w.Write([]byte(`{"reason":"` + reason + `"}`))
+	}))
+	defer srv.Close()
+
+	token := "c2732227a1d8021cfaf781d71fb2f908c61f5861079a00954a5453f1d0281433"
+	store := &mockStore{info: &PushInfo{UDID: "udid", Token: token, PushMagic: "magic"}}
+	svc := &PushService{store: store, pushsvc: push.NewService(srv.Client(), srv.URL)}
+
+	// BadDeviceToken is also returned for a token of the other environment.
+	if _, err := svc.Push(context.Background(), "udid"); err == nil {
+		t.Fatal("expected the push to fail")
+	}
+	if store.info.Unreachable() {
+		t.Fatalf("expected a BadDeviceToken push to keep the token, got %+v", store.info)
+	}
+
+	reason = "Unregistered"
+	svc.Push(context.Background(), "udid")
+	if !store.info.Unreachable() || store.info.FailureReason != "Unregistered" {
+		t.Fatalf("expected the token to be marked as rejected, got %+v", store.info)
+	}
+
+	if _, err := svc.Push(context.Background(), "udid"); err == nil {
+		t.Fatal("expected the push to be suppressed")
+	}
+	if requests != 2 {
+		t.Errorf("have %d APNs requests, want 2", requests)
+	}
+
+	if err := svc.ResetPushFailure(context.Background(), "udid"); err != nil {
+		t.Fatal(err)
+	}
+	svc.Push(context.Background(), "udid")
+	if requests != 3 {
+		t.Errorf("have %d APNs requests, want 3", requests)
+	}
+}
+
+type mockDevices []device.Device
+
+func (d mockDevices) List() ([]device.Device, error) { return d, nil }
+
+func TestBulkPush(t *testing.T) {
+	enrolled := true
+	devices := mockDevices{
+		{UDID: "a", Enrolled: true},
+		{UDID: "b", Enrolled: false},
+		{UDID: "c", Enrolled: true},
+	}
+	f := &fakePusher{calls: make(map[string]int)}
+	d, events := newTestDispatcher(t, f, WithCoalesceWindow(time.Hour))
+	svc := &PushService{devices: devices, dispatcher: d}
+
+	result, err := svc.BulkPush(context.Background(), BulkPushRequest{
+		UDIDs:    []string{"b", "a"},
+		Selector: &device.Selector{Enrolled: &enrolled},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := strings.Join(result.Queued, ","), "b,a,c"; have != want {
+		t.Errorf("have queued %s, want %s", have, want)
+	}
+
+	result, err = svc.BulkPush(context.Background(), BulkPushRequest{UDIDs: []string{"a", "d"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Queued) != 1 || result.Queued[0] != "d" || len(result.Coalesced) != 1 || result.Coalesced[0] != "a" {
+		t.Errorf("unexpected bulk push result %+v", result)
+	}
+	if len(events) != 0 {
+		t.Error("expected the pushes to wait for the coalesce window")
+	}
+
+	if _, err := svc.BulkPush(context.Background(), BulkPushRequest{}); err == nil {
+		t.Error("expected an empty request to fail")
+	}
+}
@@ -0,0 +1,98 @@
+package apns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// PushFailureResetTopic is a PubSub topic that a PushEvent of the device is
+// published to when the push failure of the device is reset.
+const PushFailureResetTopic = "mdm.PushFailureReset"
+
+// ResetPushFailure clears the rejected token of a device, so that pushes to
+// the device are sent again before it sends a new token.
+func (svc *PushService) ResetPushFailure(ctx context.Context, udid string) error {
+	if err := svc.store.ClearFailure(udid); err != nil {
+		return errors.Wrapf(err, "reset push failure of %s", udid)
+	}
+	if svc.publisher == nil {
+		return nil
+	}
+	event := &PushEvent{Time: time.Now().UTC(), DeviceUDID: udid}
+	msg, err := MarshalPushEvent(event)
+	if err != nil {
+		return errors.Wrap(err, "marshal push failure reset event")
+	}
+	if err := svc.publisher.Publish(ctx, PushFailureResetTopic, msg); err != nil {
+		fmt.Println(errors.Wrapf(err, "publish push failure reset on topic: %s", PushFailureResetTopic))
+	}
+	return nil
+}
+
+type resetPushFailureRequest struct {
+	UDID string
+}
+
+type resetPushFailureResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r resetPushFailureResponse) Failed() error { return r.Err }
+
+func decodeResetPushFailureRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	udid, ok := mux.Vars(r)["udid"]
+	if !ok {
+		return nil, errors.New("apns: bad route")
+	}
+	return resetPushFailureRequest{UDID: udid}, nil
+}
+
+func encodeResetPushFailureRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(resetPushFailureRequest)
+	r.Method, r.URL.Path = "DELETE", "/v1/devices/"+req.UDID+"/push-failure"
+	return nil
+}
+
+func decodeResetPushFailureResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp resetPushFailureResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeResetPushFailureEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(resetPushFailureRequest)
+		err := svc.ResetPushFailure(ctx, req.UDID)
+		return resetPushFailureResponse{Err: err}, nil
+	}
+}
+
+func (e Endpoints) ResetPushFailure(ctx context.Context, udid string) error {
+	resp, err := e.ResetPushFailureEndpoint(ctx, resetPushFailureRequest{UDID: udid})
+	if err != nil {
+		return err
+	}
+	return resp.(resetPushFailureResponse).Err
+}
+
+func (mw loggingMiddleware) ResetPushFailure(ctx context.Context, udid string) (err error) {
+	defer func(begin time.Time) {
+		_ = mw.logger.Log(
+			"method", "ResetPushFailure",
+			"udid", udid,
+			"err", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+
+	err = mw.next.ResetPushFailure(ctx, udid)
+	return
+}
@@ -10,12 +10,20 @@ import (
 )
 
 type Endpoints struct {
-	PushEndpoint endpoint.Endpoint
+	PushEndpoint        endpoint.Endpoint
+	BulkPushEndpoint    endpoint.Endpoint
+	PushHistoryEndpoint endpoint.Endpoint
+
+	ResetPushFailureEndpoint endpoint.Endpoint
 }
 
 func MakeServerEndpoints(s Service) Endpoints {
 	return Endpoints{
-		PushEndpoint: MakePushEndpoint(s),
+		PushEndpoint:        MakePushEndpoint(s),
+		BulkPushEndpoint:    MakeBulkPushEndpoint(s),
+		PushHistoryEndpoint: MakePushHistoryEndpoint(s),
+
+		ResetPushFailureEndpoint: MakeResetPushFailureEndpoint(s),
 	}
 }
 
@@ -24,6 +32,9 @@ func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
 
 	// GET    /push/:udid		create an APNS Push notification for a managed device or user(deprecated)
 	// POST   /v1/push/:udid	create an APNS Push notification for a managed device or user
+	// POST   /v1/push			push to the devices of a list of UDIDs or a device selector
+	// GET    /v1/devices/:udid/pushes	the latest pushes to a device
+	// DELETE /v1/devices/:udid/push-failure	resume pushes to a device whose token APNs rejected
 
 	r.Methods("GET").Path("/push/{udid}").Handler(httptransport.NewServer(
 		e.PushEndpoint,
@@ -39,5 +50,26 @@ func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
 		options...,
 	))
 
+	r.Methods("POST").Path("/v1/push").Handler(httptransport.NewServer(
+		e.BulkPushEndpoint,
+		decodeBulkPushRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/v1/devices/{udid}/pushes").Handler(httptransport.NewServer(
+		e.PushHistoryEndpoint,
+		decodePushHistoryRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("DELETE").Path("/v1/devices/{udid}/push-failure").Handler(httptransport.NewServer(
+		e.ResetPushFailureEndpoint,
+		decodeResetPushFailureRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
 	return r
 }
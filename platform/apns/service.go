@@ -6,27 +6,56 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/RobotsAndPencils/buford/push"
 	"github.com/pkg/errors"
 
 	"github.com/as/micromdm/platform/config"
+	"github.com/as/micromdm/platform/device"
 	"github.com/as/micromdm/platform/pubsub"
 	"github.com/as/micromdm/platform/queue"
 )
 
 type Service interface {
 	Push(ctx context.Context, udid string) (string, error)
+	BulkPush(ctx context.Context, request BulkPushRequest) (*BulkPushResult, error)
+	PushHistory(ctx context.Context, udid string, limit int) ([]PushEvent, error)
+	ResetPushFailure(ctx context.Context, udid string) error
 }
 
 type Store interface {
 	PushInfo(udid string) (*PushInfo, error)
+
+	// MarkUnreachable records that APNs rejected the token of the device.
+	// It does nothing if the token was replaced in the meantime.
+	MarkUnreachable(udid, token, reason string, at time.Time) error
+
+	// ClearFailure resumes the pushes to a device whose token was
+	// rejected.
+	ClearFailure(udid string) error
+
+	// PushHistory returns up to limit of the latest pushes to the device,
+	// newest first.
+	PushHistory(udid string, limit int) ([]PushEvent, error)
+}
+
+// DeviceStore resolves the devices targeted by the selector of a
+// BulkPushRequest.
+type DeviceStore interface {
+	List() ([]device.Device, error)
 }
 
 type PushService struct {
-	store    Store
-	start    chan struct{}
-	provider PushCertificateProvider
+	store     Store
+	start     chan struct{}
+	provider  PushCertificateProvider
+	publisher pubsub.Publisher
+	gateway   string
+	devices   DeviceStore
+
+	dispatchOpts []DispatcherOption
+	dispatcher   *Dispatcher
 
 	mu      sync.RWMutex
 	pushsvc *push.Service
@@ -36,6 +65,12 @@ type PushCertificateProvider interface {
 	PushCertificate() (*tls.Certificate, error)
 }
 
+// PushProviderStore is implemented by a PushCertificateProvider which also
+// stores the push provider configured with mdmctl.
+type PushProviderStore interface {
+	PushProvider() (*config.PushProvider, error)
+}
+
 type Option func(*PushService)
 
 func WithPushService(svc *push.Service) Option {
@@ -44,6 +79,36 @@ func WithPushService(svc *push.Service) Option {
 	}
 }
 
+// WithGateway sets the APNs URL used by push providers which do not
+// configure an environment or gateway of their own.
+func WithGateway(url string) Option {
+	return func(p *PushService) {
+		p.gateway = url
+	}
+}
+
+// WithDispatcherOptions configures the Dispatcher which sends the pushes of
+// queued commands.
+func WithDispatcherOptions(opts ...DispatcherOption) Option {
+	return func(p *PushService) {
+		p.dispatchOpts = append(p.dispatchOpts, opts...)
+	}
+}
+
+// WithDeviceStore allows bulk pushes to target devices by selector.
+func WithDeviceStore(store DeviceStore) Option {
+	return func(p *PushService) {
+		p.devices = store
+	}
+}
+
+// WithPublisher publishes the result of every push to PushTopic.
+func WithPublisher(pub pubsub.Publisher) Option {
+	return func(p *PushService) {
+		p.publisher = pub
+	}
+}
+
 func New(db Store, provider PushCertificateProvider, sub pubsub.Subscriber, opts ...Option) (*PushService, error) {
 	pushSvc := PushService{
 		store:    db,
@@ -53,12 +118,23 @@ func New(db Store, provider PushCertificateProvider, sub pubsub.Subscriber, opts
 	for _, opt := range opts {
 		opt(&pushSvc)
 	}
+	if pushSvc.pushsvc == nil {
+		// use the push provider configured with mdmctl, if any.
+		if svc, err := newPushService(provider, pushSvc.gateway); err == nil {
+			pushSvc.pushsvc = svc
+		}
+	}
 	// if there is no push service, the push certificate hasn't been provided.
 	// start a goroutine that delays the run of this service.
 	if err := updateClient(&pushSvc, sub); err != nil {
 		return nil, errors.Wrap(err, "wait for push service config")
 	}
 
+	dispatcher, err := newDispatcher(pushSvc.push, pushSvc.publishPushEvent, pushSvc.dispatchOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "create push dispatcher")
+	}
+	pushSvc.dispatcher = dispatcher
 	if err := pushSvc.startQueuedSubscriber(sub); err != nil {
 		return &pushSvc, err
 	}
@@ -72,25 +148,26 @@ func (svc *PushService) startQueuedSubscriber(sub pubsub.Subscriber) error {
 			"subscribing push to %s topic", queue.CommandQueuedTopic)
 	}
 	go func() {
-		if svc.pushsvc == nil {
+		svc.mu.RLock()
+		started := svc.pushsvc != nil
+		svc.mu.RUnlock()
+		if !started {
 			log.Println("push: waiting for push certificate before enabling APNS service provider")
 			<-svc.start
 			log.Println("push: service started")
 		}
-		for {
-			select {
-			case event := <-commandQueuedEvents:
-				cq, err := queue.UnmarshalQueuedCommand(event.Message)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-				_, err = svc.Push(context.TODO(), cq.DeviceUDID)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
+		// an event is acknowledged once its push was sent or finally
+		// failed, so a durable pubsub pushes again after a restart.
+		var acks pubsub.AckQueue
+		for event := range commandQueuedEvents {
+			done := acks.Add(event, 1)
+			cq, err := queue.UnmarshalQueuedCommand(event.Message)
+			if err != nil {
+				fmt.Println(err)
+				done(nil)
+				continue
 			}
+			svc.dispatcher.Dispatch(cq.DeviceUDID, func() { done(nil) })
 		}
 	}()
 
@@ -103,25 +180,54 @@ func updateClient(svc *PushService, sub pubsub.Subscriber) error {
 		return errors.Wrap(err, "update push service client")
 	}
 	go func() {
-		for {
-			select {
-			case <-configEvents:
-				pushsvc, err := NewPushService(svc.provider)
-				if err != nil {
-					log.Println("push: could not get push certificate %s", err)
-					continue
-				}
+		for event := range configEvents {
+			if pushsvc, err := newPushService(svc.provider, svc.gateway); err != nil {
+				log.Printf("push: could not get push certificate %s\n", err)
+			} else {
 				svc.mu.Lock()
 				svc.pushsvc = pushsvc
 				svc.mu.Unlock()
 				go func() { svc.start <- struct{}{} }() // unblock queue
 			}
+			if err := event.Ack(); err != nil {
+				log.Println(err)
+			}
 		}
 	}()
 	return nil
 }
 
+// NewPushService creates a push service for the push provider of the
+// store, or for the push certificate.
 func NewPushService(provider PushCertificateProvider) (*push.Service, error) {
+	return newPushService(provider, "")
+}
+
+func newPushService(provider PushCertificateProvider, gateway string) (*push.Service, error) {
+	settings := &config.PushProvider{Provider: config.PushProviderCertificate}
+	if store, ok := provider.(PushProviderStore); ok {
+		var err error
+		if settings, err = store.PushProvider(); err != nil {
+			return nil, errors.Wrap(err, "get push provider from store")
+		}
+	}
+	if settings.Environment != "" || settings.GatewayURL != "" || gateway == "" {
+		var err error
+		if gateway, err = GatewayURL(settings.Environment, settings.GatewayURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.Provider == config.PushProviderToken {
+		svc, err := NewTokenPushService(TokenConfig{
+			AuthKey: settings.AuthKey,
+			KeyID:   settings.KeyID,
+			TeamID:  settings.TeamID,
+			Topic:   settings.Topic,
+		}, gateway, nil)
+		return svc, errors.Wrap(err, "create token push service")
+	}
+
 	cert, err := provider.PushCertificate()
 	if err != nil {
 		return nil, errors.Wrap(err, "get push certificate from store")
@@ -132,6 +238,6 @@ func NewPushService(provider PushCertificateProvider) (*push.Service, error) {
 		return nil, errors.Wrap(err, "create push service client")
 	}
 
-	svc := push.NewService(client, push.Production)
+	svc := push.NewService(client, gateway)
 	return svc, nil
 }
@@ -0,0 +1,186 @@
+package apns
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RobotsAndPencils/buford/push"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+
+	"github.com/as/micromdm/platform/config"
+)
+
+// tokenLifetime is how long a provider token is reused. APNs rejects tokens
+// which are older than one hour, and refreshing more often than every
+// 20 minutes.
+const tokenLifetime = 50 * time.Minute
+
+// GatewayURL returns the URL of the APNs environment, or override if set.
+func GatewayURL(environment, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	switch environment {
+	case "", config.PushEnvironmentProduction:
+		return push.Production, nil
+	case config.PushEnvironmentSandbox:
+		return push.Development, nil
+	default:
+		return "", errors.Errorf("unknown APNs environment %q", environment)
+	}
+}
+
+// TokenConfig configures token based authentication with APNs.
+type TokenConfig struct {
+	// AuthKey is the PEM encoded .p8 key.
+	AuthKey []byte
+	KeyID   string
+	TeamID  string
+
+	// Topic is sent with every push. It is required, because there is no
+	// certificate to derive the topic from.
+	Topic string
+}
+
+// NewTokenPushService creates a push service which sends push notifications
+// to the gateway over HTTP/2, authenticated with JWTs signed by the auth key.
+// A nil transport uses a new HTTP/2 transport.
+func NewTokenPushService(conf TokenConfig, gateway string, transport http.RoundTripper) (*push.Service, error) {
+	signer, err := NewTokenSigner(conf.AuthKey, conf.KeyID, conf.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if transport == nil {
+		t := &http.Transport{TLSClientConfig: &tls.Config{}}
+		if err := http2.ConfigureTransport(t); err != nil {
+			return nil, errors.Wrap(err, "configure HTTP/2 transport")
+		}
+		transport = t
+	}
+	client := &http.Client{
+		Transport: &tokenTransport{
+			signer: signer,
+			topic:  conf.Topic,
+			next:   transport,
+		},
+	}
+	return push.NewService(client, gateway), nil
+}
+
+// TokenSigner creates the provider tokens, ES256 signed JWTs, which
+// authenticate push requests.
+type TokenSigner struct {
+	key    *ecdsa.PrivateKey
+	keyID  string
+	teamID string
+	now    func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	issued time.Time
+}
+
+func NewTokenSigner(authKey []byte, keyID, teamID string) (*TokenSigner, error) {
+	key, err := ParseAuthKey(authKey)
+	if err != nil {
+		return nil, err
+	}
+	if keyID == "" || teamID == "" {
+		return nil, errors.New("apns: token signer requires a key ID and team ID")
+	}
+	return &TokenSigner{key: key, keyID: keyID, teamID: teamID, now: time.Now}, nil
+}
+
+// ParseAuthKey parses a PEM encoded .p8 auth key.
+func ParseAuthKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("apns: decode auth key PEM")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "apns: parse auth key")
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("apns: auth key is not an ECDSA key")
+	}
+	return ecKey, nil
+}
+
+// Token returns the current provider token, and signs a new one once the
+// current token is older than the token lifetime.
+func (s *TokenSigner) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if s.token != "" && now.Sub(s.issued) < tokenLifetime {
+		return s.token, nil
+	}
+	token, err := s.sign(now)
+	if err != nil {
+		return "", err
+	}
+	s.token, s.issued = token, now
+	return token, nil
+}
+
+func (s *TokenSigner) sign(issued time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "ES256", "kid": s.keyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{"iss": s.teamID, "iat": issued.Unix()})
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "apns: sign provider token")
+	}
+	// the JWS signature is the fixed size concatenation of r and s.
+	size := (s.key.Curve.Params().BitSize + 7) / 8
+	out := make([]byte, 2*size)
+	copyPadded(out[:size], r)
+	copyPadded(out[size:], sig)
+	return signed + "." + enc.EncodeToString(out), nil
+}
+
+func copyPadded(dst []byte, n *big.Int) {
+	b := n.Bytes()
+	copy(dst[len(dst)-len(b):], b)
+}
+
+// tokenTransport adds the provider token and the topic to push requests.
+type tokenTransport struct {
+	signer *TokenSigner
+	topic  string
+	next   http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.signer.Token()
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.Header.Set("authorization", "bearer "+token)
+	if t.topic != "" && r.Header.Get("apns-topic") == "" {
+		r.Header.Set("apns-topic", t.topic)
+	}
+	return t.next.RoundTrip(r)
+}
@@ -0,0 +1,174 @@
+package apns
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenPushService(t *testing.T) {
+	key, authKey := newAuthKey(t)
+
+	var (
+		authorization string
+		topic         string
+		path          string
+		proto         int
+	)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("authorization")
+		topic = r.Header.Get("apns-topic")
+		path = r.URL.Path
+		proto = r.ProtoMajor
+		w.Header().Set("apns-id", "push-id")
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	conf := TokenConfig{
+		AuthKey: authKey,
+		KeyID:   "KEYID",
+		TeamID:  "TEAMID",
+		Topic:   "com.example.mdm",
+	}
+	svc, err := NewTokenPushService(conf, srv.URL, srv.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := svc.Push("abcdef", nil, []byte(`{"mdm":"magic"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := id, "push-id"; have != want {
+		t.Errorf("have id %q, want %q", have, want)
+	}
+	if proto != 2 {
+		t.Errorf("have HTTP/%d, want HTTP/2", proto)
+	}
+	if have, want := path, "/3/device/abcdef"; have != want {
+		t.Errorf("have path %q, want %q", have, want)
+	}
+	if have, want := topic, conf.Topic; have != want {
+		t.Errorf("have topic %q, want %q", have, want)
+	}
+	if !strings.HasPrefix(authorization, "bearer ") {
+		t.Fatalf("have authorization %q, want a bearer token", authorization)
+	}
+	header, claims := verifyToken(t, &key.PublicKey, strings.TrimPrefix(authorization, "bearer "))
+	if header["alg"] != "ES256" || header["kid"] != "KEYID" {
+		t.Errorf("unexpected token header %v", header)
+	}
+	if claims["iss"] != "TEAMID" {
+		t.Errorf("unexpected token claims %v", claims)
+	}
+}
+
+func TestTokenSignerCache(t *testing.T) {
+	_, authKey := newAuthKey(t)
+	signer, err := NewTokenSigner(authKey, "KEYID", "TEAMID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	signer.now = func() time.Time { return now }
+
+	first, err := signer.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(tokenLifetime - time.Minute)
+	if cached, _ := signer.Token(); cached != first {
+		t.Error("expected the token to be reused within its lifetime")
+	}
+	now = now.Add(time.Minute)
+	if renewed, _ := signer.Token(); renewed == first {
+		t.Error("expected a new token after the token lifetime")
+	}
+}
+
+func TestGatewayURL(t *testing.T) {
+	tests := []struct {
+		env, override, want string
+		fail                bool
+	}{
+		{env: "", want: "https://api.push.apple.com"},
+		{env: "production", want: "https://api.push.apple.com"},
+		{env: "sandbox", want: "https://api.development.push.apple.com"},
+		{env: "sandbox", override: "https://localhost:2197", want: "https://localhost:2197"},
+		{env: "staging", fail: true},
+	}
+	for _, tt := range tests {
+		have, err := GatewayURL(tt.env, tt.override)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("%q: expected an error", tt.env)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != tt.want {
+			t.Errorf("%q: have %q, want %q", tt.env, have, tt.want)
+		}
+	}
+}
+
+func newAuthKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func verifyToken(t *testing.T, pub *ecdsa.PublicKey, token string) (header, claims map[string]interface{}) {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("malformed token %q", token)
+	}
+	decode := func(s string, v interface{}) {
+		data, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v == nil {
+			return
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	decode(parts[0], &header)
+	decode(parts[1], &claims)
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(sig[:len(sig)/2])
+	s := new(big.Int).SetBytes(sig[len(sig)/2:])
+	if !ecdsa.Verify(pub, digest[:], r, s) {
+		t.Fatal("token signature does not verify")
+	}
+	return header, claims
+}
@@ -0,0 +1,309 @@
+// Package archive queries the checkin and command events which are archived
+// to BoltDB and replays them onto pubsub.
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/platform/command"
+	"github.com/as/micromdm/platform/events/envelope"
+)
+
+const (
+	// DefaultLimit is the number of events returned by a query without a limit.
+	DefaultLimit = 100
+
+	// MaxLimit is the maximum number of events returned by a query.
+	MaxLimit = 1000
+)
+
+// source is an archive bucket.
+type source struct {
+	bucket string
+	topics []string
+	topic  func(msg []byte) (string, error)
+}
+
+var sources = []source{
+	{
+		bucket: checkin.CheckinBucket,
+		topics: []string{checkin.AuthenticateTopic, checkin.TokenUpdateTopic, checkin.CheckoutTopic},
+		topic:  checkinTopic,
+	},
+	{
+		bucket: command.CommandBucket,
+		topics: []string{command.CommandTopic},
+		topic:  func([]byte) (string, error) { return command.CommandTopic, nil },
+	},
+}
+
+// Topics are the topics of the archived events.
+func Topics() []string {
+	var topics []string
+	for _, src := range sources {
+		topics = append(topics, src.topics...)
+	}
+	return topics
+}
+
+// checkinTopic returns the topic a checkin event was published to.
+func checkinTopic(msg []byte) (string, error) {
+	var ev checkin.Event
+	if err := checkin.UnmarshalEvent(msg, &ev); err != nil {
+		return "", err
+	}
+	return checkin.EventTopic(&ev)
+}
+
+// Query selects archived events.
+type Query struct {
+	// From and To limit the events to the half-open time range [From, To).
+	// Zero values do not limit the range.
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+
+	// Topics selects the topics of the events. All topics if empty.
+	Topics []string `json:"topics,omitempty"`
+
+	// UDID selects the events of a single device.
+	UDID string `json:"udid,omitempty"`
+
+	// Limit is the maximum number of events returned.
+	Limit int `json:"limit,omitempty"`
+
+	// Cursor continues a previous query after its last event.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// Page is the result of a query.
+type Page struct {
+	Events []envelope.Envelope `json:"events"`
+
+	// NextCursor is set if there are more events matching the query.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Record is an archived event.
+type Record struct {
+	Topic   string
+	Time    time.Time
+	Message []byte
+
+	position position
+	envelope *envelope.Envelope
+}
+
+// position orders the records of all sources. Keys are the nanosecond
+// timestamps of the events and unique within a source.
+type position struct {
+	nano   int64
+	source int
+}
+
+func (p position) less(o position) bool {
+	if p.nano != o.nano {
+		return p.nano < o.nano
+	}
+	return p.source < o.source
+}
+
+func (p position) String() string {
+	return fmt.Sprintf("%d-%d", p.nano, p.source)
+}
+
+func parsePosition(s string) (position, error) {
+	i := strings.LastIndex(s, "-")
+	if i < 0 {
+		return position{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	nano, err := strconv.ParseInt(s[:i], 10, 64)
+	if err != nil {
+		return position{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	src, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return position{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	return position{nano: nano, source: src}, nil
+}
+
+// Archive reads the archive buckets.
+type Archive struct {
+	db *bolt.DB
+}
+
+func New(db *bolt.DB) (*Archive, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, src := range sources {
+			if _, err := tx.CreateBucketIfNotExists([]byte(src.bucket)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating archive buckets")
+	}
+	return &Archive{db: db}, nil
+}
+
+// Records returns up to q.Limit archived events matching the query, oldest
+// first, and the cursor of the next page.
+func (a *Archive) Records(q Query) ([]Record, string, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	var after *position
+	if q.Cursor != "" {
+		pos, err := parsePosition(q.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = &pos
+	}
+
+	var records []Record
+	err := a.db.View(func(tx *bolt.Tx) error {
+		for i, src := range sources {
+			if !src.selected(q.Topics) {
+				continue
+			}
+			b := tx.Bucket([]byte(src.bucket))
+			if b == nil {
+				continue
+			}
+			// every source contributes at most limit+1 records,
+			// which is enough to fill the page after merging.
+			matched, err := scan(b, i, src, q, after, limit+1)
+			if err != nil {
+				return errors.Wrapf(err, "read %s", src.bucket)
+			}
+			records = append(records, matched...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].position.less(records[j].position)
+	})
+	var next string
+	if len(records) > limit {
+		records = records[:limit]
+		next = records[limit-1].position.String()
+	}
+	return records, next, nil
+}
+
+func (src source) selected(topics []string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, t := range topics {
+		for _, st := range src.topics {
+			if t == st {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func scan(b *bolt.Bucket, i int, src source, q Query, after *position, max int) ([]Record, error) {
+	var start []byte
+	if !q.From.IsZero() {
+		start = nanoKey(q.From.UnixNano())
+	}
+	if after != nil {
+		if k := nanoKey(after.nano); bytes.Compare(k, start) > 0 {
+			start = k
+		}
+	}
+
+	var records []Record
+	c := b.Cursor()
+	k, v := c.First()
+	if start != nil {
+		k, v = c.Seek(start)
+	}
+	for ; k != nil && len(records) < max; k, v = c.Next() {
+		nano, err := strconv.ParseInt(string(k), 10, 64)
+		if err != nil {
+			continue // not an event key.
+		}
+		pos := position{nano: nano, source: i}
+		if after != nil && !after.less(pos) {
+			continue
+		}
+		if !q.To.IsZero() && nano >= q.To.UnixNano() {
+			break
+		}
+		topic, err := src.topic(v)
+		if err != nil {
+			return nil, err
+		}
+		if !contains(q.Topics, topic) {
+			continue
+		}
+		r, err := newRecord(topic, pos, v)
+		if err != nil {
+			return nil, err
+		}
+		if q.UDID != "" && r.envelope.UDID != q.UDID {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// newRecord copies the archived message, which is only valid during the
+// transaction, and decodes its envelope.
+func newRecord(topic string, pos position, v []byte) (Record, error) {
+	msg := make([]byte, len(v))
+	copy(msg, v)
+	env, err := envelope.New(topic, msg)
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{
+		Topic:    topic,
+		Time:     time.Unix(0, pos.nano).UTC(),
+		Message:  msg,
+		position: pos,
+		envelope: env,
+	}, nil
+}
+
+// nanoKey is the archive key of a nanosecond timestamp.
+func nanoKey(nano int64) []byte {
+	return []byte(fmt.Sprintf("%d", nano))
+}
+
+// contains reports whether topic is in topics. An empty list contains every topic.
+func contains(topics []string, topic string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
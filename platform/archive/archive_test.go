@@ -0,0 +1,265 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/platform/command"
+	"github.com/as/micromdm/platform/device"
+	devicebuiltin "github.com/as/micromdm/platform/device/builtin"
+	"github.com/as/micromdm/platform/pubsub/inmem"
+)
+
+var epoch = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRecords_Paging(t *testing.T) {
+	db := setupDB(t)
+	defer db.Close()
+	archive, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		putCheckin(t, db, i, "Authenticate", "device-a")
+		putCommand(t, db, i, "device-b")
+	}
+
+	var seen int
+	q := Query{Limit: 3}
+	for {
+		records, next, err := archive.Records(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range records {
+			if want := eventTime(seen / 2); !r.Time.Equal(want) {
+				t.Errorf("record %d: have time %s, want %s", seen, r.Time, want)
+			}
+			seen++
+		}
+		if next == "" {
+			break
+		}
+		q.Cursor = next
+	}
+	if have, want := seen, 10; have != want {
+		t.Errorf("have %d records, want %d", have, want)
+	}
+}
+
+func TestRecords_Filter(t *testing.T) {
+	db := setupDB(t)
+	defer db.Close()
+	archive, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	putCheckin(t, db, 0, "Authenticate", "device-a")
+	putCheckin(t, db, 1, "TokenUpdate", "device-a")
+	putCheckin(t, db, 2, "TokenUpdate", "device-b")
+	putCommand(t, db, 3, "device-a")
+	putCheckin(t, db, 4, "CheckOut", "device-a")
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{
+			name:  "all",
+			query: Query{},
+			want: []string{checkin.AuthenticateTopic, checkin.TokenUpdateTopic, checkin.TokenUpdateTopic,
+				command.CommandTopic, checkin.CheckoutTopic},
+		},
+		{
+			name:  "topic",
+			query: Query{Topics: []string{checkin.TokenUpdateTopic}},
+			want:  []string{checkin.TokenUpdateTopic, checkin.TokenUpdateTopic},
+		},
+		{
+			name:  "udid",
+			query: Query{UDID: "device-a", Topics: []string{checkin.TokenUpdateTopic, command.CommandTopic}},
+			want:  []string{checkin.TokenUpdateTopic, command.CommandTopic},
+		},
+		{
+			name:  "time range",
+			query: Query{From: eventTime(1), To: eventTime(4)},
+			want:  []string{checkin.TokenUpdateTopic, checkin.TokenUpdateTopic, command.CommandTopic},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, next, err := archive.Records(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next != "" {
+				t.Errorf("unexpected next cursor %q", next)
+			}
+			var topics []string
+			for _, r := range records {
+				topics = append(topics, r.Topic)
+			}
+			if fmt.Sprint(topics) != fmt.Sprint(tt.want) {
+				t.Errorf("have topics %v, want %v", topics, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayEvents(t *testing.T) {
+	db := setupDB(t)
+	defer db.Close()
+	archive, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < MaxLimit+1; i++ {
+		putCheckin(t, db, i, "TokenUpdate", "device-a")
+	}
+	putCommand(t, db, MaxLimit+1, "device-a")
+
+	pub := &mockPublisher{}
+	svc := NewService(archive, pub)
+	result, err := svc.ReplayEvents(context.Background(), Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := result.Published, MaxLimit+1; have != want {
+		t.Errorf("have %d published events, want %d", have, want)
+	}
+	if have, want := len(pub.topics), MaxLimit+1; have != want {
+		t.Fatalf("have %d publishes, want %d", have, want)
+	}
+	for _, topic := range pub.topics {
+		if topic != checkin.ReplayTopic {
+			t.Fatalf("replayed to %s, want %s", topic, checkin.ReplayTopic)
+		}
+	}
+	if have, want := result.Topics[checkin.TokenUpdateTopic], MaxLimit+1; have != want {
+		t.Errorf("have %d replayed TokenUpdate events, want %d", have, want)
+	}
+
+	_, err = svc.ReplayEvents(context.Background(), Query{Topics: []string{command.CommandTopic}})
+	if err == nil {
+		t.Error("replayed command events")
+	}
+}
+
+func TestReplayEvents_NoSideEffects(t *testing.T) {
+	db := setupDB(t)
+	defer db.Close()
+	archive, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	putCheckin(t, db, 0, "Authenticate", "device-a")
+	putCheckin(t, db, 1, "TokenUpdate", "device-a")
+
+	ps := inmem.NewPubSub()
+	devices, err := devicebuiltin.NewDB(db, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enrolled, err := ps.Subscribe(context.Background(), "test", device.DeviceEnrolledTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, err := ps.Subscribe(context.Background(), "test", command.CommandTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewService(archive, ps)
+	if _, err := svc.ReplayEvents(context.Background(), Query{}); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		dev, err := devices.DeviceByUDID("device-a")
+		if err == nil && dev.Enrolled {
+			if have, want := dev.LastCheckin, eventTime(1); !have.Equal(want) {
+				t.Errorf("have last checkin %s, want %s", have, want)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("device not rebuilt from the replayed checkins")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case <-enrolled:
+		t.Error("replay published a DeviceEnrolled event")
+	case <-commands:
+		t.Error("replay queued a command")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+type mockPublisher struct {
+	topics []string
+}
+
+func (m *mockPublisher) Publish(_ context.Context, topic string, _ []byte) error {
+	m.topics = append(m.topics, topic)
+	return nil
+}
+
+func eventTime(i int) time.Time {
+	return epoch.Add(time.Duration(i) * time.Second)
+}
+
+func putCheckin(t *testing.T, db *bolt.DB, i int, messageType, udid string) {
+	ev := checkin.NewEvent(mdm.CheckinCommand{MessageType: messageType, UDID: udid})
+	ev.Time = eventTime(i)
+	msg, err := checkin.MarshalEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	put(t, db, checkin.CheckinBucket, ev.Time, msg)
+}
+
+func putCommand(t *testing.T, db *bolt.DB, i int, udid string) {
+	ev := command.NewEvent(mdm.Payload{
+		CommandUUID: fmt.Sprintf("command-%d", i),
+		Command:     &mdm.Command{RequestType: "DeviceInformation"},
+	}, udid)
+	ev.Time = eventTime(i)
+	msg, err := command.MarshalEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	put(t, db, command.CommandBucket, ev.Time, msg)
+}
+
+func put(t *testing.T, db *bolt.DB, bucket string, ts time.Time, msg []byte) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucket)).Put(nanoKey(ts.UnixNano()), msg)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func setupDB(t *testing.T) *bolt.DB {
+	f, _ := ioutil.TempFile("", "bolt-")
+	f.Close()
+	os.Remove(f.Name())
+
+	db, err := bolt.Open(f.Name(), 0777, nil)
+	if err != nil {
+		t.Fatalf("couldn't open bolt, err %s\n", err)
+	}
+	return db
+}
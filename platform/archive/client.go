@@ -0,0 +1,45 @@
+package archive
+
+import (
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransport.ClientOption) (Service, error) {
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var listEventsEndpoint endpoint.Endpoint
+	{
+		listEventsEndpoint = httptransport.NewClient(
+			"GET",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeListEventsRequest),
+			decodeListEventsResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var replayEventsEndpoint endpoint.Endpoint
+	{
+		replayEventsEndpoint = httptransport.NewClient(
+			"POST",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeReplayEventsRequest),
+			decodeReplayEventsResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	return Endpoints{
+		ListEventsEndpoint:   listEventsEndpoint,
+		ReplayEventsEndpoint: replayEventsEndpoint,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package archive
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+	"github.com/as/micromdm/platform/events/envelope"
+)
+
+// ListEvents returns a page of archived events, oldest first.
+func (svc *ArchiveService) ListEvents(ctx context.Context, q Query) (*Page, error) {
+	records, next, err := svc.archive.Records(q)
+	if err != nil {
+		return nil, err
+	}
+	page := Page{
+		Events:     make([]envelope.Envelope, 0, len(records)),
+		NextCursor: next,
+	}
+	for _, r := range records {
+		page.Events = append(page.Events, *r.envelope)
+	}
+	return &page, nil
+}
+
+type listEventsRequest struct {
+	Query Query
+}
+
+type listEventsResponse struct {
+	*Page
+	Err error `json:"err,omitempty"`
+}
+
+func (r listEventsResponse) Failed() error { return r.Err }
+
+// decodeListEventsRequest reads the query from the URL parameters
+// from, to (RFC 3339), topic (repeated), udid, limit and cursor.
+func decodeListEventsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	values := r.URL.Query()
+	q := Query{
+		Topics: values["topic"],
+		UDID:   values.Get("udid"),
+		Cursor: values.Get("cursor"),
+	}
+	var err error
+	if q.From, err = parseTime(values.Get("from")); err != nil {
+		return nil, errors.Wrap(err, "parse from")
+	}
+	if q.To, err = parseTime(values.Get("to")); err != nil {
+		return nil, errors.Wrap(err, "parse to")
+	}
+	if limit := values.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil {
+			return nil, errors.Wrap(err, "parse limit")
+		}
+	}
+	return listEventsRequest{Query: q}, nil
+}
+
+func encodeListEventsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	q := request.(listEventsRequest).Query
+	values := url.Values{}
+	if !q.From.IsZero() {
+		values.Set("from", q.From.Format(time.RFC3339Nano))
+	}
+	if !q.To.IsZero() {
+		values.Set("to", q.To.Format(time.RFC3339Nano))
+	}
+	for _, topic := range q.Topics {
+		values.Add("topic", topic)
+	}
+	if q.UDID != "" {
+		values.Set("udid", q.UDID)
+	}
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Cursor != "" {
+		values.Set("cursor", q.Cursor)
+	}
+	r.Method, r.URL.Path, r.URL.RawQuery = "GET", "/v1/archive/events", values.Encode()
+	return nil
+}
+
+func decodeListEventsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp listEventsResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeListEventsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(listEventsRequest)
+		page, err := svc.ListEvents(ctx, req.Query)
+		return listEventsResponse{Page: page, Err: err}, nil
+	}
+}
+
+func (e Endpoints) ListEvents(ctx context.Context, q Query) (*Page, error) {
+	resp, err := e.ListEventsEndpoint(ctx, listEventsRequest{Query: q})
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(listEventsResponse)
+	return response.Page, response.Err
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
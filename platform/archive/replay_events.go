@@ -0,0 +1,110 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// ReplayResult counts the replayed events by topic.
+type ReplayResult struct {
+	Published int            `json:"published"`
+	Topics    map[string]int `json:"topics"`
+}
+
+// ReplayEvents publishes the archived checkin events matching the query to
+// checkin.ReplayTopic, in the order they were archived. Derived stores such
+// as devices and users rebuild their state from the replayed events. Replays
+// are not published to the original topics, so they do not enroll devices,
+// queue commands or reach webhooks, the event bus and the events stream.
+//
+// Only checkin events can be replayed. All checkin topics are replayed if
+// the query has no topics.
+func (svc *ArchiveService) ReplayEvents(ctx context.Context, q Query) (*ReplayResult, error) {
+	if q.Cursor != "" {
+		return nil, errors.New("archive: replay does not support a cursor")
+	}
+	if len(q.Topics) == 0 {
+		q.Topics = replayTopics
+	}
+	for _, topic := range q.Topics {
+		if !contains(replayTopics, topic) {
+			return nil, fmt.Errorf("archive: %s events can not be replayed, only checkin events", topic)
+		}
+	}
+	q.Limit = MaxLimit
+
+	result := ReplayResult{Topics: make(map[string]int)}
+	for {
+		records, next, err := svc.archive.Records(q)
+		if err != nil {
+			return &result, err
+		}
+		for _, r := range records {
+			if err := svc.publisher.Publish(ctx, checkin.ReplayTopic, r.Message); err != nil {
+				return &result, err
+			}
+			result.Published++
+			result.Topics[r.Topic]++
+		}
+		if next == "" {
+			return &result, nil
+		}
+		q.Cursor = next
+	}
+}
+
+// replayTopics are the topics of the events ReplayEvents can replay.
+var replayTopics = []string{checkin.AuthenticateTopic, checkin.TokenUpdateTopic, checkin.CheckoutTopic}
+
+type replayEventsRequest struct {
+	Query
+}
+
+type replayEventsResponse struct {
+	*ReplayResult
+	Err error `json:"err,omitempty"`
+}
+
+func (r replayEventsResponse) Failed() error { return r.Err }
+
+func decodeReplayEventsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req replayEventsRequest
+	err := httputil.DecodeJSONRequest(r, &req)
+	return req, err
+}
+
+func encodeReplayEventsRequest(ctx context.Context, r *http.Request, request interface{}) error {
+	r.Method, r.URL.Path = "POST", "/v1/archive/replay"
+	return httptransport.EncodeJSONRequest(ctx, r, request)
+}
+
+func decodeReplayEventsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp replayEventsResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeReplayEventsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(replayEventsRequest)
+		result, err := svc.ReplayEvents(ctx, req.Query)
+		return replayEventsResponse{ReplayResult: result, Err: err}, nil
+	}
+}
+
+func (e Endpoints) ReplayEvents(ctx context.Context, q Query) (*ReplayResult, error) {
+	resp, err := e.ReplayEventsEndpoint(ctx, replayEventsRequest{Query: q})
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(replayEventsResponse)
+	return response.ReplayResult, response.Err
+}
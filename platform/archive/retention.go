@@ -0,0 +1,264 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/events/envelope"
+)
+
+// segmentSize is the maximum number of events removed, and exported to a
+// single file, at once.
+const segmentSize = 10000
+
+// Retention limits the events kept in every archive bucket.
+// Zero values do not limit the archive.
+type Retention struct {
+	// MaxAge removes the events older than the duration.
+	MaxAge time.Duration
+
+	// MaxSize removes the oldest events until the keys and values of
+	// the bucket take up at most MaxSize bytes.
+	MaxSize int64
+}
+
+func (r Retention) enabled() bool {
+	return r.MaxAge > 0 || r.MaxSize > 0
+}
+
+// Compactor removes the archived events outside of the retention policy.
+type Compactor struct {
+	archive   *Archive
+	retention Retention
+	exportDir string
+	gzip      bool
+	now       func() time.Time
+}
+
+type CompactorOption func(*Compactor)
+
+// WithExportDir writes the expired events of every compaction to a file in
+// dir before they are removed from the archive. Every line of the file is a
+// JSON event envelope.
+func WithExportDir(dir string) CompactorOption {
+	return func(c *Compactor) {
+		c.exportDir = dir
+	}
+}
+
+// WithGzip compresses the export files.
+func WithGzip() CompactorOption {
+	return func(c *Compactor) {
+		c.gzip = true
+	}
+}
+
+// NewCompactor creates a Compactor and starts a janitor which compacts the
+// archive every interval. The janitor is not started if the retention does
+// not limit the archive.
+func NewCompactor(archive *Archive, retention Retention, interval time.Duration, opts ...CompactorOption) (*Compactor, error) {
+	c := &Compactor{
+		archive:   archive,
+		retention: retention,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.exportDir != "" {
+		if err := os.MkdirAll(c.exportDir, 0755); err != nil {
+			return nil, errors.Wrap(err, "create archive export directory")
+		}
+	}
+	if retention.enabled() {
+		go c.janitor(interval)
+	}
+	return c, nil
+}
+
+func (c *Compactor) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := c.Compact(); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// Compact exports and removes the expired events of every archive bucket.
+//
+// An export file is complete before its events are removed. If the removal
+// fails, the events are exported again by the next compaction.
+func (c *Compactor) Compact() error {
+	if !c.retention.enabled() {
+		return nil
+	}
+	for i, src := range sources {
+		for {
+			expired, err := c.expired(i, src)
+			if err != nil {
+				return errors.Wrapf(err, "compact %s", src.bucket)
+			}
+			if len(expired) == 0 {
+				break
+			}
+			if c.exportDir != "" {
+				if err := c.export(src, expired); err != nil {
+					return errors.Wrapf(err, "export %s", src.bucket)
+				}
+			}
+			if err := c.remove(src, expired); err != nil {
+				return errors.Wrapf(err, "compact %s", src.bucket)
+			}
+			if len(expired) < segmentSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// expired returns up to segmentSize of the oldest events of the source which
+// are outside of the retention policy.
+func (c *Compactor) expired(i int, src source) ([]Record, error) {
+	var cutoff int64
+	if c.retention.MaxAge > 0 {
+		cutoff = c.now().Add(-c.retention.MaxAge).UnixNano()
+	}
+
+	var records []Record
+	err := c.archive.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(src.bucket))
+		if b == nil {
+			return nil
+		}
+		var excess int64
+		if c.retention.MaxSize > 0 {
+			err := b.ForEach(func(k, v []byte) error {
+				excess += int64(len(k) + len(v))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			excess -= c.retention.MaxSize
+		}
+
+		cur := b.Cursor()
+		for k, v := cur.First(); k != nil && len(records) < segmentSize; k, v = cur.Next() {
+			nano, err := strconv.ParseInt(string(k), 10, 64)
+			if err != nil {
+				continue // not an event key.
+			}
+			if nano >= cutoff && excess <= 0 {
+				break
+			}
+			excess -= int64(len(k) + len(v))
+			records = append(records, expiredRecord(src, position{nano: nano, source: i}, v))
+		}
+		return nil
+	})
+	return records, err
+}
+
+// expiredRecord decodes an expired event. Events which can not be decoded are
+// exported with the archived message as the payload.
+func expiredRecord(src source, pos position, v []byte) Record {
+	topic, err := src.topic(v)
+	if err == nil {
+		if r, err := newRecord(topic, pos, v); err == nil {
+			return r
+		}
+	}
+	msg := make([]byte, len(v))
+	copy(msg, v)
+	return Record{
+		Topic:    topic,
+		Time:     time.Unix(0, pos.nano).UTC(),
+		Message:  msg,
+		position: pos,
+		envelope: &envelope.Envelope{
+			Version:   envelope.Version,
+			Topic:     topic,
+			CreatedAt: time.Unix(0, pos.nano).UTC(),
+			Payload:   msg,
+		},
+	}
+}
+
+func (c *Compactor) remove(src source, records []Record) error {
+	return c.archive.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(src.bucket))
+		for _, r := range records {
+			if err := b.Delete(nanoKey(r.position.nano)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// export writes the records to a new file named after the bucket and the
+// time range of the records, like
+// mdm.Checkin.ARCHIVE-20180101T000000.000000000Z-20180102T000000.000000000Z.ndjson.gz
+func (c *Compactor) export(src source, records []Record) error {
+	const layout = "20060102T150405.000000000Z"
+	name := fmt.Sprintf("%s-%s-%s.ndjson", src.bucket,
+		records[0].Time.Format(layout), records[len(records)-1].Time.Format(layout))
+	if c.gzip {
+		name += ".gz"
+	}
+
+	f, err := ioutil.TempFile(c.exportDir, ".export-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := writeRecords(f, records, c.gzip); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(c.exportDir, name))
+}
+
+// writeRecords writes the envelope of every record as a line of JSON.
+func writeRecords(w io.Writer, records []Record, compress bool) error {
+	bw := bufio.NewWriter(w)
+	var out io.Writer = bw
+	var zw *gzip.Writer
+	if compress {
+		zw = gzip.NewWriter(bw)
+		out = zw
+	}
+	enc := json.NewEncoder(out)
+	for _, r := range records {
+		if err := enc.Encode(r.envelope); err != nil {
+			return err
+		}
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,134 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/platform/command"
+	"github.com/as/micromdm/platform/events/envelope"
+)
+
+func TestCompact_MaxAge(t *testing.T) {
+	db := setupDB(t)
+	defer db.Close()
+	archive, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		putCheckin(t, db, i, "TokenUpdate", "device-a")
+	}
+	putCommand(t, db, 2, "device-a")
+
+	dir, err := ioutil.TempDir("", "archive-export-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	compactor, err := NewCompactor(archive, Retention{}, time.Hour, WithExportDir(dir), WithGzip())
+	if err != nil {
+		t.Fatal(err)
+	}
+	compactor.retention.MaxAge = time.Minute
+	compactor.now = func() time.Time { return eventTime(60 + 6) }
+	if err := compactor.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, _, err := archive.Records(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(records), 4; have != want {
+		t.Fatalf("have %d records after compaction, want %d", have, want)
+	}
+	if have, want := records[0].Time, eventTime(6); !have.Equal(want) {
+		t.Errorf("have oldest record at %s, want %s", have, want)
+	}
+
+	checkins := readExport(t, dir, checkin.CheckinBucket)
+	if have, want := len(checkins), 6; have != want {
+		t.Errorf("have %d exported checkins, want %d", have, want)
+	}
+	for _, env := range checkins {
+		if env.Topic != checkin.TokenUpdateTopic || env.UDID != "device-a" {
+			t.Errorf("exported unexpected envelope %+v", env)
+		}
+	}
+	if have, want := len(readExport(t, dir, command.CommandBucket)), 1; have != want {
+		t.Errorf("have %d exported commands, want %d", have, want)
+	}
+}
+
+func TestCompact_MaxSize(t *testing.T) {
+	db := setupDB(t)
+	defer db.Close()
+	archive, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		putCheckin(t, db, i, "TokenUpdate", "device-a")
+	}
+	records, _, err := archive.Records(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var size int64
+	for _, r := range records[7:] {
+		size += int64(len(nanoKey(r.position.nano)) + len(r.Message))
+	}
+
+	compactor, err := NewCompactor(archive, Retention{}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compactor.retention.MaxSize = size
+	if err := compactor.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, _, err = archive.Records(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(records), 3; have != want {
+		t.Errorf("have %d records after compaction, want %d", have, want)
+	}
+}
+
+func readExport(t *testing.T, dir, bucket string) []envelope.Envelope {
+	files, err := filepath.Glob(filepath.Join(dir, bucket+"-*.ndjson.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var envelopes []envelope.Envelope
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(zr)
+		for scanner.Scan() {
+			var env envelope.Envelope
+			if err := json.Unmarshal(scanner.Bytes(), &env); err != nil {
+				t.Fatal(err)
+			}
+			envelopes = append(envelopes, env)
+		}
+		f.Close()
+	}
+	return envelopes
+}
@@ -0,0 +1,45 @@
+package archive
+
+import (
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+type Endpoints struct {
+	ListEventsEndpoint   endpoint.Endpoint
+	ReplayEventsEndpoint endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		ListEventsEndpoint:   MakeListEventsEndpoint(s),
+		ReplayEventsEndpoint: MakeReplayEventsEndpoint(s),
+	}
+}
+
+func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
+	r, options := httputil.NewRouter(logger)
+
+	// GET		/v1/archive/events		page through archived checkin and command events
+	// POST		/v1/archive/replay		publish archived events to pubsub again
+
+	r.Methods("GET").Path("/v1/archive/events").Handler(httptransport.NewServer(
+		e.ListEventsEndpoint,
+		decodeListEventsRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/v1/archive/replay").Handler(httptransport.NewServer(
+		e.ReplayEventsEndpoint,
+		decodeReplayEventsRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	return r
+}
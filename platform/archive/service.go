@@ -0,0 +1,22 @@
+package archive
+
+import (
+	"context"
+
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+type Service interface {
+	ListEvents(ctx context.Context, q Query) (*Page, error)
+	ReplayEvents(ctx context.Context, q Query) (*ReplayResult, error)
+}
+
+type ArchiveService struct {
+	archive   *Archive
+	publisher pubsub.Publisher
+}
+
+// NewService creates a Service which replays archived events to pub.
+func NewService(archive *Archive, pub pubsub.Publisher) *ArchiveService {
+	return &ArchiveService{archive: archive, publisher: pub}
+}
@@ -79,11 +79,22 @@ func (db *DB) ApplyToDevice(ctx context.Context, svc command.Service, bp *bluepr
 		})
 	}
 
+	// profiles are installed in the order they are listed in the blueprint.
+	// A profile is only sent once the device acknowledged the previous one,
+	// and fails if the previous one failed.
+	var lastProfile string
 	for _, r := range requests {
-		_, err := svc.NewCommand(ctx, r)
+		isProfile := r.RequestType == "InstallProfile"
+		if isProfile && lastProfile != "" {
+			r.After = []string{lastProfile}
+		}
+		payload, err := svc.NewCommand(ctx, r)
 		if err != nil {
 			return errors.Wrap(err, "create new command from blueprint")
 		}
+		if isProfile {
+			lastProfile = payload.CommandUUID
+		}
 	}
 	return nil
 }
@@ -96,52 +107,57 @@ func (db *DB) StartListener(sub pubsub.Subscriber, cmdSvc command.Service) error
 	}
 
 	go func() {
-		for {
-			select {
-			case event := <-tokenUpdateEvents:
-				var ev checkin.Event
-				if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				if ev.Command.UserID != "" {
-					// skip UserID token updates
-					continue
-				}
-				bps, err := db.BlueprintsByApplyAt(blueprint.ApplyAtEnroll)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-				ctx := context.Background()
-				for _, bp := range bps {
-					fmt.Printf("applying blueprint %s to %s\n", bp.Name, ev.Command.UDID)
-					err := db.ApplyToDevice(ctx, cmdSvc, bp, ev.Command.UDID)
-					if err != nil {
-						fmt.Println(err)
-					}
-				}
+		for event := range tokenUpdateEvents {
+			if err := db.applyAtEnroll(cmdSvc, event); err != nil {
+				fmt.Println(err)
+			}
+			if err := event.Ack(); err != nil {
+				fmt.Println(err)
+			}
+		}
+	}()
 
-				if ev.Command.AwaitingConfiguration {
-					_, err := cmdSvc.NewCommand(ctx, &mdm.CommandRequest{
-						Command: mdm.Command{RequestType: "DeviceConfigured"},
-						UDID:    ev.Command.UDID,
-					})
-					if err != nil {
-						fmt.Println(errors.Wrapf(err, "sending DeviceConfigured"))
-					}
-				}
+	return nil
+}
 
-				// TODO: See notes from here:
-				// https://github.com/jessepeterson/micromdm/blob/8b068ac98d06954bb3e08b1557c193007932552b/blueprint/listener.go#L73-L103
-				// Also see discussion here for general direction:
-				// https://github.com/as/micromdm/pull/149
-				// Finally see discussion here for high-level goals:
-				// https://github.com/as/micromdm/issues/110
-			}
+// applyAtEnroll applies the ApplyAtEnroll blueprints to a newly enrolled device.
+func (db *DB) applyAtEnroll(cmdSvc command.Service, event pubsub.Event) error {
+	var ev checkin.Event
+	if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	if ev.Command.UserID != "" {
+		// skip UserID token updates
+		return nil
+	}
+	bps, err := db.BlueprintsByApplyAt(blueprint.ApplyAtEnroll)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	for _, bp := range bps {
+		fmt.Printf("applying blueprint %s to %s\n", bp.Name, ev.Command.UDID)
+		err := db.ApplyToDevice(ctx, cmdSvc, bp, ev.Command.UDID)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
 
+	if ev.Command.AwaitingConfiguration {
+		_, err := cmdSvc.NewCommand(ctx, &mdm.CommandRequest{
+			Command: mdm.Command{RequestType: "DeviceConfigured"},
+			UDID:    ev.Command.UDID,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "sending DeviceConfigured")
 		}
-	}()
+	}
 
+	// TODO: See notes from here:
+	// https://github.com/jessepeterson/micromdm/blob/8b068ac98d06954bb3e08b1557c193007932552b/blueprint/listener.go#L73-L103
+	// Also see discussion here for general direction:
+	// https://github.com/as/micromdm/pull/149
+	// Finally see discussion here for high-level goals:
+	// https://github.com/as/micromdm/issues/110
 	return nil
 }
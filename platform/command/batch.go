@@ -0,0 +1,226 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/platform/command/internal/commandproto"
+	"github.com/as/micromdm/platform/device"
+)
+
+// BatchBucket is the *bolt.DB bucket where batches of commands are stored.
+const BatchBucket = "mdm.CommandBatches"
+
+// BatchRequest is a CommandRequest targeting a set of devices.
+// The set is the union of the UDIDs, the devices with the serial numbers
+// and the devices matched by the Selector.
+type BatchRequest struct {
+	UDIDs    []string         `json:"udids,omitempty"`
+	Serials  []string         `json:"serials,omitempty"`
+	Selector *device.Selector `json:"selector,omitempty"`
+
+	// Request is the command queued for every device. The UDID is ignored.
+	Request mdm.CommandRequest `json:"-"`
+}
+
+// Batch records the commands created by a BatchRequest.
+type Batch struct {
+	ID          string         `json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	RequestType string         `json:"request_type"`
+	Commands    []BatchCommand `json:"commands"`
+}
+
+// BatchCommand is a single command of a Batch.
+type BatchCommand struct {
+	UDID        string `json:"udid"`
+	CommandUUID string `json:"command_uuid"`
+}
+
+// DeviceStore resolves the devices targeted by a BatchRequest.
+type DeviceStore interface {
+	List() ([]device.Device, error)
+	DeviceBySerial(serial string) (*device.Device, error)
+}
+
+type Option func(*Command)
+
+// WithDeviceStore allows batches to target devices by serial number
+// or selector.
+func WithDeviceStore(store DeviceStore) Option {
+	return func(svc *Command) {
+		svc.devices = store
+	}
+}
+
+func (svc *Command) NewBatch(ctx context.Context, request *BatchRequest) (*Batch, error) {
+	if request == nil {
+		return nil, errors.New("empty BatchRequest")
+	}
+	udids, err := svc.batchTargets(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(udids) == 0 {
+		return nil, errors.New("batch request matched no devices")
+	}
+
+	batch := &Batch{
+		ID:          uuid.NewV4().String(),
+		CreatedAt:   time.Now().UTC(),
+		RequestType: request.Request.RequestType,
+	}
+	for _, udid := range udids {
+		req := request.Request
+		req.UDID = udid
+		payload, err := svc.NewCommand(ctx, &req)
+		if err != nil {
+			err = errors.Wrapf(err, "create command for device %s in batch %s", udid, batch.ID)
+			// keep track of the commands which were already queued.
+			if saveErr := svc.saveBatch(batch); saveErr != nil {
+				return nil, errors.Wrap(saveErr, err.Error())
+			}
+			return batch, err
+		}
+		batch.Commands = append(batch.Commands, BatchCommand{
+			UDID:        udid,
+			CommandUUID: payload.CommandUUID,
+		})
+	}
+	if err := svc.saveBatch(batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// batchTargets returns the UDIDs of the devices targeted by a request,
+// without duplicates.
+func (svc *Command) batchTargets(request *BatchRequest) ([]string, error) {
+	var udids []string
+	seen := make(map[string]bool)
+	add := func(udid string) {
+		if udid == "" || seen[udid] {
+			return
+		}
+		seen[udid] = true
+		udids = append(udids, udid)
+	}
+
+	for _, udid := range request.UDIDs {
+		add(udid)
+	}
+	if len(request.Serials) == 0 && request.Selector == nil {
+		return udids, nil
+	}
+	if svc.devices == nil {
+		return nil, errors.New("targeting devices by serial or selector is not supported")
+	}
+	for _, serial := range request.Serials {
+		dev, err := svc.devices.DeviceBySerial(serial)
+		if err != nil {
+			return nil, errors.Wrapf(err, "find device with serial %s", serial)
+		}
+		add(dev.UDID)
+	}
+	if request.Selector != nil {
+		devices, err := svc.devices.List()
+		if err != nil {
+			return nil, errors.Wrap(err, "list devices for batch selector")
+		}
+		for _, dev := range devices {
+			if request.Selector.Match(dev) {
+				add(dev.UDID)
+			}
+		}
+	}
+	return udids, nil
+}
+
+// Batch returns a batch of commands by ID.
+func (svc *Command) Batch(id string) (*Batch, error) {
+	var batch Batch
+	err := svc.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(BatchBucket)).Get([]byte(id))
+		if v == nil {
+			return &notFound{"Batch", fmt.Sprintf("id %s", id)}
+		}
+		return UnmarshalBatch(v, &batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (svc *Command) saveBatch(batch *Batch) error {
+	v, err := MarshalBatch(batch)
+	if err != nil {
+		return err
+	}
+	err = svc.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(BatchBucket))
+		if bkt == nil {
+			return fmt.Errorf("bucket %q not found!", BatchBucket)
+		}
+		return bkt.Put([]byte(batch.ID), v)
+	})
+	return errors.Wrap(err, "save command batch")
+}
+
+// MarshalBatch serializes a batch to a protocol buffer wire format.
+func MarshalBatch(b *Batch) ([]byte, error) {
+	pb := commandproto.Batch{
+		Id:          b.ID,
+		CreatedAt:   b.CreatedAt.UnixNano(),
+		RequestType: b.RequestType,
+	}
+	for _, cmd := range b.Commands {
+		pb.Commands = append(pb.Commands, &commandproto.BatchCommand{
+			Udid:        cmd.UDID,
+			CommandUuid: cmd.CommandUUID,
+		})
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBatch parses a protocol buffer representation of data into
+// the Batch.
+func UnmarshalBatch(data []byte, b *Batch) error {
+	var pb commandproto.Batch
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal pb Batch")
+	}
+	b.ID = pb.GetId()
+	b.CreatedAt = time.Unix(0, pb.GetCreatedAt()).UTC()
+	b.RequestType = pb.GetRequestType()
+	b.Commands = nil
+	for _, cmd := range pb.GetCommands() {
+		b.Commands = append(b.Commands, BatchCommand{
+			UDID:        cmd.GetUdid(),
+			CommandUUID: cmd.GetCommandUuid(),
+		})
+	}
+	return nil
+}
+
+type notFound struct {
+	ResourceType string
+	Message      string
+}
+
+func (e *notFound) Error() string {
+	return fmt.Sprintf("not found: %s %s", e.ResourceType, e.Message)
+}
+
+// IsNotFound returns true if the error is a not found error.
+func IsNotFound(err error) bool {
+	_, ok := errors.Cause(err).(*notFound)
+	return ok
+}
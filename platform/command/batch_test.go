@@ -0,0 +1,82 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/platform/device"
+)
+
+func TestService_NewBatch(t *testing.T) {
+	svc := setupDB(t)
+	var published int
+	svc.publisher = &mockPublisher{PublishFn: func(string, []byte) error {
+		published++
+		return nil
+	}}
+	svc.devices = &mockDeviceStore{devices: []device.Device{
+		{UDID: "aDevice", SerialNumber: "aSerial", OSVersion: "11.2", Enrolled: true},
+		{UDID: "bDevice", SerialNumber: "bSerial", OSVersion: "10.3.3", Enrolled: true},
+		{UDID: "cDevice", SerialNumber: "cSerial", OSVersion: "11.0", Enrolled: false},
+	}}
+
+	enrolled := true
+	request := &BatchRequest{
+		UDIDs:    []string{"xDevice", "aDevice"},
+		Serials:  []string{"bSerial"},
+		Selector: &device.Selector{OSVersion: "11", Enrolled: &enrolled},
+		Request: mdm.CommandRequest{
+			Command: mdm.Command{RequestType: "DeviceInformation"},
+		},
+	}
+	batch, err := svc.NewBatch(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var udids []string
+	for _, cmd := range batch.Commands {
+		udids = append(udids, cmd.UDID)
+		if cmd.CommandUUID == "" {
+			t.Errorf("expected command UUID for device %s", cmd.UDID)
+		}
+	}
+	if want := []string{"xDevice", "aDevice", "bDevice"}; !reflect.DeepEqual(udids, want) {
+		t.Errorf("have %v, want %v", udids, want)
+	}
+	if have, want := published, 3; have != want {
+		t.Errorf("have %d published commands, want %d", have, want)
+	}
+
+	stored, err := svc.Batch(batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored.Commands, batch.Commands) || stored.RequestType != "DeviceInformation" {
+		t.Errorf("have %+v, want %+v", stored, batch)
+	}
+
+	if _, err := svc.Batch("unknown"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+type mockDeviceStore struct {
+	devices []device.Device
+}
+
+func (m *mockDeviceStore) List() ([]device.Device, error) {
+	return m.devices, nil
+}
+
+func (m *mockDeviceStore) DeviceBySerial(serial string) (*device.Device, error) {
+	for _, dev := range m.devices {
+		if dev.SerialNumber == serial {
+			return &dev, nil
+		}
+	}
+	return nil, errors.New("device not found")
+}
@@ -0,0 +1,155 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransport.ClientOption) (Service, error) {
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var newCommandEndpoint endpoint.Endpoint
+	{
+		newCommandEndpoint = httptransport.NewClient(
+			"POST",
+			httputil.CopyURL(u, "/v1/commands"),
+			httputil.EncodeRequestWithToken(token, encodeNewCommandRequest),
+			decodeNewCommandResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var newBatchEndpoint endpoint.Endpoint
+	{
+		newBatchEndpoint = httptransport.NewClient(
+			"POST",
+			httputil.CopyURL(u, "/v1/commands"),
+			httputil.EncodeRequestWithToken(token, encodeNewBatchRequest),
+			decodeNewBatchResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	return Endpoints{
+		NewCommandEndpoint: newCommandEndpoint,
+		NewBatchEndpoint:   newBatchEndpoint,
+	}, nil
+}
+
+func (e Endpoints) NewCommand(ctx context.Context, req *mdm.CommandRequest) (*mdm.Payload, error) {
+	request := newCommandRequest{CommandRequest: *req}
+	resp, err := e.NewCommandEndpoint(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(newCommandResponse)
+	return response.Payload, response.Err
+}
+
+func (e Endpoints) NewBatch(ctx context.Context, req *BatchRequest) (*Batch, error) {
+	request := newBatchRequest{BatchRequest: *req}
+	resp, err := e.NewBatchEndpoint(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(newBatchResponse)
+	return response.Batch, response.Err
+}
+
+func encodeNewCommandRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(newCommandRequest)
+	return encodeJSONBody(r, req.CommandRequest)
+}
+
+// encodeNewBatchRequest encodes the command request together with the
+// targets of the batch in a single JSON object.
+func encodeNewBatchRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(newBatchRequest)
+	var body map[string]interface{}
+	data, err := json.Marshal(req.Request)
+	if err != nil {
+		return errors.Wrap(err, "encode batch command request")
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return errors.Wrap(err, "encode batch command request")
+	}
+	delete(body, "udid")
+	if len(req.UDIDs) > 0 {
+		body["udids"] = req.UDIDs
+	}
+	if len(req.Serials) > 0 {
+		body["serials"] = req.Serials
+	}
+	if req.Selector != nil {
+		body["selector"] = req.Selector
+	}
+	return encodeJSONBody(r, body)
+}
+
+func encodeJSONBody(r *http.Request, v interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	r.Body = ioutil.NopCloser(&buf)
+	r.ContentLength = int64(buf.Len())
+	return nil
+}
+
+// clientResponse is the JSON encoding of the command service responses.
+// Errors are encoded as an "error" string by encodeResponse.
+type clientResponse struct {
+	Payload *mdm.Payload `json:"payload"`
+	Batch   *Batch       `json:"batch"`
+	Error   string       `json:"error"`
+}
+
+func decodeClientResponse(r *http.Response) (clientResponse, error) {
+	defer r.Body.Close()
+	var resp clientResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return resp, errors.Wrapf(err, "decode command response, status %s", r.Status)
+	}
+	if resp.Error == "" && r.StatusCode != http.StatusCreated {
+		return resp, errors.Errorf("unexpected command response status %s", r.Status)
+	}
+	return resp, nil
+}
+
+func decodeNewCommandResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	resp, err := decodeClientResponse(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return newCommandResponse{Err: errors.New(resp.Error)}, nil
+	}
+	return newCommandResponse{Payload: resp.Payload}, nil
+}
+
+func decodeNewBatchResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	resp, err := decodeClientResponse(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return newBatchResponse{Err: errors.New(resp.Error)}, nil
+	}
+	return newBatchResponse{Batch: resp.Batch}, nil
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/pkg/errors"
@@ -23,11 +24,15 @@ const (
 type Command struct {
 	db        *bolt.DB
 	publisher pubsub.Publisher
+	devices   DeviceStore
 	archiveFn func(int64, []byte) error
 }
 
-func New(db *bolt.DB, pub pubsub.Publisher) (*Command, error) {
+func New(db *bolt.DB, pub pubsub.Publisher, opts ...Option) (*Command, error) {
 	err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists([]byte(BatchBucket)); err != nil {
+			return err
+		}
 		_, err := tx.CreateBucketIfNotExists([]byte(CommandBucket))
 		return err
 	})
@@ -38,6 +43,9 @@ func New(db *bolt.DB, pub pubsub.Publisher) (*Command, error) {
 		db:        db,
 		publisher: pub,
 	}
+	for _, opt := range opts {
+		opt(&svc)
+	}
 	svc.archiveFn = svc.archive
 	return &svc, nil
 }
@@ -51,6 +59,9 @@ func (svc *Command) NewCommand(ctx context.Context, request *mdm.CommandRequest)
 		return nil, errors.Wrap(err, "creating mdm payload")
 	}
 	event := NewEvent(*payload, request.UDID)
+	event.TTL = time.Duration(request.TTL) * time.Second
+	event.Priority = request.Priority
+	event.After = request.After
 	msg, err := MarshalEvent(event)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshalling mdm command event")
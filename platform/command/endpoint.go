@@ -13,10 +13,14 @@ import (
 	"github.com/go-kit/kit/metrics"
 )
 
-var errEmptyRequest = errors.New("request must contain UDID of the device")
+var (
+	errEmptyRequest      = errors.New("request must contain UDID of the device")
+	errEmptyBatchRequest = errors.New("request must contain a request_type and udids, serials or a selector")
+)
 
 type Endpoints struct {
 	NewCommandEndpoint endpoint.Endpoint
+	NewBatchEndpoint   endpoint.Endpoint
 }
 
 // MakeNewCommandEndpoint creates an endpoint which creates new MDM Commands.
@@ -34,6 +38,22 @@ func MakeNewCommandEndpoint(svc Service) endpoint.Endpoint {
 	}
 }
 
+// MakeNewBatchEndpoint creates an endpoint which creates a batch of MDM Commands
+// for a set of devices.
+func MakeNewBatchEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(newBatchRequest)
+		if len(req.UDIDs) == 0 && len(req.Serials) == 0 && req.Selector == nil {
+			return newBatchResponse{Err: errEmptyBatchRequest}, nil
+		}
+		if req.Request.RequestType == "" {
+			return newBatchResponse{Err: errEmptyBatchRequest}, nil
+		}
+		batch, err := svc.NewBatch(ctx, &req.BatchRequest)
+		return newBatchResponse{Batch: batch, Err: err}, nil
+	}
+}
+
 // EndpointInstrumentingMiddleware returns an endpoint middleware that records
 // the duration of each invocation to the passed histogram. The middleware adds
 // a single field: "success", which is "true" if no error is returned, and
@@ -76,3 +96,15 @@ type newCommandResponse struct {
 
 func (r newCommandResponse) error() error { return r.Err }
 func (r newCommandResponse) status() int  { return http.StatusCreated }
+
+type newBatchRequest struct {
+	BatchRequest
+}
+
+type newBatchResponse struct {
+	Batch *Batch `json:"batch,omitempty"`
+	Err   error  `json:"error,omitempty"`
+}
+
+func (r newBatchResponse) error() error { return r.Err }
+func (r newBatchResponse) status() int  { return http.StatusCreated }
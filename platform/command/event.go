@@ -1,6 +1,7 @@
 package command
 
 import (
+	"sort"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -16,6 +17,15 @@ type Event struct {
 	Time       time.Time
 	Payload    mdm.Payload
 	DeviceUDID string
+
+	// TTL is the duration after which the queued command expires.
+	// Zero means the command does not expire.
+	TTL time.Duration
+
+	// Priority and After order the command in the device queue.
+	// See mdm.CommandRequest.
+	Priority int
+	After    []string
 }
 
 // NewEvent returns an Event with a unique ID and the current time.
@@ -33,53 +43,91 @@ func NewEvent(cmd mdm.Payload, udid string) *Event {
 func MarshalEvent(e *Event) ([]byte, error) {
 	payload := &commandproto.Payload{
 		CommandUuid: e.Payload.CommandUUID,
+		Command:     commandToProto(e.Payload.Command),
 	}
-	if e.Payload.Command != nil {
-		payload.Command = &commandproto.Command{
-			RequestType: e.Payload.Command.RequestType,
-		}
+	return proto.Marshal(&commandproto.Event{
+		Id:         e.ID,
+		Time:       e.Time.UnixNano(),
+		Payload:    payload,
+		DeviceUdid: e.DeviceUDID,
+		Ttl:        int64(e.TTL),
+		Priority:   int64(e.Priority),
+		After:      e.After,
+	})
+
+}
+
+// UnmarshalEvent parses a protocol buffer representation of data into
+// the Event.
+func UnmarshalEvent(data []byte, e *Event) error {
+	var pb commandproto.Event
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal pb Event")
+	}
+	e.ID = pb.Id
+	e.DeviceUDID = pb.DeviceUdid
+	e.TTL = time.Duration(pb.Ttl)
+	e.Priority = int(pb.Priority)
+	e.After = pb.After
+	e.Time = time.Unix(0, pb.Time).UTC()
+	if pb.Payload == nil {
+		return nil
+	}
+	e.Payload = mdm.Payload{
+		CommandUUID: pb.Payload.CommandUuid,
+		Command:     commandFromProto(pb.Payload.Command),
+	}
+	return nil
+}
+
+// commandToProto converts the fields of the command for its RequestType.
+func commandToProto(c *mdm.Command) *commandproto.Command {
+	if c == nil {
+		return nil
 	}
-	switch e.Payload.Command.RequestType {
+	pb := &commandproto.Command{
+		RequestType: c.RequestType,
+	}
+	switch c.RequestType {
 	case "DeviceLock":
-		payload.Command.DeviceLock = &commandproto.DeviceLock{
-			Pin:         e.Payload.Command.DeviceLock.PIN,
-			Message:     e.Payload.Command.DeviceLock.Message,
-			PhoneNumber: e.Payload.Command.DeviceLock.PhoneNumber,
+		pb.DeviceLock = &commandproto.DeviceLock{
+			Pin:         c.DeviceLock.PIN,
+			Message:     c.DeviceLock.Message,
+			PhoneNumber: c.DeviceLock.PhoneNumber,
 		}
 	case "EraseDevice":
-		payload.Command.EraseDevice = &commandproto.EraseDevice{
-			Pin: e.Payload.Command.EraseDevice.PIN,
+		pb.EraseDevice = &commandproto.EraseDevice{
+			Pin: c.EraseDevice.PIN,
 		}
 	case "DeleteUser":
-		payload.Command.DeleteUser = &commandproto.DeleteUser{
-			Username:      e.Payload.Command.DeleteUser.UserName,
-			ForceDeletion: e.Payload.Command.DeleteUser.ForceDeletion,
+		pb.DeleteUser = &commandproto.DeleteUser{
+			Username:      c.DeleteUser.UserName,
+			ForceDeletion: c.DeleteUser.ForceDeletion,
 		}
 	case "ScheduleOSUpdateScan":
-		payload.Command.ScheduleOsUpdateScan = &commandproto.ScheduleOSUpdateScan{
-			Force: e.Payload.Command.ScheduleOSUpdateScan.Force,
+		pb.ScheduleOsUpdateScan = &commandproto.ScheduleOSUpdateScan{
+			Force: c.ScheduleOSUpdateScan.Force,
 		}
 	case "ScheduleOSUpdate":
-		p := e.Payload.Command.ScheduleOSUpdate
 		var updates []*commandproto.OSUpdate
-		for _, update := range p.Updates {
+		for _, update := range c.ScheduleOSUpdate.Updates {
 			updates = append(updates, &commandproto.OSUpdate{
 				ProductKey:    update.ProductKey,
 				InstallAction: update.InstallAction,
 			})
 		}
-		payload.Command.ScheduleOsUpdate = &commandproto.ScheduleOSUpdate{
+		pb.ScheduleOsUpdate = &commandproto.ScheduleOSUpdate{
 			Updates: updates,
 		}
 	case "AccountConfiguration":
-		p := e.Payload.Command.AccountConfiguration
-		payload.Command.AccountConfiguration = &commandproto.AccountConfiguration{
+		p := c.AccountConfiguration
+		pb.AccountConfiguration = &commandproto.AccountConfiguration{
 			SkipPrimarySetupAccountCreation:     p.SkipPrimarySetupAccountCreation,
 			SetPrimarySetupAccountAsRegularUser: p.SetPrimarySetupAccountAsRegularUser,
 		}
 		for _, account := range p.AutoSetupAdminAccounts {
-			payload.Command.AccountConfiguration.AutoSetupAdminAccounts = append(
-				payload.Command.AccountConfiguration.AutoSetupAdminAccounts, &commandproto.AutoSetupAdminAccounts{
+			pb.AccountConfiguration.AutoSetupAdminAccounts = append(
+				pb.AccountConfiguration.AutoSetupAdminAccounts, &commandproto.AutoSetupAdminAccounts{
 					ShortName:    account.ShortName,
 					FullName:     account.FullName,
 					PasswordHash: account.PasswordHash,
@@ -87,106 +135,210 @@ func MarshalEvent(e *Event) ([]byte, error) {
 				})
 		}
 	case "DeviceInformation":
-		payload.Command.DeviceInformation = &commandproto.DeviceInformation{
-			Queries: e.Payload.Command.DeviceInformation.Queries,
+		pb.DeviceInformation = &commandproto.DeviceInformation{
+			Queries: c.DeviceInformation.Queries,
 		}
 	case "InstallProfile":
-		payload.Command.InstallProfile = &commandproto.InstallProfile{
-			Payload: e.Payload.Command.InstallProfile.Payload,
+		pb.InstallProfile = &commandproto.InstallProfile{
+			Payload: c.InstallProfile.Payload,
 		}
 	case "RemoveProfile":
-		payload.Command.RemoveProfile = &commandproto.RemoveProfile{
-			Identifier: e.Payload.Command.RemoveProfile.Identifier,
+		pb.RemoveProfile = &commandproto.RemoveProfile{
+			Identifier: c.RemoveProfile.Identifier,
+		}
+	case "InstallProvisioningProfile":
+		pb.InstallProvisioningProfile = &commandproto.InstallProvisioningProfile{
+			ProvisioningProfile: c.InstallProvisioningProfile.ProvisioningProfile,
+		}
+	case "RemoveProvisioningProfile":
+		pb.RemoveProvisioningProfile = &commandproto.RemoveProvisioningProfile{
+			Uuid: c.RemoveProvisioningProfile.UUID,
+		}
+	case "InstalledApplicationList":
+		pb.InstalledApplicationList = &commandproto.InstalledApplicationList{
+			Identifiers:     c.InstalledApplicationList.Identifiers,
+			ManagedAppsOnly: c.InstalledApplicationList.ManagedAppsOnly,
+		}
+	case "ClearPasscode":
+		pb.ClearPasscode = &commandproto.ClearPasscode{
+			UnlockToken: c.ClearPasscode.UnlockToken,
+		}
+	case "RequestMirroring":
+		p := c.RequestMirroring
+		pb.RequestMirroring = &commandproto.RequestMirroring{
+			DestinationName:     p.DestinationName,
+			DestinationDeviceId: p.DestinationDeviceID,
+			ScanTime:            p.ScanTime,
+			Password:            p.Password,
+		}
+	case "EnableLostMode":
+		p := c.EnableLostMode
+		pb.EnableLostMode = &commandproto.EnableLostMode{
+			Message:     p.Message,
+			PhoneNumber: p.PhoneNumber,
+			Footnote:    p.Footnote,
+		}
+	case "ApplyRedemptionCode":
+		pb.ApplyRedemptionCode = &commandproto.ApplyRedemptionCode{
+			Identifier:     c.ApplyRedemptionCode.Identifier,
+			RedemptionCode: c.ApplyRedemptionCode.RedemptionCode,
+		}
+	case "InstallMedia":
+		p := c.InstallMedia
+		pb.InstallMedia = &commandproto.InstallMedia{
+			ItunesStoreId: int64(p.ITunesStoreID),
+			MediaUrl:      p.MediaURL,
+			MediaType:     p.MediaType,
+		}
+	case "RemoveMedia":
+		p := c.RemoveMedia
+		pb.RemoveMedia = &commandproto.RemoveMedia{
+			MediaType:     p.MediaType,
+			ItunesStoreId: int64(p.ITunesStoreID),
+			PersistentId:  p.PersistentID,
 		}
 	case "InstallApplication":
-		cmd := e.Payload.Command.InstallApplication
-		payload.Command.InstallApplication = &commandproto.InstallApplication{
-			ItunesStoreId:         int64(cmd.ITunesStoreID),
-			Identifier:            cmd.Identifier,
-			ManifestUrl:           cmd.ManifestURL,
-			ManagementFlags:       int64(cmd.ManagementFlags),
-			NotManaged:            cmd.NotManaged,
-			ChangeManagementState: cmd.ChangeManagementState,
+		p := c.InstallApplication
+		pb.InstallApplication = &commandproto.InstallApplication{
+			ItunesStoreId:         int64(p.ITunesStoreID),
+			Identifier:            p.Identifier,
+			ManifestUrl:           p.ManifestURL,
+			ManagementFlags:       int64(p.ManagementFlags),
+			NotManaged:            p.NotManaged,
+			ChangeManagementState: p.ChangeManagementState,
+		}
+		if p.Options != nil {
+			pb.InstallApplication.Options = &commandproto.InstallApplicationOptions{
+				NotManaged:     p.Options.NotManaged,
+				PurchaseMethod: int64(p.Options.PurchaseMethod),
+			}
+		}
+	case "ManagedApplicationList":
+		pb.ManagedApplicationList = &commandproto.ManagedApplicationList{
+			Identifiers: c.ManagedApplicationList.Identifiers,
+		}
+	case "RemoveApplication":
+		pb.RemoveApplication = &commandproto.RemoveApplication{
+			Identifier: c.RemoveApplication.Identifier,
+		}
+	case "InviteToProgram":
+		pb.InviteToProgram = &commandproto.InviteToProgram{
+			ProgramId:     c.InviteToProgram.ProgramID,
+			InvitationUrl: c.InviteToProgram.InvitationURL,
+		}
+	case "ValidateApplications":
+		pb.ValidateApplications = &commandproto.ValidateApplications{
+			Identifiers: c.ValidateApplications.Identifiers,
+		}
+	case "ApplicationConfiguration":
+		p := c.ApplicationConfiguration
+		pb.ApplicationConfiguration = &commandproto.ApplicationConfiguration{
+			Identifier: p.Identifier,
+		}
+		keys := make([]string, 0, len(p.Configuration))
+		for k := range p.Configuration {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			pb.ApplicationConfiguration.Configuration = append(pb.ApplicationConfiguration.Configuration,
+				&commandproto.ApplicationConfigurationValue{Key: k, Value: p.Configuration[k]})
+		}
+	case "ManagedApplicationConfiguration":
+		pb.ManagedApplicationConfiguration = &commandproto.ManagedApplicationConfiguration{
+			Identifiers: c.ManagedApplicationConfiguration.Identifiers,
+		}
+	case "ManagedApplicationAttributes":
+		pb.ManagedApplicationAttributes = &commandproto.ManagedApplicationAttributes{
+			Identifiers: c.ManagedApplicationAttributes.Identifiers,
+		}
+	case "ManagedApplicationFeedback":
+		pb.ManagedApplicationFeedback = &commandproto.ManagedApplicationFeedback{
+			Identifiers:    c.ManagedApplicationFeedback.Identifiers,
+			DeleteFeedback: c.ManagedApplicationFeedback.DeleteFeedback,
 		}
 	case "Settings":
-		cmd := e.Payload.Command.Settings
 		var settings []*commandproto.Setting
-		for _, s := range cmd.Settings {
-			protoSetting := &commandproto.Setting{
-				Item: s.Item,
-			}
-			if s.DeviceName != nil {
-				protoSetting.DeviceName = &commandproto.DeviceNameSetting{
-					DeviceName: *s.DeviceName,
-				}
-			}
-
-			if s.HostName != nil {
-				protoSetting.Hostname = &commandproto.HostnameSetting{
-					Hostname: *s.HostName,
-				}
-			}
-			settings = append(settings, protoSetting)
+		for _, s := range c.Settings.Settings {
+			settings = append(settings, settingToProto(s))
 		}
-		payload.Command.Settings = &commandproto.Settings{Settings: settings}
+		pb.Settings = &commandproto.Settings{Settings: settings}
 	}
-	return proto.Marshal(&commandproto.Event{
-		Id:         e.ID,
-		Time:       e.Time.UnixNano(),
-		Payload:    payload,
-		DeviceUdid: e.DeviceUDID,
-	})
-
+	return pb
 }
 
-// UnmarshalEvent parses a protocol buffer representation of data into
-// the Event.
-func UnmarshalEvent(data []byte, e *Event) error {
-	var pb commandproto.Event
-	if err := proto.Unmarshal(data, &pb); err != nil {
-		return errors.Wrap(err, "unmarshal pb Event")
+func settingToProto(s mdm.Setting) *commandproto.Setting {
+	pb := &commandproto.Setting{
+		Item: s.Item,
 	}
-	e.ID = pb.Id
-	e.DeviceUDID = pb.DeviceUdid
-	e.Time = time.Unix(0, pb.Time).UTC()
-	if pb.Payload == nil {
-		return nil
+	if s.DeviceName != nil {
+		pb.DeviceName = &commandproto.DeviceNameSetting{
+			DeviceName: *s.DeviceName,
+		}
 	}
-	e.Payload = mdm.Payload{
-		CommandUUID: pb.Payload.CommandUuid,
+	if s.HostName != nil {
+		pb.Hostname = &commandproto.HostnameSetting{
+			Hostname: *s.HostName,
+		}
+	}
+	if s.Enabled != nil {
+		pb.Enabled = &commandproto.EnabledSetting{
+			Enabled: *s.Enabled,
+		}
+	}
+	if s.Identifier != nil {
+		pb.Identifier = &commandproto.IdentifierSetting{
+			Identifier: *s.Identifier,
+		}
+	}
+	keys := make([]string, 0, len(s.Attributes))
+	for k := range s.Attributes {
+		keys = append(keys, k)
 	}
-	if pb.Payload.Command == nil {
+	sort.Strings(keys)
+	for _, k := range keys {
+		pb.Attributes = append(pb.Attributes, &commandproto.SettingAttribute{
+			Key:   k,
+			Value: s.Attributes[k],
+		})
+	}
+	return pb
+}
+
+// commandFromProto is the inverse of commandToProto.
+func commandFromProto(pb *commandproto.Command) *mdm.Command {
+	if pb == nil {
 		return nil
 	}
-	e.Payload.Command = &mdm.Command{
-		RequestType: pb.Payload.Command.RequestType,
+	c := &mdm.Command{
+		RequestType: pb.RequestType,
 	}
-	switch pb.Payload.Command.RequestType {
+	switch pb.RequestType {
 	case "DeviceLock":
-		cmd := pb.Payload.Command.GetDeviceLock()
-		e.Payload.Command.DeviceLock = mdm.DeviceLock{
+		cmd := pb.GetDeviceLock()
+		c.DeviceLock = mdm.DeviceLock{
 			PIN:         cmd.GetPin(),
 			Message:     cmd.GetMessage(),
 			PhoneNumber: cmd.GetPhoneNumber(),
 		}
 	case "EraseDevice":
-		cmd := pb.Payload.Command.GetEraseDevice()
-		e.Payload.Command.EraseDevice = mdm.EraseDevice{
+		cmd := pb.GetEraseDevice()
+		c.EraseDevice = mdm.EraseDevice{
 			PIN: cmd.GetPin(),
 		}
 	case "DeleteUser":
-		cmd := pb.Payload.Command.GetDeleteUser()
-		e.Payload.Command.DeleteUser = mdm.DeleteUser{
+		cmd := pb.GetDeleteUser()
+		c.DeleteUser = mdm.DeleteUser{
 			UserName:      cmd.GetUsername(),
 			ForceDeletion: cmd.GetForceDeletion(),
 		}
 	case "ScheduleOSUpdateScan":
-		cmd := pb.Payload.Command.GetScheduleOsUpdateScan()
-		e.Payload.Command.ScheduleOSUpdateScan = mdm.ScheduleOSUpdateScan{
+		cmd := pb.GetScheduleOsUpdateScan()
+		c.ScheduleOSUpdateScan = mdm.ScheduleOSUpdateScan{
 			Force: cmd.GetForce(),
 		}
 	case "ScheduleOSUpdate":
-		cmd := pb.Payload.Command.GetScheduleOsUpdate()
+		cmd := pb.GetScheduleOsUpdate()
 		var updates []mdm.OSUpdate
 		for _, update := range cmd.GetUpdates() {
 			updates = append(updates, mdm.OSUpdate{
@@ -194,17 +346,17 @@ func UnmarshalEvent(data []byte, e *Event) error {
 				InstallAction: update.GetInstallAction(),
 			})
 		}
-		e.Payload.Command.ScheduleOSUpdate = mdm.ScheduleOSUpdate{
+		c.ScheduleOSUpdate = mdm.ScheduleOSUpdate{
 			Updates: updates,
 		}
 	case "AccountConfiguration":
-		cmd := pb.Payload.Command.GetAccountConfiguration()
-		e.Payload.Command.AccountConfiguration = mdm.AccountConfiguration{
+		cmd := pb.GetAccountConfiguration()
+		c.AccountConfiguration = mdm.AccountConfiguration{
 			SkipPrimarySetupAccountCreation:     cmd.GetSkipPrimarySetupAccountCreation(),
 			SetPrimarySetupAccountAsRegularUser: cmd.GetSetPrimarySetupAccountAsRegularUser(),
 		}
 		for _, account := range cmd.GetAutoSetupAdminAccounts() {
-			e.Payload.Command.AccountConfiguration.AutoSetupAdminAccounts = append(e.Payload.Command.AutoSetupAdminAccounts, mdm.AdminAccount{
+			c.AccountConfiguration.AutoSetupAdminAccounts = append(c.AutoSetupAdminAccounts, mdm.AdminAccount{
 				ShortName:    account.GetShortName(),
 				FullName:     account.GetFullName(),
 				PasswordHash: account.GetPasswordHash(),
@@ -212,47 +364,163 @@ func UnmarshalEvent(data []byte, e *Event) error {
 			})
 		}
 	case "DeviceInformation":
-		e.Payload.Command.DeviceInformation = mdm.DeviceInformation{
-			Queries: pb.Payload.Command.DeviceInformation.Queries,
+		c.DeviceInformation = mdm.DeviceInformation{
+			Queries: pb.GetDeviceInformation().GetQueries(),
 		}
 	case "InstallProfile":
-		e.Payload.Command.InstallProfile = mdm.InstallProfile{
-			Payload: pb.Payload.Command.InstallProfile.Payload,
+		c.InstallProfile = mdm.InstallProfile{
+			Payload: pb.GetInstallProfile().GetPayload(),
 		}
 	case "RemoveProfile":
-		e.Payload.Command.RemoveProfile = mdm.RemoveProfile{
-			Identifier: pb.Payload.Command.RemoveProfile.Identifier,
+		c.RemoveProfile = mdm.RemoveProfile{
+			Identifier: pb.GetRemoveProfile().GetIdentifier(),
+		}
+	case "InstallProvisioningProfile":
+		c.InstallProvisioningProfile = mdm.InstallProvisioningProfile{
+			ProvisioningProfile: pb.GetInstallProvisioningProfile().GetProvisioningProfile(),
+		}
+	case "RemoveProvisioningProfile":
+		c.RemoveProvisioningProfile = mdm.RemoveProvisioningProfile{
+			UUID: pb.GetRemoveProvisioningProfile().GetUuid(),
+		}
+	case "InstalledApplicationList":
+		cmd := pb.GetInstalledApplicationList()
+		c.InstalledApplicationList = mdm.InstalledApplicationList{
+			Identifiers:     cmd.GetIdentifiers(),
+			ManagedAppsOnly: cmd.GetManagedAppsOnly(),
+		}
+	case "ClearPasscode":
+		c.ClearPasscode = mdm.ClearPasscode{
+			UnlockToken: pb.GetClearPasscode().GetUnlockToken(),
+		}
+	case "RequestMirroring":
+		cmd := pb.GetRequestMirroring()
+		c.RequestMirroring = mdm.RequestMirroring{
+			DestinationName:     cmd.GetDestinationName(),
+			DestinationDeviceID: cmd.GetDestinationDeviceId(),
+			ScanTime:            cmd.GetScanTime(),
+			Password:            cmd.GetPassword(),
+		}
+	case "EnableLostMode":
+		cmd := pb.GetEnableLostMode()
+		c.EnableLostMode = mdm.EnableLostMode{
+			Message:     cmd.GetMessage(),
+			PhoneNumber: cmd.GetPhoneNumber(),
+			Footnote:    cmd.GetFootnote(),
+		}
+	case "ApplyRedemptionCode":
+		cmd := pb.GetApplyRedemptionCode()
+		c.ApplyRedemptionCode = mdm.ApplyRedemptionCode{
+			Identifier:     cmd.GetIdentifier(),
+			RedemptionCode: cmd.GetRedemptionCode(),
+		}
+	case "InstallMedia":
+		cmd := pb.GetInstallMedia()
+		c.InstallMedia = mdm.InstallMedia{
+			ITunesStoreID: int(cmd.GetItunesStoreId()),
+			MediaURL:      cmd.GetMediaUrl(),
+			MediaType:     cmd.GetMediaType(),
+		}
+	case "RemoveMedia":
+		cmd := pb.GetRemoveMedia()
+		c.RemoveMedia = mdm.RemoveMedia{
+			MediaType:     cmd.GetMediaType(),
+			ITunesStoreID: int(cmd.GetItunesStoreId()),
+			PersistentID:  cmd.GetPersistentId(),
 		}
 	case "InstallApplication":
-		cmd := pb.Payload.Command.GetInstallApplication()
-		e.Payload.Command.InstallApplication = mdm.InstallApplication{
+		cmd := pb.GetInstallApplication()
+		c.InstallApplication = mdm.InstallApplication{
 			ITunesStoreID:         int(cmd.GetItunesStoreId()),
 			Identifier:            cmd.GetIdentifier(),
 			ManifestURL:           cmd.GetManifestUrl(),
 			ManagementFlags:       int(cmd.GetManagementFlags()),
+			NotManaged:            cmd.GetNotManaged(),
 			ChangeManagementState: cmd.GetChangeManagementState(),
 		}
-	case "Settings":
-		cmd := pb.Payload.Command.GetSettings()
-		var settings []mdm.Setting
-		for _, s := range cmd.GetSettings() {
-			mdmSetting := mdm.Setting{
-				Item: s.GetItem(),
-			}
-
-			if s.GetDeviceName() != nil {
-				mdmSetting.DeviceName = stringPtr(s.GetDeviceName().GetDeviceName())
+		if opts := cmd.GetOptions(); opts != nil {
+			c.InstallApplication.Options = &mdm.InstallApplicationOptions{
+				NotManaged:     opts.GetNotManaged(),
+				PurchaseMethod: int(opts.GetPurchaseMethod()),
 			}
-
-			if s.GetHostname() != nil {
-				mdmSetting.HostName = stringPtr(s.GetHostname().GetHostname())
+		}
+	case "ManagedApplicationList":
+		c.ManagedApplicationList = mdm.ManagedApplicationList{
+			Identifiers: pb.GetManagedApplicationList().GetIdentifiers(),
+		}
+	case "RemoveApplication":
+		c.RemoveApplication = mdm.RemoveApplication{
+			Identifier: pb.GetRemoveApplication().GetIdentifier(),
+		}
+	case "InviteToProgram":
+		cmd := pb.GetInviteToProgram()
+		c.InviteToProgram = mdm.InviteToProgram{
+			ProgramID:     cmd.GetProgramId(),
+			InvitationURL: cmd.GetInvitationUrl(),
+		}
+	case "ValidateApplications":
+		c.ValidateApplications = mdm.ValidateApplications{
+			Identifiers: pb.GetValidateApplications().GetIdentifiers(),
+		}
+	case "ApplicationConfiguration":
+		cmd := pb.GetApplicationConfiguration()
+		c.ApplicationConfiguration = mdm.ApplicationConfiguration{
+			Identifier: cmd.GetIdentifier(),
+		}
+		if len(cmd.GetConfiguration()) > 0 {
+			c.ApplicationConfiguration.Configuration = make(map[string]string, len(cmd.GetConfiguration()))
+			for _, v := range cmd.GetConfiguration() {
+				c.ApplicationConfiguration.Configuration[v.GetKey()] = v.GetValue()
 			}
+		}
+	case "ManagedApplicationConfiguration":
+		c.ManagedApplicationConfiguration = mdm.ManagedApplicationConfiguration{
+			Identifiers: pb.GetManagedApplicationConfiguration().GetIdentifiers(),
+		}
+	case "ManagedApplicationAttributes":
+		c.ManagedApplicationAttributes = mdm.ManagedApplicationAttributes{
+			Identifiers: pb.GetManagedApplicationAttributes().GetIdentifiers(),
+		}
+	case "ManagedApplicationFeedback":
+		cmd := pb.GetManagedApplicationFeedback()
+		c.ManagedApplicationFeedback = mdm.ManagedApplicationFeedback{
+			Identifiers:    cmd.GetIdentifiers(),
+			DeleteFeedback: cmd.GetDeleteFeedback(),
+		}
+	case "Settings":
+		var settings []mdm.Setting
+		for _, s := range pb.GetSettings().GetSettings() {
+			settings = append(settings, settingFromProto(s))
+		}
+		c.Settings = mdm.Settings{Settings: settings}
+	}
+	return c
+}
 
-			settings = append(settings, mdmSetting)
+func settingFromProto(pb *commandproto.Setting) mdm.Setting {
+	s := mdm.Setting{
+		Item: pb.GetItem(),
+	}
+	if pb.GetDeviceName() != nil {
+		s.DeviceName = stringPtr(pb.GetDeviceName().GetDeviceName())
+	}
+	if pb.GetHostname() != nil {
+		s.HostName = stringPtr(pb.GetHostname().GetHostname())
+	}
+	if pb.GetEnabled() != nil {
+		enabled := pb.GetEnabled().GetEnabled()
+		s.Enabled = &enabled
+	}
+	if pb.GetIdentifier() != nil {
+		s.Identifier = stringPtr(pb.GetIdentifier().GetIdentifier())
+	}
+	if len(pb.GetAttributes()) > 0 {
+		s.Attributes = make(map[string]string, len(pb.GetAttributes()))
+		for _, attr := range pb.GetAttributes() {
+			s.Attributes[attr.GetKey()] = attr.GetValue()
 		}
-		e.Payload.Command.Settings = mdm.Settings{Settings: settings}
 	}
-	return nil
+	return s
 }
 
 func stringPtr(s string) *string {
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/groob/plist"
 
@@ -38,6 +39,216 @@ func TestMarshalEvent(t *testing.T) {
 	}
 }
 
+func TestMarshalEvent_RequestTypes(t *testing.T) {
+	enabled := true
+	tests := []mdm.Command{
+		{
+			RequestType:       "DeviceInformation",
+			DeviceInformation: mdm.DeviceInformation{Queries: []string{"UDID", "SerialNumber"}},
+		},
+		{
+			RequestType: "InstallApplication",
+			InstallApplication: mdm.InstallApplication{
+				ITunesStoreID:         361285480,
+				Identifier:            "com.apple.Keynote",
+				ManifestURL:           "https://example.com/manifest.plist",
+				ManagementFlags:       1,
+				NotManaged:            true,
+				ChangeManagementState: "Managed",
+				Options:               &mdm.InstallApplicationOptions{NotManaged: true, PurchaseMethod: 1},
+			},
+		},
+		{
+			RequestType: "AccountConfiguration",
+			AccountConfiguration: mdm.AccountConfiguration{
+				SkipPrimarySetupAccountCreation:     true,
+				SetPrimarySetupAccountAsRegularUser: true,
+				AutoSetupAdminAccounts: []mdm.AdminAccount{
+					{ShortName: "admin", FullName: "Admin", PasswordHash: []byte("hash"), Hidden: true},
+				},
+			},
+		},
+		{
+			RequestType:          "ScheduleOSUpdateScan",
+			ScheduleOSUpdateScan: mdm.ScheduleOSUpdateScan{Force: true},
+		},
+		{
+			RequestType: "ScheduleOSUpdate",
+			ScheduleOSUpdate: mdm.ScheduleOSUpdate{Updates: []mdm.OSUpdate{
+				{ProductKey: "041-88800", InstallAction: "InstallASAP"},
+			}},
+		},
+		{
+			RequestType:    "InstallProfile",
+			InstallProfile: mdm.InstallProfile{Payload: []byte("profile")},
+		},
+		{
+			RequestType:   "RemoveProfile",
+			RemoveProfile: mdm.RemoveProfile{Identifier: "com.example.profile"},
+		},
+		{
+			RequestType:                "InstallProvisioningProfile",
+			InstallProvisioningProfile: mdm.InstallProvisioningProfile{ProvisioningProfile: []byte("mobileprovision")},
+		},
+		{
+			RequestType:               "RemoveProvisioningProfile",
+			RemoveProvisioningProfile: mdm.RemoveProvisioningProfile{UUID: "5F2E8B3A-0C9A-4E53-9E4D-6A1B5A7D2C11"},
+		},
+		{
+			RequestType: "InstalledApplicationList",
+			InstalledApplicationList: mdm.InstalledApplicationList{
+				Identifiers:     []string{"com.apple.Keynote"},
+				ManagedAppsOnly: true,
+			},
+		},
+		{
+			RequestType: "DeviceLock",
+			DeviceLock:  mdm.DeviceLock{PIN: "123456", Message: "Lost", PhoneNumber: "555-0100"},
+		},
+		{
+			RequestType:   "ClearPasscode",
+			ClearPasscode: mdm.ClearPasscode{UnlockToken: []byte("token")},
+		},
+		{
+			RequestType: "EraseDevice",
+			EraseDevice: mdm.EraseDevice{PIN: "123456"},
+		},
+		{
+			RequestType: "RequestMirroring",
+			RequestMirroring: mdm.RequestMirroring{
+				DestinationName:     "Conference Room",
+				DestinationDeviceID: "00:11:22:33:44:55",
+				ScanTime:            "30",
+				Password:            "secret",
+			},
+		},
+		{
+			RequestType: "DeleteUser",
+			DeleteUser:  mdm.DeleteUser{UserName: "user", ForceDeletion: true},
+		},
+		{
+			RequestType:    "EnableLostMode",
+			EnableLostMode: mdm.EnableLostMode{Message: "Lost", PhoneNumber: "555-0100", Footnote: "Reward"},
+		},
+		{
+			RequestType:         "ApplyRedemptionCode",
+			ApplyRedemptionCode: mdm.ApplyRedemptionCode{Identifier: "com.apple.Keynote", RedemptionCode: "CODE"},
+		},
+		{
+			RequestType:  "InstallMedia",
+			InstallMedia: mdm.InstallMedia{ITunesStoreID: 1, MediaURL: "https://example.com/book.pdf", MediaType: "Book"},
+		},
+		{
+			RequestType: "RemoveMedia",
+			RemoveMedia: mdm.RemoveMedia{MediaType: "Book", ITunesStoreID: 1, PersistentID: "book"},
+		},
+		{
+			RequestType: "Settings",
+			Settings: mdm.Settings{Settings: []mdm.Setting{
+				{Item: "DeviceName", DeviceName: stringPtr("iPad")},
+				{Item: "HostName", HostName: stringPtr("ipad.example.com")},
+				{Item: "VoiceRoaming", Enabled: &enabled},
+				{
+					Item:       "ApplicationAttributes",
+					Identifier: stringPtr("com.example.app"),
+					Attributes: map[string]string{"VPNUUID": "vpn", "Key": "Value"},
+				},
+			}},
+		},
+		{
+			RequestType:            "ManagedApplicationList",
+			ManagedApplicationList: mdm.ManagedApplicationList{Identifiers: []string{"com.apple.Keynote"}},
+		},
+		{
+			RequestType:       "RemoveApplication",
+			RemoveApplication: mdm.RemoveApplication{Identifier: "com.apple.Keynote"},
+		},
+		{
+			RequestType:     "InviteToProgram",
+			InviteToProgram: mdm.InviteToProgram{ProgramID: "com.apple.cloudvpp", InvitationURL: "https://example.com/invite"},
+		},
+		{
+			RequestType:          "ValidateApplications",
+			ValidateApplications: mdm.ValidateApplications{Identifiers: []string{"com.example.app"}},
+		},
+		{
+			RequestType: "ApplicationConfiguration",
+			ApplicationConfiguration: mdm.ApplicationConfiguration{
+				Identifier:    "com.example.app",
+				Configuration: map[string]string{"ServerURL": "https://example.com", "Mode": "kiosk"},
+			},
+		},
+		{
+			RequestType:                     "ManagedApplicationConfiguration",
+			ManagedApplicationConfiguration: mdm.ManagedApplicationConfiguration{Identifiers: []string{"com.example.app"}},
+		},
+		{
+			RequestType:                  "ManagedApplicationAttributes",
+			ManagedApplicationAttributes: mdm.ManagedApplicationAttributes{Identifiers: []string{"com.example.app"}},
+		},
+		{
+			RequestType: "ManagedApplicationFeedback",
+			ManagedApplicationFeedback: mdm.ManagedApplicationFeedback{
+				Identifiers:    []string{"com.example.app"},
+				DeleteFeedback: true,
+			},
+		},
+	}
+
+	// every request type embedded in mdm.Command must be covered.
+	tested := make(map[string]bool)
+	for _, tt := range tests {
+		tested[tt.RequestType] = true
+	}
+	typ := reflect.TypeOf(mdm.Command{})
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); f.Anonymous && !tested[f.Name] {
+			t.Errorf("no marshal test for RequestType %s", f.Name)
+		}
+	}
+
+	for _, tt := range tests {
+		cmd := tt
+		t.Run(cmd.RequestType, func(t *testing.T) {
+			v := command.NewEvent(mdm.Payload{CommandUUID: "uuid", Command: &cmd}, "udid")
+			buf, err := command.MarshalEvent(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var other command.Event
+			if err := command.UnmarshalEvent(buf, &other); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, &other) {
+				t.Fatalf("\nwant: %#v\n \nhave: %#v\n", v.Payload.Command, other.Payload.Command)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestMarshalEvent_QueueOptions(t *testing.T) {
+	v := command.NewEvent(mustLoadPayload(t, "DeviceInformation"), "DeviceInformation")
+	v.TTL = time.Hour
+	v.Priority = 10
+	v.After = []string{"aaaa", "bbbb"}
+
+	buf, err := command.MarshalEvent(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var other command.Event
+	if err := command.UnmarshalEvent(buf, &other); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v, &other) {
+		t.Fatalf("\nwant: %#v\n \nhave: %#v\n", v, other)
+	}
+}
+
 func BenchmarkMarshalProto(b *testing.B) {
 	for _, tt := range marshalTests {
 		v := command.NewEvent(mustLoadPayload(&testing.T{}, tt), tt)
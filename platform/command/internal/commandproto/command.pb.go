@@ -21,12 +21,36 @@ It has these top-level messages:
 	RemoveProfile
 	DeleteUser
 	InstallApplication
+	InstallApplicationOptions
+	InstallProvisioningProfile
+	RemoveProvisioningProfile
+	InstalledApplicationList
+	ClearPasscode
+	RequestMirroring
+	EnableLostMode
+	ApplyRedemptionCode
+	InstallMedia
+	RemoveMedia
 	EraseDevice
 	DeviceLock
+	ManagedApplicationList
+	RemoveApplication
+	InviteToProgram
+	ValidateApplications
+	ApplicationConfiguration
+	ApplicationConfigurationValue
+	ManagedApplicationConfiguration
+	ManagedApplicationAttributes
+	ManagedApplicationFeedback
 	Settings
 	Setting
+	EnabledSetting
+	IdentifierSetting
+	SettingAttribute
 	DeviceNameSetting
 	HostnameSetting
+	Batch
+	BatchCommand
 */
 package commandproto
 
@@ -50,6 +74,9 @@ type Event struct {
 	Time       int64    `protobuf:"varint,2,opt,name=time" json:"time,omitempty"`
 	Payload    *Payload `protobuf:"bytes,3,opt,name=payload" json:"payload,omitempty"`
 	DeviceUdid string   `protobuf:"bytes,4,opt,name=device_udid,json=deviceUdid" json:"device_udid,omitempty"`
+	Ttl        int64    `protobuf:"varint,5,opt,name=ttl" json:"ttl,omitempty"`
+	Priority   int64    `protobuf:"varint,6,opt,name=priority" json:"priority,omitempty"`
+	After      []string `protobuf:"bytes,7,rep,name=after" json:"after,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -85,6 +112,27 @@ func (m *Event) GetDeviceUdid() string {
 	return ""
 }
 
+func (m *Event) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *Event) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Event) GetAfter() []string {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
 type Payload struct {
 	CommandUuid string   `protobuf:"bytes,1,opt,name=command_uuid,json=commandUuid" json:"command_uuid,omitempty"`
 	Command     *Command `protobuf:"bytes,2,opt,name=command" json:"command,omitempty"`
@@ -110,18 +158,35 @@ func (m *Payload) GetCommand() *Command {
 }
 
 type Command struct {
-	RequestType          string                `protobuf:"bytes,1,opt,name=request_type,json=requestType" json:"request_type,omitempty"`
-	DeviceInformation    *DeviceInformation    `protobuf:"bytes,2,opt,name=device_information,json=deviceInformation" json:"device_information,omitempty"`
-	InstallProfile       *InstallProfile       `protobuf:"bytes,3,opt,name=install_profile,json=installProfile" json:"install_profile,omitempty"`
-	InstallApplication   *InstallApplication   `protobuf:"bytes,4,opt,name=install_application,json=installApplication" json:"install_application,omitempty"`
-	AccountConfiguration *AccountConfiguration `protobuf:"bytes,5,opt,name=account_configuration,json=accountConfiguration" json:"account_configuration,omitempty"`
-	ScheduleOsUpdate     *ScheduleOSUpdate     `protobuf:"bytes,6,opt,name=schedule_os_update,json=scheduleOsUpdate" json:"schedule_os_update,omitempty"`
-	ScheduleOsUpdateScan *ScheduleOSUpdateScan `protobuf:"bytes,7,opt,name=schedule_os_update_scan,json=scheduleOsUpdateScan" json:"schedule_os_update_scan,omitempty"`
-	RemoveProfile        *RemoveProfile        `protobuf:"bytes,8,opt,name=remove_profile,json=removeProfile" json:"remove_profile,omitempty"`
-	DeleteUser           *DeleteUser           `protobuf:"bytes,9,opt,name=delete_user,json=deleteUser" json:"delete_user,omitempty"`
-	Settings             *Settings             `protobuf:"bytes,10,opt,name=settings" json:"settings,omitempty"`
-	EraseDevice          *EraseDevice          `protobuf:"bytes,11,opt,name=erase_device,json=eraseDevice" json:"erase_device,omitempty"`
-	DeviceLock           *DeviceLock           `protobuf:"bytes,12,opt,name=device_lock,json=deviceLock" json:"device_lock,omitempty"`
+	RequestType                     string                           `protobuf:"bytes,1,opt,name=request_type,json=requestType" json:"request_type,omitempty"`
+	DeviceInformation               *DeviceInformation               `protobuf:"bytes,2,opt,name=device_information,json=deviceInformation" json:"device_information,omitempty"`
+	InstallProfile                  *InstallProfile                  `protobuf:"bytes,3,opt,name=install_profile,json=installProfile" json:"install_profile,omitempty"`
+	InstallApplication              *InstallApplication              `protobuf:"bytes,4,opt,name=install_application,json=installApplication" json:"install_application,omitempty"`
+	AccountConfiguration            *AccountConfiguration            `protobuf:"bytes,5,opt,name=account_configuration,json=accountConfiguration" json:"account_configuration,omitempty"`
+	ScheduleOsUpdate                *ScheduleOSUpdate                `protobuf:"bytes,6,opt,name=schedule_os_update,json=scheduleOsUpdate" json:"schedule_os_update,omitempty"`
+	ScheduleOsUpdateScan            *ScheduleOSUpdateScan            `protobuf:"bytes,7,opt,name=schedule_os_update_scan,json=scheduleOsUpdateScan" json:"schedule_os_update_scan,omitempty"`
+	RemoveProfile                   *RemoveProfile                   `protobuf:"bytes,8,opt,name=remove_profile,json=removeProfile" json:"remove_profile,omitempty"`
+	DeleteUser                      *DeleteUser                      `protobuf:"bytes,9,opt,name=delete_user,json=deleteUser" json:"delete_user,omitempty"`
+	Settings                        *Settings                        `protobuf:"bytes,10,opt,name=settings" json:"settings,omitempty"`
+	EraseDevice                     *EraseDevice                     `protobuf:"bytes,11,opt,name=erase_device,json=eraseDevice" json:"erase_device,omitempty"`
+	DeviceLock                      *DeviceLock                      `protobuf:"bytes,12,opt,name=device_lock,json=deviceLock" json:"device_lock,omitempty"`
+	InstallProvisioningProfile      *InstallProvisioningProfile      `protobuf:"bytes,13,opt,name=install_provisioning_profile,json=installProvisioningProfile" json:"install_provisioning_profile,omitempty"`
+	RemoveProvisioningProfile       *RemoveProvisioningProfile       `protobuf:"bytes,14,opt,name=remove_provisioning_profile,json=removeProvisioningProfile" json:"remove_provisioning_profile,omitempty"`
+	InstalledApplicationList        *InstalledApplicationList        `protobuf:"bytes,15,opt,name=installed_application_list,json=installedApplicationList" json:"installed_application_list,omitempty"`
+	ClearPasscode                   *ClearPasscode                   `protobuf:"bytes,16,opt,name=clear_passcode,json=clearPasscode" json:"clear_passcode,omitempty"`
+	RequestMirroring                *RequestMirroring                `protobuf:"bytes,17,opt,name=request_mirroring,json=requestMirroring" json:"request_mirroring,omitempty"`
+	EnableLostMode                  *EnableLostMode                  `protobuf:"bytes,18,opt,name=enable_lost_mode,json=enableLostMode" json:"enable_lost_mode,omitempty"`
+	ApplyRedemptionCode             *ApplyRedemptionCode             `protobuf:"bytes,19,opt,name=apply_redemption_code,json=applyRedemptionCode" json:"apply_redemption_code,omitempty"`
+	InstallMedia                    *InstallMedia                    `protobuf:"bytes,20,opt,name=install_media,json=installMedia" json:"install_media,omitempty"`
+	RemoveMedia                     *RemoveMedia                     `protobuf:"bytes,21,opt,name=remove_media,json=removeMedia" json:"remove_media,omitempty"`
+	ManagedApplicationList          *ManagedApplicationList          `protobuf:"bytes,22,opt,name=managed_application_list,json=managedApplicationList" json:"managed_application_list,omitempty"`
+	RemoveApplication               *RemoveApplication               `protobuf:"bytes,23,opt,name=remove_application,json=removeApplication" json:"remove_application,omitempty"`
+	InviteToProgram                 *InviteToProgram                 `protobuf:"bytes,24,opt,name=invite_to_program,json=inviteToProgram" json:"invite_to_program,omitempty"`
+	ValidateApplications            *ValidateApplications            `protobuf:"bytes,25,opt,name=validate_applications,json=validateApplications" json:"validate_applications,omitempty"`
+	ApplicationConfiguration        *ApplicationConfiguration        `protobuf:"bytes,26,opt,name=application_configuration,json=applicationConfiguration" json:"application_configuration,omitempty"`
+	ManagedApplicationConfiguration *ManagedApplicationConfiguration `protobuf:"bytes,27,opt,name=managed_application_configuration,json=managedApplicationConfiguration" json:"managed_application_configuration,omitempty"`
+	ManagedApplicationAttributes    *ManagedApplicationAttributes    `protobuf:"bytes,28,opt,name=managed_application_attributes,json=managedApplicationAttributes" json:"managed_application_attributes,omitempty"`
+	ManagedApplicationFeedback      *ManagedApplicationFeedback      `protobuf:"bytes,29,opt,name=managed_application_feedback,json=managedApplicationFeedback" json:"managed_application_feedback,omitempty"`
 }
 
 func (m *Command) Reset()                    { *m = Command{} }
@@ -213,6 +278,125 @@ func (m *Command) GetDeviceLock() *DeviceLock {
 	return nil
 }
 
+func (m *Command) GetInstallProvisioningProfile() *InstallProvisioningProfile {
+	if m != nil {
+		return m.InstallProvisioningProfile
+	}
+	return nil
+}
+
+func (m *Command) GetRemoveProvisioningProfile() *RemoveProvisioningProfile {
+	if m != nil {
+		return m.RemoveProvisioningProfile
+	}
+	return nil
+}
+
+func (m *Command) GetInstalledApplicationList() *InstalledApplicationList {
+	if m != nil {
+		return m.InstalledApplicationList
+	}
+	return nil
+}
+
+func (m *Command) GetClearPasscode() *ClearPasscode {
+	if m != nil {
+		return m.ClearPasscode
+	}
+	return nil
+}
+
+func (m *Command) GetRequestMirroring() *RequestMirroring {
+	if m != nil {
+		return m.RequestMirroring
+	}
+	return nil
+}
+
+func (m *Command) GetEnableLostMode() *EnableLostMode {
+	if m != nil {
+		return m.EnableLostMode
+	}
+	return nil
+}
+
+func (m *Command) GetApplyRedemptionCode() *ApplyRedemptionCode {
+	if m != nil {
+		return m.ApplyRedemptionCode
+	}
+	return nil
+}
+
+func (m *Command) GetInstallMedia() *InstallMedia {
+	if m != nil {
+		return m.InstallMedia
+	}
+	return nil
+}
+
+func (m *Command) GetRemoveMedia() *RemoveMedia {
+	if m != nil {
+		return m.RemoveMedia
+	}
+	return nil
+}
+
+func (m *Command) GetManagedApplicationList() *ManagedApplicationList {
+	if m != nil {
+		return m.ManagedApplicationList
+	}
+	return nil
+}
+
+func (m *Command) GetRemoveApplication() *RemoveApplication {
+	if m != nil {
+		return m.RemoveApplication
+	}
+	return nil
+}
+
+func (m *Command) GetInviteToProgram() *InviteToProgram {
+	if m != nil {
+		return m.InviteToProgram
+	}
+	return nil
+}
+
+func (m *Command) GetValidateApplications() *ValidateApplications {
+	if m != nil {
+		return m.ValidateApplications
+	}
+	return nil
+}
+
+func (m *Command) GetApplicationConfiguration() *ApplicationConfiguration {
+	if m != nil {
+		return m.ApplicationConfiguration
+	}
+	return nil
+}
+
+func (m *Command) GetManagedApplicationConfiguration() *ManagedApplicationConfiguration {
+	if m != nil {
+		return m.ManagedApplicationConfiguration
+	}
+	return nil
+}
+
+func (m *Command) GetManagedApplicationAttributes() *ManagedApplicationAttributes {
+	if m != nil {
+		return m.ManagedApplicationAttributes
+	}
+	return nil
+}
+
+func (m *Command) GetManagedApplicationFeedback() *ManagedApplicationFeedback {
+	if m != nil {
+		return m.ManagedApplicationFeedback
+	}
+	return nil
+}
+
 type ScheduleOSUpdate struct {
 	Updates []*OSUpdate `protobuf:"bytes,1,rep,name=updates" json:"updates,omitempty"`
 }
@@ -414,12 +598,13 @@ func (m *DeleteUser) GetForceDeletion() bool {
 }
 
 type InstallApplication struct {
-	ItunesStoreId         int64  `protobuf:"varint,1,opt,name=itunes_store_id,json=itunesStoreId" json:"itunes_store_id,omitempty"`
-	Identifier            string `protobuf:"bytes,2,opt,name=identifier" json:"identifier,omitempty"`
-	ManifestUrl           string `protobuf:"bytes,3,opt,name=manifest_url,json=manifestUrl" json:"manifest_url,omitempty"`
-	ManagementFlags       int64  `protobuf:"varint,4,opt,name=management_flags,json=managementFlags" json:"management_flags,omitempty"`
-	NotManaged            bool   `protobuf:"varint,5,opt,name=not_managed,json=notManaged" json:"not_managed,omitempty"`
-	ChangeManagementState string `protobuf:"bytes,6,opt,name=change_management_state,json=changeManagementState" json:"change_management_state,omitempty"`
+	ItunesStoreId         int64                      `protobuf:"varint,1,opt,name=itunes_store_id,json=itunesStoreId" json:"itunes_store_id,omitempty"`
+	Identifier            string                     `protobuf:"bytes,2,opt,name=identifier" json:"identifier,omitempty"`
+	ManifestUrl           string                     `protobuf:"bytes,3,opt,name=manifest_url,json=manifestUrl" json:"manifest_url,omitempty"`
+	ManagementFlags       int64                      `protobuf:"varint,4,opt,name=management_flags,json=managementFlags" json:"management_flags,omitempty"`
+	NotManaged            bool                       `protobuf:"varint,5,opt,name=not_managed,json=notManaged" json:"not_managed,omitempty"`
+	ChangeManagementState string                     `protobuf:"bytes,6,opt,name=change_management_state,json=changeManagementState" json:"change_management_state,omitempty"`
+	Options               *InstallApplicationOptions `protobuf:"bytes,7,opt,name=options" json:"options,omitempty"`
 }
 
 func (m *InstallApplication) Reset()                    { *m = InstallApplication{} }
@@ -469,6 +654,269 @@ func (m *InstallApplication) GetChangeManagementState() string {
 	return ""
 }
 
+func (m *InstallApplication) GetOptions() *InstallApplicationOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type InstallApplicationOptions struct {
+	NotManaged     bool  `protobuf:"varint,1,opt,name=not_managed,json=notManaged" json:"not_managed,omitempty"`
+	PurchaseMethod int64 `protobuf:"varint,2,opt,name=purchase_method,json=purchaseMethod" json:"purchase_method,omitempty"`
+}
+
+func (m *InstallApplicationOptions) Reset()                    { *m = InstallApplicationOptions{} }
+func (m *InstallApplicationOptions) String() string            { return proto.CompactTextString(m) }
+func (*InstallApplicationOptions) ProtoMessage()               {}
+func (*InstallApplicationOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *InstallApplicationOptions) GetNotManaged() bool {
+	if m != nil {
+		return m.NotManaged
+	}
+	return false
+}
+
+func (m *InstallApplicationOptions) GetPurchaseMethod() int64 {
+	if m != nil {
+		return m.PurchaseMethod
+	}
+	return 0
+}
+
+type InstallProvisioningProfile struct {
+	ProvisioningProfile []byte `protobuf:"bytes,1,opt,name=provisioning_profile,json=provisioningProfile,proto3" json:"provisioning_profile,omitempty"`
+}
+
+func (m *InstallProvisioningProfile) Reset()                    { *m = InstallProvisioningProfile{} }
+func (m *InstallProvisioningProfile) String() string            { return proto.CompactTextString(m) }
+func (*InstallProvisioningProfile) ProtoMessage()               {}
+func (*InstallProvisioningProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *InstallProvisioningProfile) GetProvisioningProfile() []byte {
+	if m != nil {
+		return m.ProvisioningProfile
+	}
+	return nil
+}
+
+type RemoveProvisioningProfile struct {
+	Uuid string `protobuf:"bytes,1,opt,name=uuid" json:"uuid,omitempty"`
+}
+
+func (m *RemoveProvisioningProfile) Reset()                    { *m = RemoveProvisioningProfile{} }
+func (m *RemoveProvisioningProfile) String() string            { return proto.CompactTextString(m) }
+func (*RemoveProvisioningProfile) ProtoMessage()               {}
+func (*RemoveProvisioningProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *RemoveProvisioningProfile) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+type InstalledApplicationList struct {
+	Identifiers     []string `protobuf:"bytes,1,rep,name=identifiers" json:"identifiers,omitempty"`
+	ManagedAppsOnly bool     `protobuf:"varint,2,opt,name=managed_apps_only,json=managedAppsOnly" json:"managed_apps_only,omitempty"`
+}
+
+func (m *InstalledApplicationList) Reset()                    { *m = InstalledApplicationList{} }
+func (m *InstalledApplicationList) String() string            { return proto.CompactTextString(m) }
+func (*InstalledApplicationList) ProtoMessage()               {}
+func (*InstalledApplicationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *InstalledApplicationList) GetIdentifiers() []string {
+	if m != nil {
+		return m.Identifiers
+	}
+	return nil
+}
+
+func (m *InstalledApplicationList) GetManagedAppsOnly() bool {
+	if m != nil {
+		return m.ManagedAppsOnly
+	}
+	return false
+}
+
+type ClearPasscode struct {
+	UnlockToken []byte `protobuf:"bytes,1,opt,name=unlock_token,json=unlockToken,proto3" json:"unlock_token,omitempty"`
+}
+
+func (m *ClearPasscode) Reset()                    { *m = ClearPasscode{} }
+func (m *ClearPasscode) String() string            { return proto.CompactTextString(m) }
+func (*ClearPasscode) ProtoMessage()               {}
+func (*ClearPasscode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ClearPasscode) GetUnlockToken() []byte {
+	if m != nil {
+		return m.UnlockToken
+	}
+	return nil
+}
+
+type RequestMirroring struct {
+	DestinationName     string `protobuf:"bytes,1,opt,name=destination_name,json=destinationName" json:"destination_name,omitempty"`
+	DestinationDeviceId string `protobuf:"bytes,2,opt,name=destination_device_id,json=destinationDeviceId" json:"destination_device_id,omitempty"`
+	ScanTime            string `protobuf:"bytes,3,opt,name=scan_time,json=scanTime" json:"scan_time,omitempty"`
+	Password            string `protobuf:"bytes,4,opt,name=password" json:"password,omitempty"`
+}
+
+func (m *RequestMirroring) Reset()                    { *m = RequestMirroring{} }
+func (m *RequestMirroring) String() string            { return proto.CompactTextString(m) }
+func (*RequestMirroring) ProtoMessage()               {}
+func (*RequestMirroring) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *RequestMirroring) GetDestinationName() string {
+	if m != nil {
+		return m.DestinationName
+	}
+	return ""
+}
+
+func (m *RequestMirroring) GetDestinationDeviceId() string {
+	if m != nil {
+		return m.DestinationDeviceId
+	}
+	return ""
+}
+
+func (m *RequestMirroring) GetScanTime() string {
+	if m != nil {
+		return m.ScanTime
+	}
+	return ""
+}
+
+func (m *RequestMirroring) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type EnableLostMode struct {
+	Message     string `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	PhoneNumber string `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber" json:"phone_number,omitempty"`
+	Footnote    string `protobuf:"bytes,3,opt,name=footnote" json:"footnote,omitempty"`
+}
+
+func (m *EnableLostMode) Reset()                    { *m = EnableLostMode{} }
+func (m *EnableLostMode) String() string            { return proto.CompactTextString(m) }
+func (*EnableLostMode) ProtoMessage()               {}
+func (*EnableLostMode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *EnableLostMode) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *EnableLostMode) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *EnableLostMode) GetFootnote() string {
+	if m != nil {
+		return m.Footnote
+	}
+	return ""
+}
+
+type ApplyRedemptionCode struct {
+	Identifier     string `protobuf:"bytes,1,opt,name=identifier" json:"identifier,omitempty"`
+	RedemptionCode string `protobuf:"bytes,2,opt,name=redemption_code,json=redemptionCode" json:"redemption_code,omitempty"`
+}
+
+func (m *ApplyRedemptionCode) Reset()                    { *m = ApplyRedemptionCode{} }
+func (m *ApplyRedemptionCode) String() string            { return proto.CompactTextString(m) }
+func (*ApplyRedemptionCode) ProtoMessage()               {}
+func (*ApplyRedemptionCode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ApplyRedemptionCode) GetIdentifier() string {
+	if m != nil {
+		return m.Identifier
+	}
+	return ""
+}
+
+func (m *ApplyRedemptionCode) GetRedemptionCode() string {
+	if m != nil {
+		return m.RedemptionCode
+	}
+	return ""
+}
+
+type InstallMedia struct {
+	ItunesStoreId int64  `protobuf:"varint,1,opt,name=itunes_store_id,json=itunesStoreId" json:"itunes_store_id,omitempty"`
+	MediaUrl      string `protobuf:"bytes,2,opt,name=media_url,json=mediaUrl" json:"media_url,omitempty"`
+	MediaType     string `protobuf:"bytes,3,opt,name=media_type,json=mediaType" json:"media_type,omitempty"`
+}
+
+func (m *InstallMedia) Reset()                    { *m = InstallMedia{} }
+func (m *InstallMedia) String() string            { return proto.CompactTextString(m) }
+func (*InstallMedia) ProtoMessage()               {}
+func (*InstallMedia) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *InstallMedia) GetItunesStoreId() int64 {
+	if m != nil {
+		return m.ItunesStoreId
+	}
+	return 0
+}
+
+func (m *InstallMedia) GetMediaUrl() string {
+	if m != nil {
+		return m.MediaUrl
+	}
+	return ""
+}
+
+func (m *InstallMedia) GetMediaType() string {
+	if m != nil {
+		return m.MediaType
+	}
+	return ""
+}
+
+type RemoveMedia struct {
+	MediaType     string `protobuf:"bytes,1,opt,name=media_type,json=mediaType" json:"media_type,omitempty"`
+	ItunesStoreId int64  `protobuf:"varint,2,opt,name=itunes_store_id,json=itunesStoreId" json:"itunes_store_id,omitempty"`
+	PersistentId  string `protobuf:"bytes,3,opt,name=persistent_id,json=persistentId" json:"persistent_id,omitempty"`
+}
+
+func (m *RemoveMedia) Reset()                    { *m = RemoveMedia{} }
+func (m *RemoveMedia) String() string            { return proto.CompactTextString(m) }
+func (*RemoveMedia) ProtoMessage()               {}
+func (*RemoveMedia) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *RemoveMedia) GetMediaType() string {
+	if m != nil {
+		return m.MediaType
+	}
+	return ""
+}
+
+func (m *RemoveMedia) GetItunesStoreId() int64 {
+	if m != nil {
+		return m.ItunesStoreId
+	}
+	return 0
+}
+
+func (m *RemoveMedia) GetPersistentId() string {
+	if m != nil {
+		return m.PersistentId
+	}
+	return ""
+}
+
 type EraseDevice struct {
 	Pin              string `protobuf:"bytes,1,opt,name=pin" json:"pin,omitempty"`
 	PreserveDataPlan bool   `protobuf:"varint,2,opt,name=preserve_data_plan,json=preserveDataPlan" json:"preserve_data_plan,omitempty"`
@@ -477,7 +925,7 @@ type EraseDevice struct {
 func (m *EraseDevice) Reset()                    { *m = EraseDevice{} }
 func (m *EraseDevice) String() string            { return proto.CompactTextString(m) }
 func (*EraseDevice) ProtoMessage()               {}
-func (*EraseDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*EraseDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *EraseDevice) GetPin() string {
 	if m != nil {
@@ -502,7 +950,7 @@ type DeviceLock struct {
 func (m *DeviceLock) Reset()                    { *m = DeviceLock{} }
 func (m *DeviceLock) String() string            { return proto.CompactTextString(m) }
 func (*DeviceLock) ProtoMessage()               {}
-func (*DeviceLock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*DeviceLock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *DeviceLock) GetPin() string {
 	if m != nil {
@@ -525,6 +973,184 @@ func (m *DeviceLock) GetPhoneNumber() string {
 	return ""
 }
 
+type ManagedApplicationList struct {
+	Identifiers []string `protobuf:"bytes,1,rep,name=identifiers" json:"identifiers,omitempty"`
+}
+
+func (m *ManagedApplicationList) Reset()                    { *m = ManagedApplicationList{} }
+func (m *ManagedApplicationList) String() string            { return proto.CompactTextString(m) }
+func (*ManagedApplicationList) ProtoMessage()               {}
+func (*ManagedApplicationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ManagedApplicationList) GetIdentifiers() []string {
+	if m != nil {
+		return m.Identifiers
+	}
+	return nil
+}
+
+type RemoveApplication struct {
+	Identifier string `protobuf:"bytes,1,opt,name=identifier" json:"identifier,omitempty"`
+}
+
+func (m *RemoveApplication) Reset()                    { *m = RemoveApplication{} }
+func (m *RemoveApplication) String() string            { return proto.CompactTextString(m) }
+func (*RemoveApplication) ProtoMessage()               {}
+func (*RemoveApplication) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *RemoveApplication) GetIdentifier() string {
+	if m != nil {
+		return m.Identifier
+	}
+	return ""
+}
+
+type InviteToProgram struct {
+	ProgramId     string `protobuf:"bytes,1,opt,name=program_id,json=programId" json:"program_id,omitempty"`
+	InvitationUrl string `protobuf:"bytes,2,opt,name=invitation_url,json=invitationUrl" json:"invitation_url,omitempty"`
+}
+
+func (m *InviteToProgram) Reset()                    { *m = InviteToProgram{} }
+func (m *InviteToProgram) String() string            { return proto.CompactTextString(m) }
+func (*InviteToProgram) ProtoMessage()               {}
+func (*InviteToProgram) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *InviteToProgram) GetProgramId() string {
+	if m != nil {
+		return m.ProgramId
+	}
+	return ""
+}
+
+func (m *InviteToProgram) GetInvitationUrl() string {
+	if m != nil {
+		return m.InvitationUrl
+	}
+	return ""
+}
+
+type ValidateApplications struct {
+	Identifiers []string `protobuf:"bytes,1,rep,name=identifiers" json:"identifiers,omitempty"`
+}
+
+func (m *ValidateApplications) Reset()                    { *m = ValidateApplications{} }
+func (m *ValidateApplications) String() string            { return proto.CompactTextString(m) }
+func (*ValidateApplications) ProtoMessage()               {}
+func (*ValidateApplications) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *ValidateApplications) GetIdentifiers() []string {
+	if m != nil {
+		return m.Identifiers
+	}
+	return nil
+}
+
+type ApplicationConfiguration struct {
+	Identifier    string                           `protobuf:"bytes,1,opt,name=identifier" json:"identifier,omitempty"`
+	Configuration []*ApplicationConfigurationValue `protobuf:"bytes,2,rep,name=configuration" json:"configuration,omitempty"`
+}
+
+func (m *ApplicationConfiguration) Reset()                    { *m = ApplicationConfiguration{} }
+func (m *ApplicationConfiguration) String() string            { return proto.CompactTextString(m) }
+func (*ApplicationConfiguration) ProtoMessage()               {}
+func (*ApplicationConfiguration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ApplicationConfiguration) GetIdentifier() string {
+	if m != nil {
+		return m.Identifier
+	}
+	return ""
+}
+
+func (m *ApplicationConfiguration) GetConfiguration() []*ApplicationConfigurationValue {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+type ApplicationConfigurationValue struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *ApplicationConfigurationValue) Reset()                    { *m = ApplicationConfigurationValue{} }
+func (m *ApplicationConfigurationValue) String() string            { return proto.CompactTextString(m) }
+func (*ApplicationConfigurationValue) ProtoMessage()               {}
+func (*ApplicationConfigurationValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ApplicationConfigurationValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ApplicationConfigurationValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type ManagedApplicationConfiguration struct {
+	Identifiers []string `protobuf:"bytes,1,rep,name=identifiers" json:"identifiers,omitempty"`
+}
+
+func (m *ManagedApplicationConfiguration) Reset()         { *m = ManagedApplicationConfiguration{} }
+func (m *ManagedApplicationConfiguration) String() string { return proto.CompactTextString(m) }
+func (*ManagedApplicationConfiguration) ProtoMessage()    {}
+func (*ManagedApplicationConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{31}
+}
+
+func (m *ManagedApplicationConfiguration) GetIdentifiers() []string {
+	if m != nil {
+		return m.Identifiers
+	}
+	return nil
+}
+
+type ManagedApplicationAttributes struct {
+	Identifiers []string `protobuf:"bytes,1,rep,name=identifiers" json:"identifiers,omitempty"`
+}
+
+func (m *ManagedApplicationAttributes) Reset()                    { *m = ManagedApplicationAttributes{} }
+func (m *ManagedApplicationAttributes) String() string            { return proto.CompactTextString(m) }
+func (*ManagedApplicationAttributes) ProtoMessage()               {}
+func (*ManagedApplicationAttributes) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *ManagedApplicationAttributes) GetIdentifiers() []string {
+	if m != nil {
+		return m.Identifiers
+	}
+	return nil
+}
+
+type ManagedApplicationFeedback struct {
+	Identifiers    []string `protobuf:"bytes,1,rep,name=identifiers" json:"identifiers,omitempty"`
+	DeleteFeedback bool     `protobuf:"varint,2,opt,name=delete_feedback,json=deleteFeedback" json:"delete_feedback,omitempty"`
+}
+
+func (m *ManagedApplicationFeedback) Reset()                    { *m = ManagedApplicationFeedback{} }
+func (m *ManagedApplicationFeedback) String() string            { return proto.CompactTextString(m) }
+func (*ManagedApplicationFeedback) ProtoMessage()               {}
+func (*ManagedApplicationFeedback) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *ManagedApplicationFeedback) GetIdentifiers() []string {
+	if m != nil {
+		return m.Identifiers
+	}
+	return nil
+}
+
+func (m *ManagedApplicationFeedback) GetDeleteFeedback() bool {
+	if m != nil {
+		return m.DeleteFeedback
+	}
+	return false
+}
+
 type Settings struct {
 	Settings []*Setting `protobuf:"bytes,1,rep,name=settings" json:"settings,omitempty"`
 }
@@ -532,7 +1158,7 @@ type Settings struct {
 func (m *Settings) Reset()                    { *m = Settings{} }
 func (m *Settings) String() string            { return proto.CompactTextString(m) }
 func (*Settings) ProtoMessage()               {}
-func (*Settings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*Settings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *Settings) GetSettings() []*Setting {
 	if m != nil {
@@ -542,15 +1168,18 @@ func (m *Settings) GetSettings() []*Setting {
 }
 
 type Setting struct {
-	Item       string             `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
-	DeviceName *DeviceNameSetting `protobuf:"bytes,2,opt,name=device_name,json=deviceName" json:"device_name,omitempty"`
-	Hostname   *HostnameSetting   `protobuf:"bytes,3,opt,name=hostname" json:"hostname,omitempty"`
+	Item       string              `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
+	DeviceName *DeviceNameSetting  `protobuf:"bytes,2,opt,name=device_name,json=deviceName" json:"device_name,omitempty"`
+	Hostname   *HostnameSetting    `protobuf:"bytes,3,opt,name=hostname" json:"hostname,omitempty"`
+	Enabled    *EnabledSetting     `protobuf:"bytes,4,opt,name=enabled" json:"enabled,omitempty"`
+	Identifier *IdentifierSetting  `protobuf:"bytes,5,opt,name=identifier" json:"identifier,omitempty"`
+	Attributes []*SettingAttribute `protobuf:"bytes,6,rep,name=attributes" json:"attributes,omitempty"`
 }
 
 func (m *Setting) Reset()                    { *m = Setting{} }
 func (m *Setting) String() string            { return proto.CompactTextString(m) }
 func (*Setting) ProtoMessage()               {}
-func (*Setting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*Setting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *Setting) GetItem() string {
 	if m != nil {
@@ -573,6 +1202,83 @@ func (m *Setting) GetHostname() *HostnameSetting {
 	return nil
 }
 
+func (m *Setting) GetEnabled() *EnabledSetting {
+	if m != nil {
+		return m.Enabled
+	}
+	return nil
+}
+
+func (m *Setting) GetIdentifier() *IdentifierSetting {
+	if m != nil {
+		return m.Identifier
+	}
+	return nil
+}
+
+func (m *Setting) GetAttributes() []*SettingAttribute {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type EnabledSetting struct {
+	Enabled bool `protobuf:"varint,1,opt,name=enabled" json:"enabled,omitempty"`
+}
+
+func (m *EnabledSetting) Reset()                    { *m = EnabledSetting{} }
+func (m *EnabledSetting) String() string            { return proto.CompactTextString(m) }
+func (*EnabledSetting) ProtoMessage()               {}
+func (*EnabledSetting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *EnabledSetting) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type IdentifierSetting struct {
+	Identifier string `protobuf:"bytes,1,opt,name=identifier" json:"identifier,omitempty"`
+}
+
+func (m *IdentifierSetting) Reset()                    { *m = IdentifierSetting{} }
+func (m *IdentifierSetting) String() string            { return proto.CompactTextString(m) }
+func (*IdentifierSetting) ProtoMessage()               {}
+func (*IdentifierSetting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *IdentifierSetting) GetIdentifier() string {
+	if m != nil {
+		return m.Identifier
+	}
+	return ""
+}
+
+type SettingAttribute struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *SettingAttribute) Reset()                    { *m = SettingAttribute{} }
+func (m *SettingAttribute) String() string            { return proto.CompactTextString(m) }
+func (*SettingAttribute) ProtoMessage()               {}
+func (*SettingAttribute) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *SettingAttribute) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SettingAttribute) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 type DeviceNameSetting struct {
 	DeviceName string `protobuf:"bytes,1,opt,name=device_name,json=deviceName" json:"device_name,omitempty"`
 }
@@ -580,7 +1286,7 @@ type DeviceNameSetting struct {
 func (m *DeviceNameSetting) Reset()                    { *m = DeviceNameSetting{} }
 func (m *DeviceNameSetting) String() string            { return proto.CompactTextString(m) }
 func (*DeviceNameSetting) ProtoMessage()               {}
-func (*DeviceNameSetting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*DeviceNameSetting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *DeviceNameSetting) GetDeviceName() string {
 	if m != nil {
@@ -596,7 +1302,7 @@ type HostnameSetting struct {
 func (m *HostnameSetting) Reset()                    { *m = HostnameSetting{} }
 func (m *HostnameSetting) String() string            { return proto.CompactTextString(m) }
 func (*HostnameSetting) ProtoMessage()               {}
-func (*HostnameSetting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*HostnameSetting) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *HostnameSetting) GetHostname() string {
 	if m != nil {
@@ -605,6 +1311,70 @@ func (m *HostnameSetting) GetHostname() string {
 	return ""
 }
 
+type Batch struct {
+	Id          string          `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	CreatedAt   int64           `protobuf:"varint,2,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	RequestType string          `protobuf:"bytes,3,opt,name=request_type,json=requestType" json:"request_type,omitempty"`
+	Commands    []*BatchCommand `protobuf:"bytes,4,rep,name=commands" json:"commands,omitempty"`
+}
+
+func (m *Batch) Reset()                    { *m = Batch{} }
+func (m *Batch) String() string            { return proto.CompactTextString(m) }
+func (*Batch) ProtoMessage()               {}
+func (*Batch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *Batch) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Batch) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Batch) GetRequestType() string {
+	if m != nil {
+		return m.RequestType
+	}
+	return ""
+}
+
+func (m *Batch) GetCommands() []*BatchCommand {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+type BatchCommand struct {
+	Udid        string `protobuf:"bytes,1,opt,name=udid" json:"udid,omitempty"`
+	CommandUuid string `protobuf:"bytes,2,opt,name=command_uuid,json=commandUuid" json:"command_uuid,omitempty"`
+}
+
+func (m *BatchCommand) Reset()                    { *m = BatchCommand{} }
+func (m *BatchCommand) String() string            { return proto.CompactTextString(m) }
+func (*BatchCommand) ProtoMessage()               {}
+func (*BatchCommand) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *BatchCommand) GetUdid() string {
+	if m != nil {
+		return m.Udid
+	}
+	return ""
+}
+
+func (m *BatchCommand) GetCommandUuid() string {
+	if m != nil {
+		return m.CommandUuid
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Event)(nil), "commandproto.Event")
 	proto.RegisterType((*Payload)(nil), "commandproto.Payload")
@@ -619,88 +1389,180 @@ func init() {
 	proto.RegisterType((*RemoveProfile)(nil), "commandproto.RemoveProfile")
 	proto.RegisterType((*DeleteUser)(nil), "commandproto.DeleteUser")
 	proto.RegisterType((*InstallApplication)(nil), "commandproto.InstallApplication")
+	proto.RegisterType((*InstallApplicationOptions)(nil), "commandproto.InstallApplicationOptions")
+	proto.RegisterType((*InstallProvisioningProfile)(nil), "commandproto.InstallProvisioningProfile")
+	proto.RegisterType((*RemoveProvisioningProfile)(nil), "commandproto.RemoveProvisioningProfile")
+	proto.RegisterType((*InstalledApplicationList)(nil), "commandproto.InstalledApplicationList")
+	proto.RegisterType((*ClearPasscode)(nil), "commandproto.ClearPasscode")
+	proto.RegisterType((*RequestMirroring)(nil), "commandproto.RequestMirroring")
+	proto.RegisterType((*EnableLostMode)(nil), "commandproto.EnableLostMode")
+	proto.RegisterType((*ApplyRedemptionCode)(nil), "commandproto.ApplyRedemptionCode")
+	proto.RegisterType((*InstallMedia)(nil), "commandproto.InstallMedia")
+	proto.RegisterType((*RemoveMedia)(nil), "commandproto.RemoveMedia")
 	proto.RegisterType((*EraseDevice)(nil), "commandproto.EraseDevice")
 	proto.RegisterType((*DeviceLock)(nil), "commandproto.DeviceLock")
+	proto.RegisterType((*ManagedApplicationList)(nil), "commandproto.ManagedApplicationList")
+	proto.RegisterType((*RemoveApplication)(nil), "commandproto.RemoveApplication")
+	proto.RegisterType((*InviteToProgram)(nil), "commandproto.InviteToProgram")
+	proto.RegisterType((*ValidateApplications)(nil), "commandproto.ValidateApplications")
+	proto.RegisterType((*ApplicationConfiguration)(nil), "commandproto.ApplicationConfiguration")
+	proto.RegisterType((*ApplicationConfigurationValue)(nil), "commandproto.ApplicationConfigurationValue")
+	proto.RegisterType((*ManagedApplicationConfiguration)(nil), "commandproto.ManagedApplicationConfiguration")
+	proto.RegisterType((*ManagedApplicationAttributes)(nil), "commandproto.ManagedApplicationAttributes")
+	proto.RegisterType((*ManagedApplicationFeedback)(nil), "commandproto.ManagedApplicationFeedback")
 	proto.RegisterType((*Settings)(nil), "commandproto.Settings")
 	proto.RegisterType((*Setting)(nil), "commandproto.Setting")
+	proto.RegisterType((*EnabledSetting)(nil), "commandproto.EnabledSetting")
+	proto.RegisterType((*IdentifierSetting)(nil), "commandproto.IdentifierSetting")
+	proto.RegisterType((*SettingAttribute)(nil), "commandproto.SettingAttribute")
 	proto.RegisterType((*DeviceNameSetting)(nil), "commandproto.DeviceNameSetting")
 	proto.RegisterType((*HostnameSetting)(nil), "commandproto.HostnameSetting")
+	proto.RegisterType((*Batch)(nil), "commandproto.Batch")
+	proto.RegisterType((*BatchCommand)(nil), "commandproto.BatchCommand")
 }
 
 func init() { proto.RegisterFile("command.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1139 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x56, 0x5b, 0x6f, 0x1c, 0x35,
-	0x14, 0xd6, 0xee, 0xb6, 0xd9, 0xdd, 0xb3, 0xd9, 0x24, 0x35, 0x49, 0x3a, 0xa5, 0xb4, 0x09, 0x53,
-	0x40, 0x2d, 0xea, 0x05, 0x0a, 0x42, 0xaa, 0x04, 0x12, 0xa1, 0x29, 0x6a, 0x45, 0xdb, 0x04, 0x87,
-	0x80, 0x10, 0x42, 0x96, 0x3b, 0xe3, 0xdd, 0xb5, 0x32, 0x63, 0x4f, 0x6d, 0x4f, 0xd0, 0x3e, 0xf0,
-	0xc4, 0x2f, 0xe0, 0x15, 0x89, 0x7f, 0xc3, 0x0f, 0x43, 0xbe, 0xcd, 0x5e, 0xfb, 0xe6, 0xf3, 0xf9,
-	0x3b, 0x9f, 0xcf, 0xf8, 0x5c, 0x3c, 0x30, 0xcc, 0x64, 0x59, 0x52, 0x91, 0x3f, 0xac, 0x94, 0x34,
-	0x12, 0x6d, 0x06, 0xd3, 0x59, 0xe9, 0x9f, 0x70, 0xf5, 0xd9, 0x25, 0x13, 0x06, 0x6d, 0x41, 0x9b,
-	0xe7, 0x49, 0xeb, 0xb0, 0x75, 0xb7, 0x8f, 0xdb, 0x3c, 0x47, 0x08, 0xae, 0x18, 0x5e, 0xb2, 0xa4,
-	0x7d, 0xd8, 0xba, 0xdb, 0xc1, 0x6e, 0x8d, 0x1e, 0x41, 0xb7, 0xa2, 0xd3, 0x42, 0xd2, 0x3c, 0xe9,
-	0x1c, 0xb6, 0xee, 0x0e, 0x1e, 0xef, 0x3d, 0x9c, 0x17, 0x7b, 0x78, 0xea, 0x37, 0x71, 0x64, 0xa1,
-	0x03, 0x18, 0xe4, 0xec, 0x92, 0x67, 0x8c, 0xd4, 0x39, 0xcf, 0x93, 0x2b, 0x4e, 0x1d, 0x3c, 0x74,
-	0x9e, 0xf3, 0x3c, 0xfd, 0x1d, 0xba, 0xc1, 0x09, 0x7d, 0x08, 0x31, 0x32, 0x52, 0xd7, 0x4d, 0x28,
-	0x83, 0x80, 0x9d, 0xd7, 0x3c, 0xb7, 0xe7, 0x07, 0xd3, 0x85, 0xb5, 0x72, 0xfe, 0x53, 0x6f, 0xe0,
-	0xc8, 0x4a, 0xff, 0xdb, 0x80, 0x6e, 0x00, 0xad, 0xbe, 0x62, 0x6f, 0x6b, 0xa6, 0x0d, 0x31, 0xd3,
-	0x8a, 0x45, 0xfd, 0x80, 0xfd, 0x34, 0xad, 0x18, 0x7a, 0x0d, 0x28, 0x84, 0xcb, 0xc5, 0x48, 0xaa,
-	0x92, 0x1a, 0x2e, 0x45, 0x38, 0xea, 0x60, 0xf1, 0xa8, 0x63, 0xc7, 0x7b, 0x31, 0xa3, 0xe1, 0x6b,
-	0xf9, 0x32, 0x84, 0x9e, 0xc1, 0x36, 0x17, 0xda, 0xd0, 0xa2, 0x20, 0x95, 0x92, 0x23, 0x5e, 0xb0,
-	0x70, 0x6f, 0x1f, 0x2c, 0x8a, 0xbd, 0xf0, 0xa4, 0x53, 0xcf, 0xc1, 0x5b, 0x7c, 0xc1, 0x46, 0x3f,
-	0xc2, 0x7b, 0x51, 0x86, 0x56, 0x55, 0xc1, 0x33, 0x1f, 0xd7, 0x15, 0x27, 0x75, 0xb8, 0x56, 0xea,
-	0x68, 0xc6, 0xc3, 0x88, 0xaf, 0x60, 0xe8, 0x17, 0xd8, 0xa3, 0x59, 0x26, 0x6b, 0x61, 0x48, 0x26,
-	0xc5, 0x88, 0x8f, 0x6b, 0xe5, 0x45, 0xaf, 0x3a, 0xd1, 0x74, 0x51, 0xf4, 0xc8, 0x53, 0x9f, 0xce,
-	0x33, 0xf1, 0x2e, 0x5d, 0x83, 0xa2, 0x97, 0x80, 0x74, 0x36, 0x61, 0x79, 0x5d, 0x30, 0x22, 0x35,
-	0xa9, 0xab, 0x9c, 0x1a, 0x96, 0x6c, 0x38, 0xd5, 0xdb, 0x8b, 0xaa, 0x67, 0x81, 0x77, 0x72, 0x76,
-	0xee, 0x58, 0x78, 0x27, 0x7a, 0x9e, 0x68, 0x8f, 0xa0, 0x5f, 0xe1, 0xfa, 0xaa, 0x1a, 0xd1, 0x19,
-	0x15, 0x49, 0x77, 0x5d, 0xa0, 0xcb, 0x92, 0x67, 0x19, 0x15, 0x78, 0x77, 0x59, 0xd6, 0xa2, 0xe8,
-	0x3b, 0xd8, 0x52, 0xac, 0x94, 0x97, 0xac, 0x49, 0x4d, 0xcf, 0x29, 0xde, 0x5c, 0x54, 0xc4, 0x8e,
-	0x13, 0x33, 0x33, 0x54, 0xf3, 0x26, 0x7a, 0x62, 0xcb, 0xbb, 0x60, 0x86, 0x91, 0x5a, 0x33, 0x95,
-	0xf4, 0x9d, 0x40, 0xb2, 0x5c, 0x28, 0x96, 0x70, 0xae, 0x99, 0xb2, 0x85, 0x1f, 0xd7, 0xe8, 0x31,
-	0xf4, 0x34, 0x33, 0x86, 0x8b, 0xb1, 0x4e, 0xc0, 0xf9, 0xed, 0x2f, 0x7d, 0x4a, 0xd8, 0xc5, 0x0d,
-	0x0f, 0x7d, 0x0d, 0x9b, 0x4c, 0x51, 0xcd, 0x88, 0xaf, 0xb4, 0x64, 0xe0, 0xfc, 0x6e, 0x2c, 0xfa,
-	0x3d, 0xb3, 0x0c, 0x5f, 0x9d, 0x78, 0xc0, 0x66, 0x86, 0x0f, 0xd6, 0x15, 0x77, 0x21, 0xb3, 0x8b,
-	0x64, 0x73, 0x7d, 0xb0, 0x96, 0xf0, 0x52, 0x66, 0x17, 0xb1, 0x4b, 0xed, 0x3a, 0x3d, 0x86, 0x9d,
-	0xe5, 0x9b, 0x45, 0x9f, 0x41, 0xd7, 0xa7, 0x43, 0x27, 0xad, 0xc3, 0xce, 0x6a, 0xfc, 0x4d, 0x56,
-	0x23, 0x2d, 0xc5, 0xd0, 0x6b, 0xbc, 0x0f, 0x60, 0x50, 0x29, 0x99, 0xd7, 0x99, 0x21, 0x17, 0x6c,
-	0x1a, 0x7a, 0x11, 0x02, 0xf4, 0x03, 0x9b, 0xa2, 0x8f, 0x61, 0xab, 0xa9, 0xf9, 0xac, 0x69, 0xc3,
-	0x3e, 0x1e, 0xc6, 0x62, 0x76, 0x60, 0x7a, 0x1f, 0x76, 0xd7, 0xe5, 0x1c, 0xed, 0xc2, 0xd5, 0x91,
-	0x54, 0x99, 0xef, 0xf2, 0x1e, 0xf6, 0x46, 0xfa, 0x6f, 0x1b, 0x76, 0x8f, 0xd6, 0x57, 0xed, 0x1d,
-	0x7d, 0xc1, 0x2b, 0x52, 0x29, 0x5e, 0x52, 0x35, 0x25, 0x9a, 0x99, 0xba, 0x22, 0x4d, 0x87, 0x28,
-	0xe6, 0x9b, 0xc3, 0x8b, 0x1d, 0x58, 0xea, 0xa9, 0x67, 0x9e, 0x59, 0x62, 0x94, 0x0c, 0x34, 0xf4,
-	0x33, 0xdc, 0xd3, 0xcc, 0xbc, 0x43, 0x8c, 0x6a, 0xa2, 0xd8, 0xb8, 0x2e, 0xa8, 0xf2, 0x45, 0xd3,
-	0x76, 0x9a, 0x77, 0x34, 0x33, 0x6b, 0x24, 0x8f, 0x34, 0xf6, 0x5c, 0x57, 0x33, 0x04, 0x6e, 0xd0,
-	0xda, 0xc8, 0x28, 0x98, 0x97, 0x5c, 0x44, 0x59, 0x9d, 0x74, 0x5c, 0x12, 0x3e, 0x5a, 0x6a, 0xdc,
-	0xda, 0x48, 0xaf, 0x67, 0xc9, 0x41, 0x54, 0xe3, 0x7d, 0xba, 0x16, 0x4f, 0xff, 0x6e, 0xc1, 0xfe,
-	0x7a, 0x17, 0x74, 0x0b, 0x40, 0x4f, 0xa4, 0x32, 0x44, 0xd0, 0x32, 0xce, 0xce, 0xbe, 0x43, 0x5e,
-	0xd3, 0x92, 0xa1, 0x9b, 0xd0, 0x1f, 0xd5, 0x45, 0xe1, 0x77, 0x7d, 0xa6, 0x7a, 0x16, 0x70, 0x9b,
-	0x77, 0x60, 0x58, 0x51, 0xad, 0xff, 0x90, 0x2a, 0x27, 0x13, 0xaa, 0x27, 0x6e, 0x08, 0x6e, 0xe2,
-	0xcd, 0x08, 0x3e, 0xa7, 0x7a, 0x82, 0xf6, 0x61, 0x63, 0xc2, 0xf3, 0x9c, 0xf9, 0xb9, 0xd6, 0xc3,
-	0xc1, 0x4a, 0x1f, 0xc0, 0xb5, 0x95, 0x59, 0x8b, 0x12, 0xe8, 0xbe, 0xad, 0x99, 0xe2, 0xa1, 0xf8,
-	0xfa, 0x38, 0x9a, 0xe9, 0xa7, 0xb0, 0xb5, 0x38, 0x4d, 0x2d, 0x37, 0x3e, 0x5a, 0x2d, 0x77, 0x6e,
-	0x34, 0xd3, 0x47, 0x30, 0x5c, 0x68, 0x6f, 0x74, 0x1b, 0x80, 0xe7, 0x4c, 0x18, 0x3e, 0xe2, 0x4c,
-	0xc5, 0xa2, 0x9c, 0x21, 0xe9, 0x09, 0xc0, 0xac, 0x9d, 0xd1, 0xfb, 0xd0, 0xb3, 0x19, 0x9c, 0xbb,
-	0x90, 0xc6, 0xb6, 0xe5, 0xeb, 0x4a, 0x8e, 0xb8, 0x96, 0x8f, 0xe5, 0xdb, 0xc3, 0x43, 0x87, 0x1e,
-	0x07, 0x30, 0xfd, 0xab, 0x0d, 0x68, 0x75, 0x62, 0xa3, 0x4f, 0x60, 0x9b, 0x9b, 0x5a, 0x30, 0x4d,
-	0xb4, 0x91, 0x8a, 0x91, 0xf0, 0x1a, 0x76, 0xf0, 0xd0, 0xc3, 0x67, 0x16, 0x7d, 0x91, 0x2f, 0xc5,
-	0xdb, 0x5e, 0x8e, 0xd7, 0x3e, 0x79, 0x25, 0x15, 0x7c, 0x64, 0xdf, 0xbc, 0x5a, 0x15, 0xee, 0xde,
-	0xfb, 0x78, 0x10, 0xb1, 0x73, 0x55, 0xa0, 0x7b, 0xb0, 0x53, 0x52, 0x41, 0xc7, 0xac, 0x64, 0xc2,
-	0x90, 0x51, 0x41, 0xc7, 0xda, 0x25, 0xa0, 0x83, 0xb7, 0x67, 0xf8, 0xf7, 0x16, 0xb6, 0x3d, 0x2b,
-	0xa4, 0x21, 0x1e, 0xce, 0xdd, 0x4b, 0xd1, 0xc3, 0x20, 0xa4, 0x79, 0xe5, 0x11, 0xf4, 0x15, 0x5c,
-	0xcf, 0x26, 0x54, 0x8c, 0x19, 0x99, 0x93, 0xd4, 0x26, 0x3e, 0x00, 0x7d, 0xbc, 0xe7, 0xb7, 0x5f,
-	0x35, 0xbb, 0x67, 0x76, 0x33, 0x7d, 0x05, 0x83, 0xb9, 0xa9, 0x85, 0x76, 0xa0, 0x53, 0x71, 0x11,
-	0xae, 0xd4, 0x2e, 0xd1, 0x7d, 0x40, 0x95, 0x62, 0x9a, 0xa9, 0x4b, 0x46, 0x72, 0x6a, 0x28, 0xa9,
-	0x0a, 0x1a, 0x6f, 0x74, 0x27, 0xee, 0x1c, 0x53, 0x43, 0x4f, 0x0b, 0x2a, 0xd2, 0xdf, 0x6c, 0x96,
-	0xe2, 0xec, 0x5a, 0xa3, 0x96, 0x40, 0xb7, 0x64, 0x5a, 0xd3, 0x71, 0xac, 0xd4, 0x68, 0xda, 0xfb,
-	0xaa, 0x26, 0x52, 0x30, 0x22, 0xea, 0xf2, 0x0d, 0x53, 0xf1, 0xbe, 0x1c, 0xf6, 0xda, 0x41, 0xe9,
-	0x37, 0xd0, 0x8b, 0x93, 0x19, 0x7d, 0x3e, 0x37, 0xc3, 0xfd, 0x0c, 0xdc, 0x5b, 0x3b, 0xc3, 0x67,
-	0x23, 0x3c, 0xfd, 0xa7, 0x05, 0xdd, 0x80, 0xda, 0x3f, 0x2c, 0x6e, 0x58, 0x19, 0x42, 0x73, 0x6b,
-	0xf4, 0x6d, 0x33, 0xa4, 0x9b, 0x4e, 0x7a, 0xc7, 0xaf, 0x87, 0xed, 0xac, 0xa8, 0x1f, 0x66, 0xb5,
-	0x6b, 0xb6, 0x27, 0xd0, 0x9b, 0x48, 0x6d, 0x9c, 0xbb, 0xff, 0xd9, 0xb8, 0xb5, 0xe8, 0xfe, 0x3c,
-	0xec, 0x36, 0xc1, 0x45, 0x7a, 0xfa, 0x65, 0x6c, 0xb5, 0x39, 0xed, 0xb9, 0x5f, 0xb8, 0xb9, 0x42,
-	0x9f, 0x3b, 0x30, 0x7d, 0x00, 0xdb, 0x4b, 0x92, 0xb6, 0x33, 0x9a, 0x18, 0x42, 0x67, 0x44, 0xfb,
-	0xcd, 0x86, 0x8b, 0xe2, 0x8b, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x4e, 0x4b, 0x47, 0x7e, 0x96,
-	0x0a, 0x00, 0x00,
+	// 2229 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x59, 0xeb, 0x72, 0x1b, 0xb7,
+	0x15, 0x1e, 0x92, 0x96, 0x49, 0x1e, 0x8a, 0xa4, 0x04, 0x5d, 0xbc, 0x92, 0x2d, 0x4b, 0x5e, 0xb7,
+	0xb1, 0xe3, 0xc6, 0x76, 0xe3, 0x74, 0x3c, 0x4d, 0xa6, 0x6d, 0xa2, 0xf8, 0xd2, 0x68, 0x62, 0x59,
+	0xca, 0xca, 0x72, 0xa6, 0xd3, 0x69, 0x76, 0xe0, 0x5d, 0x90, 0x44, 0xb5, 0xb7, 0x00, 0x58, 0x75,
+	0xf8, 0x10, 0xfd, 0xd1, 0x07, 0xe8, 0x0b, 0xf4, 0x29, 0xda, 0x3f, 0x7d, 0x81, 0xbe, 0x50, 0x07,
+	0xb7, 0xe5, 0x92, 0xbb, 0xb4, 0xf4, 0x6f, 0xcf, 0x87, 0x83, 0x83, 0x03, 0xe0, 0xe0, 0xe0, 0x3b,
+	0x58, 0xe8, 0x07, 0x69, 0x1c, 0xe3, 0x24, 0x7c, 0x92, 0xb1, 0x54, 0xa4, 0x68, 0xd5, 0x88, 0x4a,
+	0x72, 0xff, 0xd3, 0x80, 0x95, 0x57, 0x97, 0x24, 0x11, 0x68, 0x00, 0x4d, 0x1a, 0x3a, 0x8d, 0x83,
+	0xc6, 0xc3, 0xae, 0xd7, 0xa4, 0x21, 0x42, 0x70, 0x43, 0xd0, 0x98, 0x38, 0xcd, 0x83, 0xc6, 0xc3,
+	0x96, 0xa7, 0xbe, 0xd1, 0x53, 0x68, 0x67, 0x78, 0x1a, 0xa5, 0x38, 0x74, 0x5a, 0x07, 0x8d, 0x87,
+	0xbd, 0x67, 0x5b, 0x4f, 0xca, 0xd6, 0x9e, 0x9c, 0xea, 0x46, 0xcf, 0x6a, 0xa1, 0x7d, 0xe8, 0x85,
+	0xe4, 0x92, 0x06, 0xc4, 0xcf, 0x43, 0x1a, 0x3a, 0x37, 0x94, 0x75, 0xd0, 0xd0, 0x79, 0x48, 0x43,
+	0xb4, 0x06, 0x2d, 0x21, 0x22, 0x67, 0x45, 0x0d, 0x22, 0x3f, 0xd1, 0x2e, 0x74, 0x32, 0x46, 0x53,
+	0x46, 0xc5, 0xd4, 0xb9, 0xa9, 0xe0, 0x42, 0x46, 0x9b, 0xb0, 0x82, 0x47, 0x82, 0x30, 0xa7, 0x7d,
+	0xd0, 0x7a, 0xd8, 0xf5, 0xb4, 0xe0, 0xfe, 0x05, 0xda, 0x66, 0x60, 0x74, 0x0f, 0xec, 0xf4, 0xfc,
+	0x3c, 0x2f, 0xa6, 0xd3, 0x33, 0xd8, 0x79, 0x4e, 0x43, 0x39, 0x07, 0x23, 0x3a, 0xcd, 0xba, 0x39,
+	0xbc, 0xd0, 0x82, 0x67, 0xb5, 0xdc, 0x7f, 0x23, 0x68, 0x1b, 0x50, 0xda, 0x67, 0xe4, 0xe7, 0x9c,
+	0x70, 0xe1, 0x8b, 0x69, 0x46, 0xac, 0x7d, 0x83, 0xbd, 0x9b, 0x66, 0x04, 0xbd, 0x05, 0x64, 0xa6,
+	0x4c, 0x93, 0x51, 0xca, 0x62, 0x2c, 0x68, 0x9a, 0x98, 0xa1, 0xf6, 0xe7, 0x87, 0x7a, 0xa9, 0xf4,
+	0x8e, 0x66, 0x6a, 0xde, 0x7a, 0xb8, 0x08, 0xa1, 0x57, 0x30, 0xa4, 0x09, 0x17, 0x38, 0x8a, 0xfc,
+	0x8c, 0xa5, 0x23, 0x1a, 0x11, 0xb3, 0xf6, 0x77, 0xe6, 0x8d, 0x1d, 0x69, 0xa5, 0x53, 0xad, 0xe3,
+	0x0d, 0xe8, 0x9c, 0x8c, 0x7e, 0x80, 0x0d, 0x6b, 0x06, 0x67, 0x59, 0x44, 0x03, 0xed, 0xd7, 0x0d,
+	0x65, 0xea, 0xa0, 0xd6, 0xd4, 0xe1, 0x4c, 0xcf, 0x43, 0xb4, 0x82, 0xa1, 0x1f, 0x61, 0x0b, 0x07,
+	0x41, 0x9a, 0x27, 0xc2, 0x0f, 0xd2, 0x64, 0x44, 0xc7, 0x39, 0xd3, 0x46, 0x57, 0x94, 0x51, 0x77,
+	0xde, 0xe8, 0xa1, 0x56, 0x7d, 0x51, 0xd6, 0xf4, 0x36, 0x71, 0x0d, 0x8a, 0xde, 0x00, 0xe2, 0xc1,
+	0x84, 0x84, 0x79, 0x44, 0xfc, 0x94, 0xfb, 0x79, 0x16, 0x62, 0x41, 0x54, 0x30, 0xf4, 0x9e, 0xdd,
+	0x9d, 0xb7, 0x7a, 0x66, 0xf4, 0x4e, 0xce, 0xce, 0x95, 0x96, 0xb7, 0x66, 0x7b, 0x9e, 0x70, 0x8d,
+	0xa0, 0x3f, 0xc1, 0xad, 0xaa, 0x35, 0x9f, 0x07, 0x38, 0x71, 0xda, 0x75, 0x8e, 0x2e, 0x9a, 0x3c,
+	0x0b, 0x70, 0xe2, 0x6d, 0x2e, 0x9a, 0x95, 0x28, 0xfa, 0x16, 0x06, 0x8c, 0xc4, 0xe9, 0x25, 0x29,
+	0xb6, 0xa6, 0xa3, 0x2c, 0xde, 0x9e, 0xb7, 0xe8, 0x29, 0x1d, 0xbb, 0x33, 0x7d, 0x56, 0x16, 0xd1,
+	0x97, 0xf2, 0x88, 0x44, 0x44, 0x10, 0x3f, 0xe7, 0x84, 0x39, 0x5d, 0x65, 0xc0, 0x59, 0x0c, 0x14,
+	0xa9, 0x70, 0xce, 0x09, 0x93, 0x87, 0xc7, 0x7e, 0xa3, 0x67, 0xd0, 0xe1, 0x44, 0x08, 0x9a, 0x8c,
+	0xb9, 0x03, 0xaa, 0xdf, 0xf6, 0xc2, 0x54, 0x4c, 0xab, 0x57, 0xe8, 0xa1, 0xdf, 0xc1, 0x2a, 0x61,
+	0x98, 0x13, 0x5f, 0x47, 0x9a, 0xd3, 0x53, 0xfd, 0x76, 0xe6, 0xfb, 0xbd, 0x92, 0x1a, 0x3a, 0x3a,
+	0xbd, 0x1e, 0x99, 0x09, 0xda, 0x59, 0xf9, 0xe5, 0x47, 0x69, 0x70, 0xe1, 0xac, 0xd6, 0x3b, 0x2b,
+	0x15, 0xde, 0xa4, 0xc1, 0x85, 0x3d, 0xe9, 0xf2, 0x1b, 0xfd, 0x15, 0xee, 0x94, 0xe2, 0xf8, 0x92,
+	0x72, 0x9a, 0x26, 0x34, 0x19, 0x17, 0x2b, 0xd7, 0x57, 0xb6, 0x1e, 0x2e, 0x0b, 0xea, 0xa2, 0x83,
+	0x5d, 0xc6, 0x5d, 0xba, 0xb4, 0x0d, 0x8d, 0xe1, 0xf6, 0x6c, 0x5f, 0xaa, 0x43, 0x0d, 0xd4, 0x50,
+	0x0f, 0x96, 0x6c, 0x52, 0x65, 0xa4, 0x1d, 0xb6, 0xac, 0x09, 0x85, 0x60, 0xdd, 0x20, 0x61, 0xf9,
+	0x5c, 0xf9, 0x11, 0xe5, 0xc2, 0x19, 0xaa, 0x71, 0x3e, 0xa9, 0x9d, 0x12, 0x09, 0x4b, 0x47, 0xe9,
+	0x0d, 0xe5, 0xc2, 0x73, 0xe8, 0x92, 0x16, 0x19, 0x66, 0x41, 0x44, 0x30, 0xf3, 0x33, 0xcc, 0x79,
+	0x90, 0x86, 0xc4, 0x59, 0xab, 0x0b, 0xb3, 0x17, 0x52, 0xe7, 0xd4, 0xa8, 0x78, 0xfd, 0xa0, 0x2c,
+	0xa2, 0xef, 0x61, 0xdd, 0x66, 0xae, 0x98, 0x32, 0x96, 0x32, 0x9a, 0x8c, 0x9d, 0xf5, 0xba, 0x23,
+	0xe5, 0x69, 0xb5, 0x63, 0xab, 0xe5, 0xad, 0xb1, 0x05, 0x04, 0xbd, 0x86, 0x35, 0x92, 0xe0, 0x0f,
+	0x91, 0x0c, 0x03, 0x69, 0x50, 0xba, 0x84, 0xea, 0x92, 0xd2, 0x2b, 0xa5, 0xf5, 0x26, 0xe5, 0xe2,
+	0x58, 0xfa, 0x34, 0x20, 0x73, 0x32, 0x3a, 0x87, 0x2d, 0xb9, 0x68, 0x53, 0x9f, 0x91, 0x90, 0xc4,
+	0x99, 0x5a, 0x39, 0x35, 0xbf, 0x0d, 0x65, 0xec, 0xde, 0x42, 0x06, 0x91, 0xaa, 0x5e, 0xa1, 0xf9,
+	0x42, 0x5a, 0xdc, 0xc0, 0x55, 0x10, 0x7d, 0x0d, 0x7d, 0x1b, 0x6a, 0x31, 0x09, 0x29, 0x76, 0x36,
+	0x95, 0xb9, 0xdd, 0xda, 0x8d, 0x38, 0x96, 0x1a, 0xde, 0x2a, 0x2d, 0x49, 0xf2, 0x90, 0x98, 0xf8,
+	0xd1, 0xfd, 0xb7, 0xea, 0x0e, 0x89, 0x0e, 0x18, 0xdd, 0xbd, 0xc7, 0x66, 0x02, 0xfa, 0x09, 0x9c,
+	0x18, 0x27, 0x78, 0x5c, 0x17, 0x12, 0xdb, 0xca, 0xd2, 0x2f, 0xe6, 0x2d, 0x1d, 0x6b, 0xed, 0xc5,
+	0x80, 0xd8, 0x8e, 0x6b, 0x71, 0x79, 0xc3, 0x18, 0xef, 0xca, 0x99, 0xfc, 0x56, 0xdd, 0x0d, 0xa3,
+	0x7d, 0x2c, 0x27, 0xf2, 0x75, 0xb6, 0x08, 0xa1, 0x23, 0x58, 0xa7, 0xc9, 0x25, 0x15, 0xc4, 0x17,
+	0xa9, 0x3c, 0x23, 0x63, 0x86, 0x63, 0xc7, 0x51, 0xe6, 0xf6, 0x16, 0x97, 0x4c, 0xaa, 0xbd, 0x4b,
+	0x4f, 0xb5, 0x92, 0x37, 0xa4, 0xf3, 0x80, 0xbc, 0x12, 0x2e, 0x71, 0x44, 0x55, 0x86, 0x2d, 0x39,
+	0xc7, 0x9d, 0x9d, 0xba, 0x4c, 0xfb, 0xde, 0xa8, 0x96, 0x9c, 0xe1, 0xde, 0xe6, 0x65, 0x0d, 0x8a,
+	0x02, 0xd8, 0x29, 0xaf, 0xe5, 0xfc, 0x7d, 0xb3, 0x5b, 0x77, 0xce, 0x4a, 0xdd, 0xe7, 0xef, 0x1c,
+	0x07, 0x2f, 0x69, 0x41, 0x53, 0xb8, 0x57, 0xb7, 0x71, 0xf3, 0x83, 0xdd, 0x56, 0x83, 0x3d, 0xbe,
+	0x6a, 0x07, 0xe7, 0xc7, 0xdc, 0x8f, 0x3f, 0xae, 0x80, 0x32, 0xb8, 0x5b, 0x37, 0x34, 0x16, 0x82,
+	0xd1, 0x0f, 0xb9, 0x20, 0xdc, 0xb9, 0xa3, 0xc6, 0x7d, 0x74, 0xd5, 0xb8, 0x87, 0x45, 0x0f, 0xef,
+	0x4e, 0xfc, 0x91, 0x56, 0x99, 0x8f, 0xeb, 0x46, 0x1c, 0x11, 0x12, 0x7e, 0xc0, 0xc1, 0x85, 0xb3,
+	0x57, 0x97, 0x8f, 0xab, 0xe3, 0xbd, 0x36, 0xfa, 0xde, 0x6e, 0xbc, 0xb4, 0xcd, 0x7d, 0x09, 0x6b,
+	0x8b, 0xb7, 0x2a, 0xfa, 0x35, 0xb4, 0xf5, 0x55, 0xcc, 0x9d, 0xc6, 0x41, 0xab, 0x7a, 0x77, 0x15,
+	0x37, 0xba, 0x55, 0x73, 0x3d, 0xe8, 0x14, 0xbd, 0xf7, 0xa1, 0x97, 0xb1, 0x34, 0xcc, 0x03, 0xe1,
+	0x5f, 0x90, 0xa9, 0xe1, 0x61, 0x60, 0xa0, 0xef, 0xc9, 0x14, 0xfd, 0x12, 0x06, 0x05, 0xdf, 0x09,
+	0x0a, 0x0a, 0xd6, 0xf5, 0x6c, 0x66, 0x38, 0x54, 0xa0, 0xfb, 0x19, 0x6c, 0xd6, 0xdd, 0xf7, 0x92,
+	0x69, 0x8e, 0x52, 0x16, 0x68, 0x86, 0xd7, 0xf1, 0xb4, 0xe0, 0xfe, 0xb3, 0x09, 0x9b, 0x87, 0xf5,
+	0x8c, 0xe5, 0x3e, 0xbf, 0xa0, 0x99, 0x9f, 0x31, 0x1a, 0x63, 0x36, 0xf5, 0x39, 0x11, 0x79, 0xe6,
+	0x17, 0xec, 0x88, 0x11, 0x1d, 0x3b, 0xda, 0xd8, 0xbe, 0x54, 0x3d, 0xd5, 0x9a, 0x67, 0x52, 0xd1,
+	0x9a, 0x34, 0x6a, 0xe8, 0x3d, 0x7c, 0xca, 0x89, 0x58, 0x62, 0x0c, 0x73, 0x9f, 0x91, 0x71, 0x1e,
+	0x61, 0xa6, 0x09, 0x43, 0x53, 0xd9, 0xbc, 0xcf, 0x89, 0xa8, 0x31, 0x79, 0xc8, 0x3d, 0xad, 0xab,
+	0xf8, 0x82, 0x0f, 0x3b, 0x38, 0x17, 0xa9, 0x35, 0x18, 0xc6, 0x34, 0xb1, 0x66, 0xb9, 0xd3, 0x3a,
+	0x68, 0x55, 0x33, 0xd3, 0x61, 0x2e, 0x52, 0x6d, 0x4f, 0x2a, 0x1b, 0xa3, 0xdc, 0xdb, 0xc6, 0xb5,
+	0xb8, 0xfb, 0x8f, 0x06, 0x6c, 0xd7, 0x77, 0x41, 0x7b, 0x00, 0x7c, 0x92, 0x32, 0xe1, 0x27, 0x38,
+	0xb6, 0xbc, 0xb9, 0xab, 0x90, 0xb7, 0x38, 0x26, 0xe8, 0x36, 0x74, 0x47, 0x79, 0x14, 0xe9, 0x56,
+	0xbd, 0x53, 0x1d, 0x09, 0xa8, 0xc6, 0xfb, 0xd0, 0x97, 0x37, 0xdf, 0xdf, 0x52, 0x16, 0xfa, 0x13,
+	0xcc, 0x27, 0x8a, 0x00, 0xaf, 0x7a, 0xab, 0x16, 0xfc, 0x0e, 0xf3, 0x09, 0xda, 0x86, 0x9b, 0x13,
+	0x1a, 0x86, 0x44, 0x73, 0xda, 0x8e, 0x67, 0x24, 0xf7, 0x31, 0xac, 0x57, 0x78, 0x36, 0x72, 0xa0,
+	0xfd, 0x73, 0x4e, 0x18, 0x35, 0xc1, 0xd7, 0xf5, 0xac, 0xe8, 0x3e, 0x82, 0xc1, 0x3c, 0x93, 0x96,
+	0xba, 0xb6, 0xe8, 0x69, 0xa8, 0x71, 0xad, 0xe8, 0x3e, 0x85, 0xfe, 0x1c, 0xb5, 0x43, 0x77, 0x01,
+	0x68, 0x48, 0x12, 0x41, 0x47, 0x94, 0x30, 0x1b, 0x94, 0x33, 0xc4, 0x3d, 0x01, 0x98, 0x51, 0x39,
+	0x59, 0xe9, 0xc8, 0x1d, 0x2c, 0x2d, 0x48, 0x21, 0xcb, 0xf0, 0x55, 0x21, 0xe7, 0x2b, 0xba, 0x67,
+	0xc3, 0xb7, 0xe3, 0xf5, 0x15, 0xfa, 0xd2, 0x80, 0xee, 0x7f, 0x9b, 0x80, 0xaa, 0x6c, 0x1d, 0x7d,
+	0x02, 0x43, 0x2a, 0xf2, 0x84, 0x70, 0x9f, 0x8b, 0x94, 0x11, 0xdf, 0x54, 0x42, 0x2d, 0xaf, 0xaf,
+	0xe1, 0x33, 0x89, 0x1e, 0x85, 0x0b, 0xfe, 0x36, 0x17, 0xfd, 0x95, 0xe5, 0x4e, 0x8c, 0x13, 0x3a,
+	0x92, 0xac, 0x21, 0x67, 0x91, 0x5a, 0xf7, 0xae, 0xd7, 0xb3, 0xd8, 0x39, 0x8b, 0xd0, 0xa7, 0xb0,
+	0xa6, 0x0f, 0x7e, 0x4c, 0x12, 0xe1, 0x8f, 0x22, 0x3c, 0xe6, 0x6a, 0x03, 0x5a, 0xde, 0x70, 0x86,
+	0xbf, 0x96, 0xb0, 0x3c, 0xb3, 0x49, 0x2a, 0x7c, 0x0d, 0x87, 0xaa, 0x4a, 0xe8, 0x78, 0x90, 0xa4,
+	0xc2, 0x64, 0x15, 0xf4, 0x1c, 0x6e, 0x05, 0x13, 0x9c, 0x8c, 0x89, 0x5f, 0x32, 0xc9, 0x85, 0x25,
+	0xff, 0x5d, 0x6f, 0x4b, 0x37, 0x1f, 0x17, 0xad, 0x67, 0xb2, 0x11, 0x1d, 0x42, 0x3b, 0xcd, 0xf4,
+	0x3d, 0xd3, 0xae, 0xa3, 0x76, 0xd5, 0x15, 0x3a, 0xd1, 0xea, 0x9e, 0xed, 0xe7, 0x12, 0xd8, 0x59,
+	0xaa, 0xb5, 0xe8, 0x78, 0xa3, 0xe2, 0xf8, 0x03, 0x18, 0x66, 0x39, 0x0b, 0x26, 0x92, 0x57, 0xc7,
+	0x44, 0x4c, 0xd2, 0xd0, 0x94, 0xcd, 0x03, 0x0b, 0x1f, 0x2b, 0xd4, 0x3d, 0x81, 0xdd, 0xe5, 0x94,
+	0x16, 0x7d, 0x0e, 0x9b, 0xb5, 0x7c, 0x55, 0x87, 0xdd, 0x46, 0x56, 0xed, 0xe2, 0x3e, 0x85, 0x9d,
+	0xa5, 0xc4, 0x55, 0x96, 0xf0, 0xa5, 0x2a, 0x58, 0x7d, 0xbb, 0x13, 0x70, 0x96, 0x31, 0x50, 0x74,
+	0x00, 0xbd, 0xd9, 0xe6, 0xdb, 0x93, 0x51, 0x86, 0xd0, 0x23, 0x58, 0x2f, 0x5d, 0x1a, 0xdc, 0x4f,
+	0x93, 0x68, 0x6a, 0x22, 0x73, 0x38, 0xcb, 0xff, 0xfc, 0x24, 0x89, 0xa6, 0xee, 0x33, 0xe8, 0xcf,
+	0x31, 0x52, 0x19, 0x4d, 0x79, 0x22, 0xeb, 0x06, 0x5f, 0xa4, 0x17, 0x24, 0x31, 0xd3, 0xea, 0x69,
+	0xec, 0x9d, 0x84, 0xdc, 0x7f, 0x35, 0x60, 0x6d, 0x91, 0x7f, 0xca, 0x10, 0x0b, 0x09, 0x17, 0x34,
+	0xd1, 0x37, 0x54, 0xe9, 0xbc, 0x0c, 0x4b, 0xb8, 0xca, 0x14, 0xcf, 0x60, 0xab, 0xac, 0x6a, 0x0b,
+	0xf1, 0xd0, 0xc4, 0xf6, 0x46, 0xa9, 0xd1, 0x24, 0x85, 0x50, 0xa6, 0x1e, 0x59, 0x0c, 0xfa, 0xea,
+	0xb5, 0x43, 0x47, 0x78, 0x47, 0x02, 0xef, 0xe4, 0x8b, 0x87, 0x7c, 0x8d, 0x30, 0x59, 0xc6, 0xbc,
+	0x5e, 0x14, 0xb2, 0x4b, 0x61, 0x30, 0xcf, 0x6f, 0x65, 0xaa, 0x88, 0x09, 0xe7, 0x78, 0x6c, 0x1d,
+	0xb4, 0xa2, 0x9c, 0x7b, 0x36, 0x49, 0x13, 0xe2, 0x27, 0x79, 0xfc, 0xa1, 0x38, 0x6b, 0x3d, 0x85,
+	0xbd, 0x55, 0x90, 0x1c, 0x6a, 0x94, 0xa6, 0x22, 0x49, 0x45, 0xe1, 0x86, 0x95, 0xdd, 0x9f, 0x60,
+	0xa3, 0x86, 0xfd, 0x5e, 0x95, 0x6f, 0x64, 0x5c, 0x2e, 0x32, 0x6b, 0x3d, 0xf0, 0x80, 0xcd, 0x19,
+	0x72, 0x19, 0xac, 0x96, 0xe9, 0xf0, 0xb5, 0x13, 0xc8, 0x6d, 0xe8, 0x2a, 0x86, 0xac, 0xb2, 0x83,
+	0x49, 0xdb, 0x0a, 0x90, 0xa9, 0x61, 0x0f, 0x40, 0x37, 0xaa, 0xa7, 0x12, 0x3d, 0x25, 0xad, 0x2e,
+	0x1f, 0x4a, 0xdc, 0x29, 0xf4, 0x4a, 0x14, 0x7a, 0x41, 0xbb, 0xb1, 0xa0, 0x5d, 0xe7, 0x51, 0xb3,
+	0xce, 0x23, 0x79, 0x57, 0x10, 0xc6, 0x29, 0x17, 0x32, 0x79, 0xd0, 0xd0, 0x8c, 0xbb, 0x3a, 0x03,
+	0x8f, 0x42, 0xf7, 0x18, 0x7a, 0xa5, 0x12, 0x57, 0x3e, 0x42, 0x65, 0x34, 0x31, 0x63, 0xca, 0x4f,
+	0xf4, 0x19, 0xa0, 0x8c, 0x11, 0x4e, 0xd8, 0x25, 0xf1, 0x43, 0x2c, 0xb0, 0x9f, 0x45, 0xd8, 0xa6,
+	0xe0, 0x35, 0xdb, 0xf2, 0x12, 0x0b, 0x7c, 0x1a, 0xe1, 0xc4, 0xfd, 0x33, 0x80, 0xb6, 0xa4, 0x0a,
+	0xdd, 0xaa, 0xb5, 0x52, 0x58, 0x34, 0x3f, 0x1e, 0x16, 0xad, 0x4a, 0x58, 0xb8, 0x5f, 0xc1, 0x76,
+	0x7d, 0x7d, 0x70, 0xf5, 0x71, 0x75, 0xbf, 0x80, 0xf5, 0x4a, 0x05, 0x70, 0xe5, 0x25, 0xf5, 0x23,
+	0x0c, 0x17, 0x78, 0xbe, 0xdc, 0x1b, 0x53, 0x17, 0xf8, 0x45, 0x3a, 0xe9, 0x1a, 0xe4, 0x28, 0xd4,
+	0x5c, 0xeb, 0x92, 0x0a, 0x7d, 0xe8, 0x66, 0xa1, 0xd0, 0x9f, 0xa1, 0xe7, 0x2c, 0x72, 0x7f, 0x0b,
+	0x9b, 0x75, 0x8c, 0xff, 0x1a, 0xf3, 0xf8, 0x7b, 0x03, 0x9c, 0xa5, 0xd4, 0xf9, 0xaa, 0x43, 0xf0,
+	0x83, 0x7c, 0x01, 0x2d, 0x75, 0x70, 0x9a, 0x8a, 0xe9, 0xfc, 0xea, 0x7a, 0xe5, 0xc2, 0x7b, 0x1c,
+	0xe5, 0xb2, 0x98, 0x2e, 0x63, 0xee, 0x1f, 0x61, 0xef, 0xa3, 0xfa, 0x32, 0x06, 0x66, 0xb4, 0x54,
+	0x7e, 0x4a, 0x42, 0x79, 0x29, 0x9b, 0xcc, 0xd2, 0x68, 0xc1, 0x7d, 0x01, 0xfb, 0x57, 0x94, 0x0e,
+	0xd7, 0x58, 0x9d, 0x6f, 0xe0, 0xce, 0xc7, 0xea, 0x80, 0x6b, 0x58, 0x18, 0xc3, 0xee, 0x72, 0x66,
+	0x7f, 0x8d, 0x6b, 0xe1, 0x01, 0x0c, 0xcd, 0x1b, 0x56, 0x51, 0x3e, 0xe8, 0xb3, 0x32, 0xd0, 0x70,
+	0x51, 0x08, 0xfc, 0x1e, 0x3a, 0xf6, 0x4d, 0x0a, 0x7d, 0x5e, 0x7a, 0xbd, 0xd2, 0x15, 0xc0, 0x56,
+	0xed, 0xeb, 0xd5, 0xec, 0xf1, 0xca, 0xfd, 0x5f, 0x13, 0xda, 0x06, 0x95, 0x97, 0x1b, 0x15, 0x24,
+	0xb6, 0x97, 0x9b, 0xfc, 0x46, 0xdf, 0x14, 0xcf, 0x53, 0x05, 0x8f, 0x5c, 0xf2, 0xe8, 0x2a, 0x6f,
+	0x0b, 0x6b, 0x1f, 0xc2, 0x02, 0x42, 0x5f, 0x42, 0x67, 0x92, 0x72, 0xa1, 0xba, 0xb7, 0xea, 0x4a,
+	0xe0, 0xef, 0x4c, 0x6b, 0xe1, 0x9c, 0x55, 0x47, 0xcf, 0xa1, 0xad, 0x9f, 0x37, 0x42, 0xe7, 0xc6,
+	0xf2, 0xb7, 0x90, 0xd0, 0x76, 0xb4, 0xca, 0xe8, 0xeb, 0xb9, 0xf8, 0x5d, 0xa9, 0xf3, 0xf9, 0xa8,
+	0x68, 0x2f, 0x7c, 0x2e, 0x05, 0xf8, 0x1f, 0x00, 0x4a, 0x75, 0xe2, 0xcd, 0x83, 0x56, 0xf5, 0x4d,
+	0xc7, 0x74, 0x2b, 0x82, 0xc2, 0x2b, 0xf5, 0x90, 0x94, 0x77, 0xde, 0x37, 0x99, 0xb0, 0xec, 0x54,
+	0x34, 0xd9, 0xb1, 0xa2, 0xcc, 0x28, 0x15, 0x67, 0xae, 0xcc, 0x28, 0x5f, 0xc1, 0xda, 0xa2, 0x03,
+	0xd7, 0x3e, 0x21, 0xbf, 0xb1, 0xf4, 0xbd, 0xb4, 0x63, 0xa5, 0xdf, 0x0a, 0x25, 0x32, 0x50, 0xda,
+	0x46, 0xf7, 0x31, 0x0c, 0x17, 0x36, 0x4a, 0x5e, 0xaf, 0xc5, 0xce, 0x1a, 0xb6, 0x6d, 0x65, 0x59,
+	0xb7, 0xac, 0x7c, 0x8b, 0x45, 0x30, 0xa9, 0xfc, 0x05, 0xd9, 0x03, 0x50, 0xd5, 0x9b, 0x24, 0x3c,
+	0xc2, 0xdc, 0x38, 0x5d, 0x83, 0x1c, 0x8a, 0xca, 0xff, 0x80, 0x56, 0xf5, 0x7f, 0xc0, 0x73, 0xe8,
+	0x98, 0xad, 0x90, 0xc4, 0xb8, 0x55, 0x7d, 0x87, 0x52, 0x03, 0xdb, 0xbf, 0x0e, 0x85, 0xae, 0xfb,
+	0x0a, 0x56, 0xcb, 0x2d, 0x8a, 0xcc, 0x85, 0x25, 0x32, 0x17, 0xd2, 0xea, 0xef, 0x8e, 0x66, 0xe5,
+	0x77, 0xc7, 0x87, 0x9b, 0x6a, 0x90, 0x2f, 0xfe, 0x1f, 0x00, 0x00, 0xff, 0xff, 0xb2, 0xc9, 0x0a,
+	0x4c, 0x06, 0x1a, 0x00, 0x00,
 }
@@ -12,6 +12,7 @@ import (
 
 type Service interface {
 	NewCommand(context.Context, *mdm.CommandRequest) (*mdm.Payload, error)
+	NewBatch(context.Context, *BatchRequest) (*Batch, error)
 }
 
 // Middleware describes a service (as opposed to endpoint) middleware.
@@ -39,6 +40,17 @@ func (mw serviceLoggingMiddleware) NewCommand(ctx context.Context, req *mdm.Comm
 	return mw.next.NewCommand(ctx, req)
 }
 
+func (mw serviceLoggingMiddleware) NewBatch(ctx context.Context, req *BatchRequest) (b *Batch, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log(
+			"method", "NewBatch",
+			"error", err,
+			"took", time.Since(begin),
+		)
+	}(time.Now())
+	return mw.next.NewBatch(ctx, req)
+}
+
 type serviceLoggingMiddleware struct {
 	logger log.Logger
 	next   Service
@@ -65,3 +77,11 @@ func (mw serviceInstrumentingMiddleware) NewCommand(ctx context.Context, req *md
 	mw.payloads.Add(1)
 	return p, err
 }
+
+func (mw serviceInstrumentingMiddleware) NewBatch(ctx context.Context, req *BatchRequest) (*Batch, error) {
+	b, err := mw.next.NewBatch(ctx, req)
+	if b != nil {
+		mw.payloads.Add(float64(len(b.Commands)))
+	}
+	return b, err
+}
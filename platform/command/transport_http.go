@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/pkg/errors"
 
+	"github.com/go-kit/kit/endpoint"
 	httptransport "github.com/go-kit/kit/transport/http"
 )
 
@@ -18,7 +20,7 @@ type HTTPHandlers struct {
 func MakeHTTPHandlers(ctx context.Context, endpoints Endpoints, opts ...httptransport.ServerOption) HTTPHandlers {
 	h := HTTPHandlers{
 		NewCommandHandler: httptransport.NewServer(
-			endpoints.NewCommandEndpoint,
+			commandOrBatch(endpoints),
 			decodeRequest,
 			encodeResponse,
 			opts...,
@@ -47,9 +49,37 @@ func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	})
 }
 
+// commandOrBatch dispatches a decoded request to the single command or the
+// batch endpoint.
+func commandOrBatch(endpoints Endpoints) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		if _, ok := request.(newBatchRequest); ok {
+			if endpoints.NewBatchEndpoint == nil {
+				return newBatchResponse{Err: errors.New("batch requests are not supported")}, nil
+			}
+			return endpoints.NewBatchEndpoint(ctx, request)
+		}
+		return endpoints.NewCommandEndpoint(ctx, request)
+	}
+}
+
+// decodeRequest decodes a single command request, or a batch request
+// if the body targets devices with udids, serials or a selector.
 func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, 1000000))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding command request")
+	}
+	var batch newBatchRequest
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return nil, errors.Wrap(err, "decoding command request")
+	}
+	if len(batch.UDIDs) > 0 || len(batch.Serials) > 0 || batch.Selector != nil {
+		err := json.Unmarshal(body, &batch.Request)
+		return batch, errors.Wrap(err, "decoding batch command request")
+	}
 	var req newCommandRequest
-	err := json.NewDecoder(io.LimitReader(r.Body, 1000000)).Decode(&req)
+	err = json.Unmarshal(body, &req)
 	return req, errors.Wrap(err, "decoding command request")
 }
 
@@ -3,8 +3,14 @@ package command
 import (
 	"context"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/go-kit/kit/log"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/platform/device"
 )
 
 func TestDecodeRequest(t *testing.T) {
@@ -33,3 +39,112 @@ func TestDecodeRequest(t *testing.T) {
 	}
 
 }
+
+func TestDecodeBatchRequest(t *testing.T) {
+	requestData := `
+{
+    "request_type": "DeviceLock",
+    "serials": ["C02ABCDEFGH1"],
+    "selector": {"os_version": "11", "enrolled": true},
+    "pin": "123456",
+    "priority": 10
+}
+`
+	req := httptest.NewRequest("POST", "https://mdm.acme.co/v1/commands", strings.NewReader(requestData))
+	request, err := decodeRequest(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := request.(newBatchRequest)
+
+	if have, want := decoded.Request.RequestType, "DeviceLock"; have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	if have, want := decoded.Request.DeviceLock.PIN, "123456"; have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	if have, want := decoded.Request.Priority, 10; have != want {
+		t.Errorf("have %d, want %d", have, want)
+	}
+	if len(decoded.Serials) != 1 || decoded.Selector == nil || decoded.Selector.OSVersion != "11" {
+		t.Errorf("expected serial and selector targets, got %+v", decoded.BatchRequest)
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	svc := new(recordingService)
+	endpoints := Endpoints{
+		NewCommandEndpoint: MakeNewCommandEndpoint(svc),
+		NewBatchEndpoint:   MakeNewBatchEndpoint(svc),
+	}
+	handlers := MakeHTTPHandlers(context.Background(), endpoints)
+	srv := httptest.NewServer(handlers.NewCommandHandler)
+	defer srv.Close()
+
+	client, err := NewHTTPClient(srv.URL, "secret", log.NewNopLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	request := &mdm.CommandRequest{
+		UDID:     "aDevice",
+		Priority: 5,
+		Command: mdm.Command{
+			RequestType: "RemoveApplication",
+			RemoveApplication: mdm.RemoveApplication{
+				Identifier: "com.example.app",
+			},
+		},
+	}
+	payload, err := client.NewCommand(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := payload.CommandUUID, "aUUID"; have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	if !reflect.DeepEqual(svc.command, request) {
+		t.Errorf("have request %+v, want %+v", svc.command, request)
+	}
+
+	batchRequest := &BatchRequest{
+		Serials:  []string{"aSerial"},
+		Selector: &device.Selector{Model: "iPad"},
+		Request: mdm.CommandRequest{
+			Command: mdm.Command{
+				RequestType: "DeviceLock",
+				DeviceLock:  mdm.DeviceLock{PIN: "123456"},
+			},
+		},
+	}
+	batch, err := client.NewBatch(ctx, batchRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := batch.ID, "aBatch"; have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	if !reflect.DeepEqual(svc.batch, batchRequest) {
+		t.Errorf("have batch request %+v, want %+v", svc.batch, batchRequest)
+	}
+
+	if _, err := client.NewCommand(ctx, &mdm.CommandRequest{Command: mdm.Command{RequestType: "RestartDevice"}}); err == nil {
+		t.Error("expected error for request without UDID")
+	}
+}
+
+type recordingService struct {
+	command *mdm.CommandRequest
+	batch   *BatchRequest
+}
+
+func (s *recordingService) NewCommand(_ context.Context, req *mdm.CommandRequest) (*mdm.Payload, error) {
+	s.command = req
+	return &mdm.Payload{CommandUUID: "aUUID", Command: &req.Command}, nil
+}
+
+func (s *recordingService) NewBatch(_ context.Context, req *BatchRequest) (*Batch, error) {
+	s.batch = req
+	return &Batch{ID: "aBatch", RequestType: req.Request.RequestType}, nil
+}
@@ -17,6 +17,9 @@ import (
 
 const (
 	ConfigBucket = "mdm.ServerConfig"
+
+	// pushProviderKey is the key of the config.PushProvider in the ConfigBucket.
+	pushProviderKey = "push-provider"
 )
 
 // DB stores server configuration in BoltDB
@@ -113,15 +116,114 @@ func (db *DB) PushCertificate() (*tls.Certificate, error) {
 	return &cert, nil
 }
 
+// PushTopic returns the topic of the token push provider, or the topic of
+// the push certificate.
 func (db *DB) PushTopic() (string, error) {
-	cert, err := db.PushCertificate()
+	var topic string
+	err := db.View(func(tx *bolt.Tx) error {
+		p, err := pushProvider(tx)
+		if err != nil {
+			return err
+		}
+		topic, err = pushTopic(tx, p)
+		return err
+	})
+	return topic, err
+}
+
+// pushTopic returns the topic of the token push provider p, or the topic of
+// the push certificate.
+func pushTopic(tx *bolt.Tx, p *config.PushProvider) (string, error) {
+	if p.Provider == config.PushProviderToken {
+		return p.Topic, nil
+	}
+	data := tx.Bucket([]byte(ConfigBucket)).Get([]byte("config"))
+	if data == nil {
+		return "", &notFound{"ServerConfig", "no config found in boltdb"}
+	}
+	var conf config.ServerConfig
+	if err := config.UnmarshalServerConfig(data, &conf); err != nil {
+		return "", errors.Wrap(err, "get server config for push topic")
+	}
+	block, _ := pem.Decode(conf.PushCertificate)
+	if block == nil {
+		return "", errors.New("decode push certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
-		return "", errors.Wrap(err, "get push certificate for topic")
+		return "", errors.Wrap(err, "parse push certificate from server config")
 	}
-	topic, err := crypto.TopicFromCert(cert.Leaf)
+	topic, err := crypto.TopicFromCert(cert)
 	return topic, errors.Wrap(err, "get topic from push certificate")
 }
 
+// SavePushProvider selects the APNs authentication and gateway. check is
+// called with the current push topic and the push topic of p in the same
+// transaction. A topic which is not known, because no push certificate was
+// saved, is empty.
+func (db *DB) SavePushProvider(p *config.PushProvider, check func(current, topic string) error) error {
+	data, err := config.MarshalPushProvider(p)
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if check != nil {
+			stored, err := pushProvider(tx)
+			if err != nil {
+				return err
+			}
+			current, err := knownTopic(tx, stored)
+			if err != nil {
+				return errors.Wrap(err, "get current push topic")
+			}
+			topic, err := knownTopic(tx, p)
+			if err != nil {
+				return errors.Wrap(err, "get push topic of provider")
+			}
+			if err := check(current, topic); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket([]byte(ConfigBucket)).Put([]byte(pushProviderKey), data)
+	})
+	if err != nil {
+		return errors.Wrap(err, "save push provider in bucket")
+	}
+	return db.Publisher.Publish(context.TODO(), config.ConfigTopic, []byte("updated"))
+}
+
+// knownTopic is pushTopic, which returns an empty topic if no push
+// certificate was saved.
+func knownTopic(tx *bolt.Tx, p *config.PushProvider) (string, error) {
+	topic, err := pushTopic(tx, p)
+	if _, ok := err.(*notFound); ok {
+		return "", nil
+	}
+	return topic, err
+}
+
+// PushProvider returns the saved push provider. The certificate provider is
+// used if none was saved.
+func (db *DB) PushProvider() (*config.PushProvider, error) {
+	var p *config.PushProvider
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		p, err = pushProvider(tx)
+		return err
+	})
+	return p, err
+}
+
+func pushProvider(tx *bolt.Tx) (*config.PushProvider, error) {
+	p := config.PushProvider{Provider: config.PushProviderCertificate}
+	data := tx.Bucket([]byte(ConfigBucket)).Get([]byte(pushProviderKey))
+	if data == nil {
+		return &p, nil
+	}
+	err := config.UnmarshalPushProvider(data, &p)
+	return &p, errors.Wrap(err, "get push provider from bolt")
+}
+
 type notFound struct {
 	ResourceType string
 	Message      string
@@ -130,3 +232,7 @@ type notFound struct {
 func (e *notFound) Error() string {
 	return fmt.Sprintf("not found: %s %s", e.ResourceType, e.Message)
 }
+
+func (e *notFound) NotFound() bool {
+	return true
+}
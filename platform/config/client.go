@@ -26,6 +26,17 @@ func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransp
 		).Endpoint()
 	}
 
+	var savePushProviderEndpoint endpoint.Endpoint
+	{
+		savePushProviderEndpoint = httptransport.NewClient(
+			"PUT",
+			httputil.CopyURL(u, "/v1/config/push-provider"),
+			httputil.EncodeRequestWithToken(token, httptransport.EncodeJSONRequest),
+			decodeSavePushProviderResponse,
+			opts...,
+		).Endpoint()
+	}
+
 	var applyDEPTokensEndpoint endpoint.Endpoint
 	{
 		applyDEPTokensEndpoint = httptransport.NewClient(
@@ -48,9 +59,22 @@ func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransp
 		).Endpoint()
 	}
 
+	var getConfigEndpoint endpoint.Endpoint
+	{
+		getConfigEndpoint = httptransport.NewClient(
+			"GET",
+			httputil.CopyURL(u, "/v1/config"),
+			httputil.EncodeRequestWithToken(token, httptransport.EncodeJSONRequest),
+			decodeGetConfigResponse,
+			opts...,
+		).Endpoint()
+	}
+
 	return Endpoints{
 		SavePushCertificateEndpoint: saveEndpoint,
+		SavePushProviderEndpoint:    savePushProviderEndpoint,
 		ApplyDEPTokensEndpoint:      applyDEPTokensEndpoint,
 		GetDEPTokensEndpoint:        getDEPTokensEndpoint,
+		GetConfigEndpoint:           getConfigEndpoint,
 	}, nil
 }
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/as/micromdm/platform/config/internal/configproto"
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+// PushCertificateExpiryTopic is published to when the push certificate is
+// about to expire, or has expired.
+const PushCertificateExpiryTopic = "mdm.PushCertificateExpiry"
+
+// DefaultExpiryThresholds are the times before the push certificate expires
+// at which an ExpiryMonitor created without thresholds warns.
+var DefaultExpiryThresholds = []time.Duration{
+	30 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+}
+
+// PushCertificateExpiry warns that the push certificate expires within
+// Threshold, or has Expired.
+type PushCertificateExpiry struct {
+	ID           string        `json:"id"`
+	Time         time.Time     `json:"time"`
+	Topic        string        `json:"topic"`
+	Subject      string        `json:"subject"`
+	SerialNumber string        `json:"serial_number"`
+	NotAfter     time.Time     `json:"not_after"`
+	Threshold    time.Duration `json:"threshold"`
+	Expired      bool          `json:"expired"`
+}
+
+func MarshalPushCertificateExpiry(e *PushCertificateExpiry) ([]byte, error) {
+	data, err := proto.Marshal(&configproto.PushCertificateExpiry{
+		Id:           e.ID,
+		Time:         e.Time.UnixNano(),
+		Topic:        e.Topic,
+		Subject:      e.Subject,
+		SerialNumber: e.SerialNumber,
+		NotAfter:     e.NotAfter.UnixNano(),
+		Threshold:    int64(e.Threshold),
+		Expired:      e.Expired,
+	})
+	return data, errors.Wrap(err, "marshal push certificate expiry to proto")
+}
+
+func UnmarshalPushCertificateExpiry(data []byte, e *PushCertificateExpiry) error {
+	var pb configproto.PushCertificateExpiry
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal push certificate expiry from proto")
+	}
+	e.ID = pb.GetId()
+	e.Time = time.Unix(0, pb.GetTime()).UTC()
+	e.Topic = pb.GetTopic()
+	e.Subject = pb.GetSubject()
+	e.SerialNumber = pb.GetSerialNumber()
+	e.NotAfter = time.Unix(0, pb.GetNotAfter()).UTC()
+	e.Threshold = time.Duration(pb.GetThreshold())
+	e.Expired = pb.GetExpired()
+	return nil
+}
+
+// PushCertificateStore returns the saved push certificate.
+type PushCertificateStore interface {
+	PushCertificate() (*tls.Certificate, error)
+}
+
+// ExpiryMonitor publishes a PushCertificateExpiry event when the push
+// certificate crosses one of the thresholds before it expires, and once it
+// has expired. Every threshold is published once per certificate, so a
+// replaced certificate starts over. The state is not persisted, and after a
+// restart the last crossed threshold is published again.
+type ExpiryMonitor struct {
+	certs      PushCertificateStore
+	pub        pubsub.Publisher
+	thresholds []time.Duration
+	now        func() time.Time
+
+	mu sync.Mutex
+	// warned is the smallest threshold published for each certificate
+	// serial number. Expired certificates have a threshold of zero.
+	warned map[string]time.Duration
+}
+
+// NewExpiryMonitor creates an ExpiryMonitor which checks the certificate
+// now and every interval. DefaultExpiryThresholds are used if thresholds is
+// empty.
+func NewExpiryMonitor(certs PushCertificateStore, pub pubsub.Publisher, thresholds []time.Duration, interval time.Duration) *ExpiryMonitor {
+	if len(thresholds) == 0 {
+		thresholds = DefaultExpiryThresholds
+	}
+	sorted := make([]time.Duration, len(thresholds))
+	copy(sorted, thresholds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	m := &ExpiryMonitor{
+		certs:      certs,
+		pub:        pub,
+		thresholds: sorted,
+		now:        time.Now,
+		warned:     make(map[string]time.Duration),
+	}
+	go m.monitor(interval)
+	return m
+}
+
+func (m *ExpiryMonitor) monitor(interval time.Duration) {
+	if err := m.Check(context.Background()); err != nil {
+		fmt.Println(err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := m.Check(context.Background()); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// Check publishes a PushCertificateExpiry event if the push certificate
+// crossed a threshold since the last check. There is nothing to check
+// before a push certificate is saved.
+func (m *ExpiryMonitor) Check(ctx context.Context) error {
+	cert, err := m.certs.PushCertificate()
+	if err != nil || cert.Leaf == nil {
+		return nil
+	}
+	info := NewPushCertificateInfo(cert.Leaf)
+	now := m.now()
+	remaining := info.NotAfter.Sub(now)
+
+	threshold, crossed := m.threshold(remaining)
+	if !crossed {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if warned, ok := m.warned[info.SerialNumber]; ok && warned <= threshold {
+		return nil
+	}
+
+	event := PushCertificateExpiry{
+		ID:           uuid.NewV4().String(),
+		Time:         now.UTC(),
+		Topic:        info.Topic,
+		Subject:      info.Subject,
+		SerialNumber: info.SerialNumber,
+		NotAfter:     info.NotAfter.UTC(),
+		Threshold:    threshold,
+		Expired:      remaining <= 0,
+	}
+	data, err := MarshalPushCertificateExpiry(&event)
+	if err != nil {
+		return err
+	}
+	if err := m.pub.Publish(ctx, PushCertificateExpiryTopic, data); err != nil {
+		return errors.Wrapf(err, "publish %s", PushCertificateExpiryTopic)
+	}
+	m.warned[info.SerialNumber] = threshold
+
+	if event.Expired {
+		fmt.Printf("push certificate for topic %s expired at %s\n", info.Topic, info.NotAfter)
+	} else {
+		fmt.Printf("push certificate for topic %s expires at %s\n", info.Topic, info.NotAfter)
+	}
+	return nil
+}
+
+// threshold returns the smallest threshold the remaining validity is
+// within. Zero means the certificate has expired.
+func (m *ExpiryMonitor) threshold(remaining time.Duration) (time.Duration, bool) {
+	if remaining <= 0 {
+		return 0, true
+	}
+	for _, t := range m.thresholds {
+		if remaining <= t {
+			return t, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+var oidUserID = asn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 1}
+
+// newPushCert creates a self signed push certificate for the topic.
+func newPushCert(t *testing.T, serial int64, topic string, notAfter time.Time) (*x509.Certificate, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject: pkix.Name{
+			CommonName: "APSP:" + topic,
+			ExtraNames: []pkix.AttributeTypeAndValue{{Type: oidUserID, Value: topic}},
+		},
+		NotBefore: notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:  notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+type mockStore struct {
+	Store
+	cert     *x509.Certificate
+	saved    []byte
+	provider *PushProvider
+}
+
+func (s *mockStore) PushCertificate() (*tls.Certificate, error) {
+	if s.cert == nil {
+		return nil, errNotFound
+	}
+	return &tls.Certificate{Certificate: [][]byte{s.cert.Raw}, Leaf: s.cert}, nil
+}
+
+func (s *mockStore) PushTopic() (string, error) {
+	if s.cert == nil {
+		return "", errNotFound
+	}
+	return NewPushCertificateInfo(s.cert).Topic, nil
+}
+
+func (s *mockStore) SavePushCertificate(cert, key []byte) error {
+	s.saved = cert
+	return nil
+}
+
+func (s *mockStore) SavePushProvider(p *PushProvider, check func(current, topic string) error) error {
+	if check != nil {
+		current, _ := s.PushTopic()
+		if err := check(current, p.Topic); err != nil {
+			return err
+		}
+	}
+	s.provider = p
+	return nil
+}
+
+var errNotFound error = notFound{}
+
+type notFound struct{}
+
+func (notFound) Error() string  { return "not found" }
+func (notFound) NotFound() bool { return true }
+
+type recorder struct{ events []PushCertificateExpiry }
+
+func (r *recorder) Publish(_ context.Context, topic string, msg []byte) error {
+	if topic != PushCertificateExpiryTopic {
+		return nil
+	}
+	var ev PushCertificateExpiry
+	if err := UnmarshalPushCertificateExpiry(msg, &ev); err != nil {
+		return err
+	}
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestExpiryMonitor(t *testing.T) {
+	const day = 24 * time.Hour
+	now := time.Now()
+	first, _ := newPushCert(t, 1, "com.apple.mgmt.test", now.Add(10*day))
+	store := &mockStore{cert: first}
+	pub := &recorder{}
+	m := &ExpiryMonitor{
+		certs:      store,
+		pub:        pub,
+		thresholds: []time.Duration{day, 7 * day, 30 * day},
+		now:        func() time.Time { return now },
+		warned:     make(map[string]time.Duration),
+	}
+
+	check := func(after time.Duration, wantEvents int) {
+		t.Helper()
+		now = first.NotAfter.Add(-after)
+		if err := m.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if have := len(pub.events); have != wantEvents {
+			t.Fatalf("%s before expiry: have %d events, want %d", after, have, wantEvents)
+		}
+	}
+
+	check(40*day, 0)
+	check(10*day, 1)
+	check(9*day, 1) // the 30 day threshold is only published once.
+	check(6*day, 2)
+	check(-time.Hour, 3)
+	check(-2*time.Hour, 3)
+
+	last := pub.events[2]
+	if !last.Expired || last.Threshold != 0 || last.Topic != "com.apple.mgmt.test" || last.SerialNumber != "1" {
+		t.Errorf("unexpected expired event %+v", last)
+	}
+	if pub.events[0].Threshold != 30*day || pub.events[1].Threshold != 7*day {
+		t.Errorf("have thresholds %s and %s, want 720h and 168h", pub.events[0].Threshold, pub.events[1].Threshold)
+	}
+
+	// a replaced certificate is warned about again.
+	renewed, _ := newPushCert(t, 2, "com.apple.mgmt.test", now.Add(20*day))
+	store.cert = renewed
+	if err := m.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(pub.events) != 4 || pub.events[3].SerialNumber != "2" || pub.events[3].Threshold != 30*day {
+		t.Errorf("unexpected events after renewal %+v", pub.events[3:])
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// Info is the push configuration of the server, without any key material.
+type Info struct {
+	PushProvider    string `json:"push_provider"`
+	PushEnvironment string `json:"push_environment,omitempty"`
+	PushTopic       string `json:"push_topic,omitempty"`
+
+	// PushCertificate is nil if no push certificate was saved.
+	PushCertificate *PushCertificateInfo `json:"push_certificate,omitempty"`
+}
+
+func (svc *ConfigService) GetConfig(ctx context.Context) (*Info, error) {
+	provider, err := svc.store.PushProvider()
+	if err != nil {
+		return nil, errors.Wrap(err, "get push provider")
+	}
+	info := Info{
+		PushProvider:    provider.Provider,
+		PushEnvironment: provider.Environment,
+	}
+	// the topic and certificate are missing until they are configured.
+	info.PushTopic, _ = svc.store.PushTopic()
+	if cert, err := svc.store.PushCertificate(); err == nil && cert.Leaf != nil {
+		info.PushCertificate = NewPushCertificateInfo(cert.Leaf)
+	}
+	return &info, nil
+}
+
+type getConfigResponse struct {
+	*Info
+	Err error `json:"err,omitempty"`
+}
+
+func (r getConfigResponse) Failed() error { return r.Err }
+
+func decodeGetConfigRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeGetConfigResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp getConfigResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeGetConfigEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		info, err := svc.GetConfig(ctx)
+		return getConfigResponse{Info: info, Err: err}, nil
+	}
+}
+
+func (e Endpoints) GetConfig(ctx context.Context) (*Info, error) {
+	response, err := e.GetConfigEndpoint(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.(getConfigResponse).Info, response.(getConfigResponse).Err
+}
@@ -9,6 +9,8 @@ It is generated from these files:
 
 It has these top-level messages:
 	ServerConfig
+	PushProvider
+	PushCertificateExpiry
 */
 package configproto
 
@@ -51,20 +53,173 @@ func (m *ServerConfig) GetPushCertificateKey() []byte {
 	return nil
 }
 
+type PushProvider struct {
+	Provider    string `protobuf:"bytes,1,opt,name=provider" json:"provider,omitempty"`
+	AuthKey     []byte `protobuf:"bytes,2,opt,name=auth_key,json=authKey,proto3" json:"auth_key,omitempty"`
+	KeyId       string `protobuf:"bytes,3,opt,name=key_id,json=keyId" json:"key_id,omitempty"`
+	TeamId      string `protobuf:"bytes,4,opt,name=team_id,json=teamId" json:"team_id,omitempty"`
+	Topic       string `protobuf:"bytes,5,opt,name=topic" json:"topic,omitempty"`
+	Environment string `protobuf:"bytes,6,opt,name=environment" json:"environment,omitempty"`
+	GatewayUrl  string `protobuf:"bytes,7,opt,name=gateway_url,json=gatewayUrl" json:"gateway_url,omitempty"`
+}
+
+func (m *PushProvider) Reset()                    { *m = PushProvider{} }
+func (m *PushProvider) String() string            { return proto.CompactTextString(m) }
+func (*PushProvider) ProtoMessage()               {}
+func (*PushProvider) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *PushProvider) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *PushProvider) GetAuthKey() []byte {
+	if m != nil {
+		return m.AuthKey
+	}
+	return nil
+}
+
+func (m *PushProvider) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *PushProvider) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *PushProvider) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PushProvider) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *PushProvider) GetGatewayUrl() string {
+	if m != nil {
+		return m.GatewayUrl
+	}
+	return ""
+}
+
+type PushCertificateExpiry struct {
+	Id           string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Time         int64  `protobuf:"varint,2,opt,name=time" json:"time,omitempty"`
+	Topic        string `protobuf:"bytes,3,opt,name=topic" json:"topic,omitempty"`
+	Subject      string `protobuf:"bytes,4,opt,name=subject" json:"subject,omitempty"`
+	SerialNumber string `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	NotAfter     int64  `protobuf:"varint,6,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	Threshold    int64  `protobuf:"varint,7,opt,name=threshold" json:"threshold,omitempty"`
+	Expired      bool   `protobuf:"varint,8,opt,name=expired" json:"expired,omitempty"`
+}
+
+func (m *PushCertificateExpiry) Reset()                    { *m = PushCertificateExpiry{} }
+func (m *PushCertificateExpiry) String() string            { return proto.CompactTextString(m) }
+func (*PushCertificateExpiry) ProtoMessage()               {}
+func (*PushCertificateExpiry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *PushCertificateExpiry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PushCertificateExpiry) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *PushCertificateExpiry) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PushCertificateExpiry) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *PushCertificateExpiry) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *PushCertificateExpiry) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+func (m *PushCertificateExpiry) GetThreshold() int64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *PushCertificateExpiry) GetExpired() bool {
+	if m != nil {
+		return m.Expired
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ServerConfig)(nil), "configproto.ServerConfig")
+	proto.RegisterType((*PushProvider)(nil), "configproto.PushProvider")
+	proto.RegisterType((*PushCertificateExpiry)(nil), "configproto.PushCertificateExpiry")
 }
 
 func init() { proto.RegisterFile("config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 115 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0xce, 0xcf, 0x4b,
-	0xcb, 0x4c, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x86, 0xf0, 0xc0, 0x1c, 0xa5, 0x6c,
-	0x2e, 0x9e, 0xe0, 0xd4, 0xa2, 0xb2, 0xd4, 0x22, 0x67, 0xb0, 0xa0, 0x90, 0x26, 0x97, 0x40, 0x41,
-	0x69, 0x71, 0x46, 0x7c, 0x72, 0x6a, 0x51, 0x49, 0x66, 0x5a, 0x66, 0x72, 0x62, 0x49, 0xaa, 0x04,
-	0xa3, 0x02, 0xa3, 0x06, 0x4f, 0x10, 0x3f, 0x48, 0xdc, 0x19, 0x21, 0x2c, 0x64, 0xc0, 0x25, 0x82,
-	0xae, 0x34, 0x3e, 0x3b, 0xb5, 0x52, 0x82, 0x09, 0xac, 0x5c, 0x08, 0x4d, 0xb9, 0x77, 0x6a, 0x65,
-	0x12, 0x1b, 0xd8, 0x4e, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0xea, 0xa7, 0xb0, 0xbc, 0x90,
-	0x00, 0x00, 0x00,
+	// 365 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0xdb, 0x6a, 0xea, 0x40,
+	0x14, 0x86, 0x89, 0xd1, 0x24, 0x2e, 0xb3, 0x0f, 0x2c, 0x94, 0x3d, 0xfb, 0x00, 0x5b, 0xdc, 0x37,
+	0xee, 0x9b, 0x52, 0xe8, 0x13, 0x14, 0xe9, 0x85, 0x08, 0x45, 0x52, 0x7a, 0x1d, 0x62, 0x66, 0x69,
+	0xa6, 0x39, 0x4c, 0x98, 0x4c, 0x6c, 0xf3, 0x92, 0x7d, 0x8b, 0xbe, 0x47, 0xc9, 0x18, 0xab, 0xf5,
+	0x6e, 0x7d, 0x5f, 0xfe, 0x64, 0xad, 0x1f, 0x02, 0x7e, 0x2c, 0x8b, 0xad, 0xd8, 0x5d, 0x95, 0x4a,
+	0x6a, 0x89, 0xa3, 0x03, 0x19, 0x98, 0xa5, 0xe0, 0x3f, 0x90, 0xda, 0x93, 0x5a, 0x18, 0x89, 0xff,
+	0xe1, 0x7b, 0x59, 0x57, 0x49, 0x18, 0x93, 0xd2, 0x62, 0x2b, 0xe2, 0x48, 0x13, 0xb3, 0xa6, 0xd6,
+	0xdc, 0x0f, 0xbe, 0xb5, 0x7e, 0x71, 0xd2, 0x78, 0x0d, 0xe3, 0xcb, 0x68, 0x98, 0x52, 0xc3, 0x7a,
+	0x26, 0x8e, 0x17, 0xf1, 0x15, 0x35, 0xb3, 0x57, 0x0b, 0xfc, 0x75, 0x5d, 0x25, 0x6b, 0x25, 0xf7,
+	0x82, 0x93, 0xc2, 0x5f, 0xe0, 0x95, 0xdd, 0x6c, 0xb6, 0x0c, 0x83, 0x0f, 0xc6, 0x9f, 0xe0, 0x45,
+	0xb5, 0x4e, 0xce, 0x3e, 0xe9, 0xb6, 0xbc, 0xa2, 0x06, 0x27, 0xe0, 0xa4, 0xd4, 0x84, 0x82, 0x33,
+	0xdb, 0xbc, 0x34, 0x48, 0xa9, 0x59, 0x72, 0xfc, 0x01, 0xae, 0xa6, 0x28, 0x6f, 0x7d, 0xdf, 0x78,
+	0xa7, 0xc5, 0x25, 0xc7, 0x31, 0x0c, 0xb4, 0x2c, 0x45, 0xcc, 0x06, 0x87, 0xb8, 0x01, 0x9c, 0xc2,
+	0x88, 0x8a, 0xbd, 0x50, 0xb2, 0xc8, 0xa9, 0xd0, 0xcc, 0x31, 0xcf, 0xce, 0x15, 0xfe, 0x85, 0xd1,
+	0x2e, 0xd2, 0xf4, 0x1c, 0x35, 0x61, 0xad, 0x32, 0xe6, 0x9a, 0x04, 0x74, 0xea, 0x51, 0x65, 0xb3,
+	0x37, 0x0b, 0x26, 0xeb, 0xcf, 0x3d, 0xef, 0x5e, 0x4a, 0xa1, 0x1a, 0xfc, 0x0a, 0x3d, 0xc1, 0xbb,
+	0x4e, 0x3d, 0xc1, 0x11, 0xa1, 0xaf, 0x45, 0x4e, 0xa6, 0x89, 0x1d, 0x98, 0xf9, 0x74, 0x96, 0x7d,
+	0x7e, 0x16, 0x03, 0xb7, 0xaa, 0x37, 0x4f, 0x14, 0xeb, 0xae, 0xc5, 0x11, 0xf1, 0x1f, 0x7c, 0xa9,
+	0x48, 0x89, 0x28, 0x0b, 0x8b, 0x3a, 0xdf, 0x90, 0xea, 0xea, 0xf8, 0x07, 0x79, 0x6f, 0x1c, 0xfe,
+	0x86, 0x61, 0x21, 0x75, 0x18, 0x6d, 0x35, 0x29, 0xd3, 0xc9, 0x0e, 0xbc, 0x42, 0xea, 0xdb, 0x96,
+	0xf1, 0x0f, 0x0c, 0x75, 0xa2, 0xa8, 0x4a, 0x64, 0xc6, 0x4d, 0x1d, 0x3b, 0x38, 0x89, 0x76, 0x33,
+	0xb5, 0xd7, 0x13, 0x67, 0xde, 0xd4, 0x9a, 0x7b, 0xc1, 0x11, 0x37, 0x8e, 0xf9, 0x59, 0x6e, 0xde,
+	0x03, 0x00, 0x00, 0xff, 0xff, 0xb6, 0xc6, 0xe4, 0x2b, 0x49, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/crypto"
+)
+
+// PushCertificateInfo describes an MDM push certificate.
+type PushCertificateInfo struct {
+	Subject      string    `json:"subject"`
+	Topic        string    `json:"topic"`
+	SerialNumber string    `json:"serial_number"`
+	NotBefore    time.Time `json:"not_before"`
+	NotAfter     time.Time `json:"not_after"`
+}
+
+// NewPushCertificateInfo describes the certificate. The topic is empty if
+// the certificate has none.
+func NewPushCertificateInfo(cert *x509.Certificate) *PushCertificateInfo {
+	topic, _ := crypto.TopicFromCert(cert)
+	return &PushCertificateInfo{
+		Subject:      cert.Subject.String(),
+		Topic:        topic,
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+	}
+}
+
+// TopicMismatchError is returned when a push certificate or push provider
+// with a topic other than the current push topic is saved without force. Devices only accept
+// pushes to the topic they enrolled with, so replacing the topic orphans
+// every enrolled device.
+type TopicMismatchError struct {
+	Current string
+	New     string
+}
+
+func (e *TopicMismatchError) Error() string {
+	return fmt.Sprintf("push topic %s does not match the current push topic %s, "+
+		"enrolled devices would no longer receive pushes. Save with force to replace the topic",
+		e.New, e.Current)
+}
+
+func (e *TopicMismatchError) StatusCode() int { return http.StatusConflict }
+
+func parsePushCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("decode push certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	return cert, errors.Wrap(err, "parse push certificate")
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/config/internal/configproto"
+)
+
+// Push providers authenticate with APNs.
+const (
+	// PushProviderCertificate authenticates with the MDM push certificate.
+	PushProviderCertificate = "certificate"
+
+	// PushProviderToken authenticates with JWTs signed by a .p8 auth key.
+	PushProviderToken = "token"
+)
+
+// APNs environments.
+const (
+	PushEnvironmentProduction = "production"
+	PushEnvironmentSandbox    = "sandbox"
+)
+
+// PushProvider selects how the server authenticates with APNs and which
+// APNs gateway it sends push notifications to.
+type PushProvider struct {
+	Provider string `json:"provider"`
+
+	// AuthKey, KeyID, TeamID and Topic are required by the token provider.
+	// AuthKey is the PEM encoded .p8 key.
+	AuthKey []byte `json:"auth_key,omitempty"`
+	KeyID   string `json:"key_id,omitempty"`
+	TeamID  string `json:"team_id,omitempty"`
+	Topic   string `json:"topic,omitempty"`
+
+	// Environment is production or sandbox. GatewayURL overrides the URL
+	// of the environment, for example to test against a local server.
+	Environment string `json:"environment,omitempty"`
+	GatewayURL  string `json:"gateway_url,omitempty"`
+}
+
+// Validate checks that the provider is complete.
+func (p *PushProvider) Validate() error {
+	switch p.Environment {
+	case "", PushEnvironmentProduction, PushEnvironmentSandbox:
+	default:
+		return errors.Errorf("unknown APNs environment %q", p.Environment)
+	}
+	switch p.Provider {
+	case "", PushProviderCertificate:
+		return nil
+	case PushProviderToken:
+		if len(p.AuthKey) == 0 || p.KeyID == "" || p.TeamID == "" || p.Topic == "" {
+			return errors.New("token push provider requires an auth key, key ID, team ID and topic")
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown push provider %q", p.Provider)
+	}
+}
+
+func MarshalPushProvider(p *PushProvider) ([]byte, error) {
+	pb := configproto.PushProvider{
+		Provider:    p.Provider,
+		AuthKey:     p.AuthKey,
+		KeyId:       p.KeyID,
+		TeamId:      p.TeamID,
+		Topic:       p.Topic,
+		Environment: p.Environment,
+		GatewayUrl:  p.GatewayURL,
+	}
+	data, err := proto.Marshal(&pb)
+	return data, errors.Wrap(err, "marshal push provider to proto")
+}
+
+func UnmarshalPushProvider(data []byte, p *PushProvider) error {
+	var pb configproto.PushProvider
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal push provider from proto")
+	}
+	p.Provider = pb.GetProvider()
+	p.AuthKey = pb.GetAuthKey()
+	p.KeyID = pb.GetKeyId()
+	p.TeamID = pb.GetTeamId()
+	p.Topic = pb.GetTopic()
+	p.Environment = pb.GetEnvironment()
+	p.GatewayURL = pb.GetGatewayUrl()
+	return nil
+}
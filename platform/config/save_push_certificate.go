@@ -4,19 +4,65 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/as/micromdm/pkg/crypto"
 	"github.com/as/micromdm/pkg/httputil"
 	"github.com/go-kit/kit/endpoint"
 	"github.com/pkg/errors"
 )
 
-func (svc *ConfigService) SavePushCertificate(ctx context.Context, cert, key []byte) error {
+// SavePushCertificate saves the push certificate and key. Unless force is
+// set, it refuses a certificate whose topic differs from the current push
+// topic with a TopicMismatchError.
+func (svc *ConfigService) SavePushCertificate(ctx context.Context, cert, key []byte, force bool) error {
+	if !force {
+		if err := svc.checkTopic(cert); err != nil {
+			return err
+		}
+	}
 	err := svc.store.SavePushCertificate(cert, key)
 	return errors.Wrap(err, "save push certificate")
 }
 
+// checkTopic compares the topic of the certificate with the current push
+// topic. There is nothing to compare before the first certificate is saved.
+func (svc *ConfigService) checkTopic(certPEM []byte) error {
+	topics := svc.topics
+	if topics == nil {
+		topics = svc.store
+	}
+	current, err := topics.PushTopic()
+	if isNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "get current push topic")
+	}
+	if current == "" {
+		return nil
+	}
+	cert, err := parsePushCertificate(certPEM)
+	if err != nil {
+		return err
+	}
+	topic, err := crypto.TopicFromCert(cert)
+	if err != nil {
+		return errors.Wrap(err, "get topic from push certificate")
+	}
+	return compareTopics(current, topic)
+}
+
+// compareTopics returns a TopicMismatchError if a new push topic differs
+// from the current one. An empty topic is not known and matches any topic.
+func compareTopics(current, topic string) error {
+	if current == "" || topic == "" || topic == current {
+		return nil
+	}
+	return &TopicMismatchError{Current: current, New: topic}
+}
+
 type saveRequest struct {
-	Cert []byte `json:"cert"`
-	Key  []byte `json:"key"`
+	Cert  []byte `json:"cert"`
+	Key   []byte `json:"key"`
+	Force bool   `json:"force,omitempty"`
 }
 
 type saveResponse struct {
@@ -40,15 +86,16 @@ func decodeSavePushCertificateResponse(_ context.Context, r *http.Response) (int
 func MakeSavePushCertificateEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(saveRequest)
-		err = svc.SavePushCertificate(ctx, req.Cert, req.Key)
+		err = svc.SavePushCertificate(ctx, req.Cert, req.Key, req.Force)
 		return saveResponse{Err: err}, nil
 	}
 }
 
-func (e Endpoints) SavePushCertificate(ctx context.Context, cert, key []byte) error {
+func (e Endpoints) SavePushCertificate(ctx context.Context, cert, key []byte, force bool) error {
 	request := saveRequest{
-		Cert: cert,
-		Key:  key,
+		Cert:  cert,
+		Key:   key,
+		Force: force,
 	}
 
 	response, err := e.SavePushCertificateEndpoint(ctx, request)
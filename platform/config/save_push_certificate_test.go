@@ -0,0 +1,85 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSavePushCertificateTopic(t *testing.T) {
+	notAfter := time.Now().Add(365 * 24 * time.Hour)
+	current, _ := newPushCert(t, 1, "com.apple.mgmt.current", notAfter)
+	_, renewed := newPushCert(t, 2, "com.apple.mgmt.current", notAfter)
+	_, other := newPushCert(t, 3, "com.apple.mgmt.other", notAfter)
+
+	// the first certificate sets the topic.
+	store := &mockStore{}
+	svc := New(store)
+	if err := svc.SavePushCertificate(context.Background(), other, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	store = &mockStore{cert: current}
+	svc = New(store)
+	if err := svc.SavePushCertificate(context.Background(), renewed, nil, false); err != nil {
+		t.Fatalf("renewing with the same topic: %s", err)
+	}
+
+	store.saved = nil
+	err := svc.SavePushCertificate(context.Background(), other, nil, false)
+	mismatch, ok := err.(*TopicMismatchError)
+	if !ok {
+		t.Fatalf("have error %v, want a TopicMismatchError", err)
+	}
+	if mismatch.Current != "com.apple.mgmt.current" || mismatch.New != "com.apple.mgmt.other" || mismatch.StatusCode() != 409 {
+		t.Errorf("unexpected mismatch %+v", mismatch)
+	}
+	if store.saved != nil {
+		t.Error("certificate with a different topic was saved")
+	}
+
+	if err := svc.SavePushCertificate(context.Background(), other, nil, true); err != nil {
+		t.Fatalf("forced save: %s", err)
+	}
+	if store.saved == nil {
+		t.Error("forced save did not save the certificate")
+	}
+}
+
+func TestSavePushCertificateTopic_StoreError(t *testing.T) {
+	_, cert := newPushCert(t, 1, "com.apple.mgmt.other", time.Now().Add(time.Hour))
+	store := &mockStore{}
+	svc := New(store, WithTopicProvider(topicFunc(func() (string, error) {
+		return "", errors.New("corrupt push certificate")
+	})))
+	if err := svc.SavePushCertificate(context.Background(), cert, nil, false); err == nil {
+		t.Fatal("expected the store error to be returned")
+	}
+	if store.saved != nil {
+		t.Error("certificate was saved without comparing the topic")
+	}
+}
+
+func TestSavePushCertificateTopic_Provider(t *testing.T) {
+	notAfter := time.Now().Add(365 * 24 * time.Hour)
+	_, flag := newPushCert(t, 1, "com.apple.mgmt.flag", notAfter)
+	_, other := newPushCert(t, 2, "com.apple.mgmt.other", notAfter)
+
+	// devices enrolled with the topic of the -apns-cert flag, no certificate
+	// was saved yet.
+	store := &mockStore{}
+	svc := New(store, WithTopicProvider(topicFunc(func() (string, error) {
+		return "com.apple.mgmt.flag", nil
+	})))
+	if _, ok := svc.SavePushCertificate(context.Background(), other, nil, false).(*TopicMismatchError); !ok {
+		t.Error("expected a TopicMismatchError for a topic other than the flag topic")
+	}
+	if err := svc.SavePushCertificate(context.Background(), flag, nil, false); err != nil {
+		t.Errorf("saving a certificate with the flag topic: %s", err)
+	}
+}
+
+type topicFunc func() (string, error)
+
+func (f topicFunc) PushTopic() (string, error) { return f() }
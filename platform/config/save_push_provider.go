@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// SavePushProvider saves the push provider. Unless force is set, it refuses
+// a provider whose topic differs from the current push topic with a
+// TopicMismatchError.
+func (svc *ConfigService) SavePushProvider(ctx context.Context, p PushProvider, force bool) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	var check func(current, topic string) error
+	if !force {
+		check = svc.checkProviderTopic
+	}
+	err := svc.store.SavePushProvider(&p, check)
+	if mismatch, ok := errors.Cause(err).(*TopicMismatchError); ok {
+		return mismatch
+	}
+	return errors.Wrap(err, "save push provider")
+}
+
+// checkProviderTopic compares the topic of a push provider with the current
+// push topic, which is the topic of the TopicProvider if one is set.
+func (svc *ConfigService) checkProviderTopic(current, topic string) error {
+	if svc.topics == nil {
+		return compareTopics(current, topic)
+	}
+	current, err := svc.topics.PushTopic()
+	if isNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "get current push topic")
+	}
+	return compareTopics(current, topic)
+}
+
+type savePushProviderRequest struct {
+	PushProvider
+	Force bool `json:"force,omitempty"`
+}
+
+type savePushProviderResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r savePushProviderResponse) Failed() error { return r.Err }
+
+func decodeSavePushProviderRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req savePushProviderRequest
+	err := httputil.DecodeJSONRequest(r, &req)
+	return req, err
+}
+
+func decodeSavePushProviderResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp savePushProviderResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeSavePushProviderEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(savePushProviderRequest)
+		err = svc.SavePushProvider(ctx, req.PushProvider, req.Force)
+		return savePushProviderResponse{Err: err}, nil
+	}
+}
+
+func (e Endpoints) SavePushProvider(ctx context.Context, p PushProvider, force bool) error {
+	request := savePushProviderRequest{PushProvider: p, Force: force}
+	response, err := e.SavePushProviderEndpoint(ctx, request)
+	if err != nil {
+		return err
+	}
+	return response.(savePushProviderResponse).Err
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSavePushProviderTopic(t *testing.T) {
+	current, _ := newPushCert(t, 1, "com.apple.mgmt.current", time.Now().Add(time.Hour))
+	store := &mockStore{cert: current}
+	svc := New(store)
+	ctx := context.Background()
+
+	provider := func(topic string) PushProvider {
+		return PushProvider{
+			Provider: PushProviderToken,
+			AuthKey:  []byte("key"),
+			KeyID:    "key-id",
+			TeamID:   "team-id",
+			Topic:    topic,
+		}
+	}
+
+	if err := svc.SavePushProvider(ctx, provider("com.apple.mgmt.current"), false); err != nil {
+		t.Fatalf("saving a provider with the current topic: %s", err)
+	}
+	if store.provider == nil {
+		t.Fatal("provider with the current topic was not saved")
+	}
+
+	store.provider = nil
+	err := svc.SavePushProvider(ctx, provider("com.apple.mgmt.other"), false)
+	if _, ok := err.(*TopicMismatchError); !ok {
+		t.Fatalf("have error %v, want a TopicMismatchError", err)
+	}
+	if store.provider != nil {
+		t.Error("provider with a different topic was saved")
+	}
+
+	if err := svc.SavePushProvider(ctx, provider("com.apple.mgmt.other"), true); err != nil {
+		t.Fatalf("forced save: %s", err)
+	}
+	if store.provider == nil {
+		t.Error("forced save did not save the provider")
+	}
+
+	// devices enrolled with the topic of the -apns-cert flag.
+	svc = New(store, WithTopicProvider(topicFunc(func() (string, error) {
+		return "com.apple.mgmt.flag", nil
+	})))
+	if _, ok := svc.SavePushProvider(ctx, provider("com.apple.mgmt.current"), false).(*TopicMismatchError); !ok {
+		t.Error("expected a TopicMismatchError for a topic other than the flag topic")
+	}
+}
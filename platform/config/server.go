@@ -11,24 +11,37 @@ import (
 type Endpoints struct {
 	ApplyDEPTokensEndpoint      endpoint.Endpoint
 	SavePushCertificateEndpoint endpoint.Endpoint
+	SavePushProviderEndpoint    endpoint.Endpoint
 	GetDEPTokensEndpoint        endpoint.Endpoint
+	GetConfigEndpoint           endpoint.Endpoint
 }
 
 func MakeServerEndpoints(s Service) Endpoints {
 	return Endpoints{
 		ApplyDEPTokensEndpoint:      MakeApplyDEPTokensEndpoint(s),
 		SavePushCertificateEndpoint: MakeSavePushCertificateEndpoint(s),
+		SavePushProviderEndpoint:    MakeSavePushProviderEndpoint(s),
 		GetDEPTokensEndpoint:        MakeGetDEPTokensEndpoint(s),
+		GetConfigEndpoint:           MakeGetConfigEndpoint(s),
 	}
 }
 
 func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
 	r, options := httputil.NewRouter(logger)
 
+	// GET     /v1/config					get the push provider, topic and certificate
 	// PUT     /v1/config/certificate		create or replace the MDM Push Certificate
+	// PUT     /v1/config/push-provider		select the APNs authentication and gateway
 	// PUT     /v1/dep-tokens				create or replace a DEP OAuth token
 	// GET     /v1/dep-tokens				get the OAuth Token used for the DEP client
 
+	r.Methods("GET").Path("/v1/config").Handler(httptransport.NewServer(
+		e.GetConfigEndpoint,
+		decodeGetConfigRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
 	r.Methods("PUT").Path("/v1/config/certificate").Handler(httptransport.NewServer(
 		e.SavePushCertificateEndpoint,
 		decodeSavePushCertificateRequest,
@@ -36,6 +49,13 @@ func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
 		options...,
 	))
 
+	r.Methods("PUT").Path("/v1/config/push-provider").Handler(httptransport.NewServer(
+		e.SavePushProviderEndpoint,
+		decodeSavePushProviderRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
 	r.Methods("PUT").Path("/v1/dep-tokens").Handler(httptransport.NewServer(
 		e.ApplyDEPTokensEndpoint,
 		decodeApplyDEPTokensRequest,
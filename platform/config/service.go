@@ -5,10 +5,14 @@ import (
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
+
+	"github.com/pkg/errors"
 )
 
 type Service interface {
-	SavePushCertificate(ctx context.Context, cert, key []byte) error
+	SavePushCertificate(ctx context.Context, cert, key []byte, force bool) error
+	GetConfig(ctx context.Context) (*Info, error)
+	SavePushProvider(ctx context.Context, p PushProvider, force bool) error
 	ApplyDEPToken(ctx context.Context, P7MContent []byte) error
 	GetDEPTokens(ctx context.Context) ([]DEPToken, []byte, error)
 }
@@ -17,15 +21,53 @@ type Store interface {
 	SavePushCertificate(cert, key []byte) error
 	PushCertificate() (*tls.Certificate, error)
 	PushTopic() (string, error)
+	// SavePushProvider calls check with the current push topic and the
+	// push topic of the provider in the transaction which saves the
+	// provider. An error returned by check aborts the save. A nil check
+	// saves the provider unconditionally.
+	SavePushProvider(p *PushProvider, check func(current, topic string) error) error
+	PushProvider() (*PushProvider, error)
 	DEPKeypair() (key *rsa.PrivateKey, cert *x509.Certificate, err error)
 	AddToken(consumerKey string, json []byte) error
 	DEPTokens() ([]DEPToken, error)
 }
 
+// TopicProvider returns the push topic that devices enroll with.
+type TopicProvider interface {
+	PushTopic() (string, error)
+}
+
 type ConfigService struct {
-	store Store
+	store  Store
+	topics TopicProvider
+}
+
+type Option func(*ConfigService)
+
+// WithTopicProvider sets the provider of the push topic that new push
+// certificates and push providers must match, like the topic of the
+// -apns-cert flag. By default the topic of the stored push configuration
+// is used.
+func WithTopicProvider(p TopicProvider) Option {
+	return func(svc *ConfigService) {
+		svc.topics = p
+	}
 }
 
-func New(store Store) *ConfigService {
-	return &ConfigService{store: store}
+func New(store Store, opts ...Option) *ConfigService {
+	svc := ConfigService{store: store}
+	for _, opt := range opts {
+		opt(&svc)
+	}
+	return &svc
+}
+
+func isNotFound(err error) bool {
+	type notFoundError interface {
+		error
+		NotFound() bool
+	}
+
+	_, ok := errors.Cause(err).(notFoundError)
+	return ok
 }
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"log"
 
+	"github.com/pkg/errors"
+
 	"github.com/as/micromdm/platform/config"
 	"github.com/as/micromdm/platform/pubsub"
 )
@@ -16,27 +18,33 @@ func (svc *DEPService) watchTokenUpdates(pubsub pubsub.Subscriber) error {
 	}
 
 	go func() {
-		for {
-			select {
-			case event := <-tokenAdded:
-				var token config.DEPToken
-				if err := json.Unmarshal(event.Message, &token); err != nil {
-					log.Printf("unmarshalling tokenAdded to token: %s\n", err)
-					continue
-				}
-
-				client, err := token.Client()
-				if err != nil {
-					log.Printf("creating new DEP client: %s\n", err)
-					continue
-				}
-
-				svc.mtx.Lock() //TODO(as): fix
-				svc.client = client
-				svc.mtx.Unlock()
+		for event := range tokenAdded {
+			if err := svc.updateClient(event); err != nil {
+				log.Println(err)
+			}
+			if err := event.Ack(); err != nil {
+				log.Println(err)
 			}
 		}
 	}()
 
 	return nil
 }
+
+// updateClient replaces the DEP client with a client for the added token.
+func (svc *DEPService) updateClient(event pubsub.Event) error {
+	var token config.DEPToken
+	if err := json.Unmarshal(event.Message, &token); err != nil {
+		return errors.Wrap(err, "unmarshalling tokenAdded to token")
+	}
+
+	client, err := token.Client()
+	if err != nil {
+		return errors.Wrap(err, "creating new DEP client")
+	}
+
+	svc.mtx.Lock() //TODO(as): fix
+	svc.client = client
+	svc.mtx.Unlock()
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/as/micromdm/dep/depsync"
 	"github.com/as/micromdm/mdm/checkin"
 	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/platform/apns"
 	"github.com/as/micromdm/platform/device"
 	"github.com/as/micromdm/platform/pubsub"
 )
@@ -34,8 +35,21 @@ func NewDB(db *bolt.DB, pubsubSvc pubsub.PublishSubscriber) (*DB, error) {
 		if err != nil {
 			return err
 		}
+		_, err = tx.CreateBucketIfNotExists([]byte(InventoryBucket))
+		if err != nil {
+			return err
+		}
 		_, err = tx.CreateBucketIfNotExists([]byte(DeviceBucket))
-		return err
+		if err != nil {
+			return err
+		}
+		if tx.Bucket([]byte(deviceSortIndexBucket)) != nil {
+			return nil
+		}
+		if _, err := tx.CreateBucket([]byte(deviceSortIndexBucket)); err != nil {
+			return err
+		}
+		return reindex(tx)
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "creating %s bucket", DeviceBucket)
@@ -75,6 +89,7 @@ func (db *DB) Save(dev *device.Device) error {
 	if err != nil {
 		return errors.Wrap(err, "begin transaction")
 	}
+	defer tx.Rollback()
 	bkt := tx.Bucket([]byte(DeviceBucket))
 	if bkt == nil {
 		return fmt.Errorf("bucket %q not found!", DeviceBucket)
@@ -102,6 +117,16 @@ func (db *DB) Save(dev *device.Device) error {
 	}
 
 	key := []byte(dev.UUID)
+	var old *device.Device
+	if v := bkt.Get(key); v != nil {
+		old = new(device.Device)
+		if err := device.UnmarshalDevice(v, old); err != nil {
+			return errors.Wrap(err, "unmarshal saved device")
+		}
+	}
+	if err := updateSortIndexes(tx, old, dev); err != nil {
+		return errors.Wrap(err, "update device sort indexes")
+	}
 	if err := bkt.Put(key, devproto); err != nil {
 		return errors.Wrap(err, "put device to boltdb")
 	}
@@ -192,164 +217,330 @@ func (db *DB) pollCheckin(pubsubSvc pubsub.PublishSubscriber) error {
 		return errors.Wrapf(err,
 			"subscribing devices to %s topic", connect.ConnectTopic)
 	}
+	pushEvents, err := pubsubSvc.Subscribe(context.TODO(), "devices", apns.PushTopic)
+	if err != nil {
+		return errors.Wrapf(err,
+			"subscribing devices to %s topic", apns.PushTopic)
+	}
+	pushResetEvents, err := pubsubSvc.Subscribe(context.TODO(), "devices", apns.PushFailureResetTopic)
+	if err != nil {
+		return errors.Wrapf(err,
+			"subscribing devices to %s topic", apns.PushFailureResetTopic)
+	}
+	replayEvents, err := pubsubSvc.Subscribe(context.TODO(), "devices", checkin.ReplayTopic)
+	if err != nil {
+		return errors.Wrapf(err,
+			"subscribing devices to %s topic", checkin.ReplayTopic)
+	}
 	go func() {
 		for {
+			var (
+				event pubsub.Event
+				err   error
+			)
 			select {
-			case event := <-authenticateEvents:
-				var ev checkin.Event
-				if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				newDevice := new(device.Device)
-				bySerial, err := db.DeviceBySerial(ev.Command.SerialNumber)
-				if err == nil && bySerial != nil { // must be a DEP device
-					newDevice = bySerial
-				}
-				if err != nil && !isNotFound(err) {
-					fmt.Println(err) // some other issue is going on
-					continue
-				}
-				_, err = db.DeviceByUDID(ev.Command.UDID)
-				if err != nil && isNotFound(err) { // never checked in
-					fmt.Printf("checking in new device %s\n", ev.Command.SerialNumber)
-				} else if err != nil {
-					fmt.Println(err)
-					continue
-				} else if err == nil {
-					fmt.Printf("re-enrolling device %s\n", ev.Command.SerialNumber)
-					newDevice.Enrolled = false
-				}
-
-				// only create new UUID on initial enrollment.
-				if newDevice.UUID == "" {
-					newDevice.UUID = uuid.NewV4().String()
-				}
-				newDevice.UDID = ev.Command.UDID
-				newDevice.OSVersion = ev.Command.OSVersion
-				newDevice.BuildVersion = ev.Command.BuildVersion
-				newDevice.ProductName = ev.Command.ProductName
-				newDevice.SerialNumber = ev.Command.SerialNumber
-				newDevice.IMEI = ev.Command.IMEI
-				newDevice.MEID = ev.Command.MEID
-				newDevice.DeviceName = ev.Command.DeviceName
-				newDevice.Model = ev.Command.Model
-				newDevice.ModelName = ev.Command.ModelName
-				newDevice.LastCheckin = time.Now()
-				// Challenge:    ev.Command.Challenge, // FIXME: @groob why is this commented out?
-
-				if err := db.Save(newDevice); err != nil {
-					fmt.Println(err)
-					continue
-				}
-			case event := <-tokenUpdateEvents:
-				var ev checkin.Event
-				if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				if ev.Command.UserID != "" {
-					continue
-				}
-				dev, err := db.DeviceByUDID(ev.Command.UDID)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-				dev.Token = ev.Command.Token.String()
-				dev.PushMagic = ev.Command.PushMagic
-				dev.UnlockToken = ev.Command.UnlockToken.String()
-				dev.AwaitingConfiguration = ev.Command.AwaitingConfiguration
-				dev.LastCheckin = time.Now()
-				var newlyEnrolled bool = false
-				if !dev.Enrolled{
-					newlyEnrolled = true
-					dev.Enrolled = true
-				}
-				if err := db.Save(dev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				if newlyEnrolled {
-					fmt.Printf("device %s enrolled\n", ev.Command.UDID)
-					err := pubsubSvc.Publish(context.TODO(), device.DeviceEnrolledTopic, event.Message)
-					if err != nil {
-						fmt.Println(err)
-					}
-				}
-			case event := <-depSyncEvents:
-				var ev depsync.Event
-				if err := depsync.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				fmt.Printf("got %d devices from DEP\n", len(ev.Devices))
-				for _, d := range ev.Devices {
-					newDevice := new(device.Device)
-					bySerial, err := db.DeviceBySerial(d.SerialNumber)
-					if err == nil && bySerial != nil { // must be a DEP device
-						fmt.Printf("existing device checked in from DEP: %s\n", d.SerialNumber)
-						newDevice = bySerial
-					}
-					if err != nil && !isNotFound(err) {
-						fmt.Println(err) // some other issue is going on
-						continue
-					}
-					if newDevice.UUID == "" { // previously unknown
-						newDevice.UUID = uuid.NewV4().String()
-					}
-					newDevice.SerialNumber = d.SerialNumber
-					newDevice.Model = d.Model
-					newDevice.Description = d.Description
-					newDevice.Color = d.Color
-					newDevice.AssetTag = d.AssetTag
-					newDevice.DEPProfileStatus = device.DEPProfileStatus(d.ProfileStatus)
-					newDevice.DEPProfileUUID = d.ProfileUUID
-					newDevice.DEPProfileAssignTime = d.ProfileAssignTime
-					newDevice.DEPProfileAssignedDate = d.DeviceAssignedDate
-					newDevice.DEPProfileAssignedBy = d.DeviceAssignedBy
-					// TODO: deal with sync fields OpType, OpDate
-					if err := db.Save(newDevice); err != nil {
-						fmt.Println(err)
-						continue
-					}
-				}
-			case event := <-connectEvents:
-				var ev connect.Event
-				if err := connect.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				dev, err := db.DeviceByUDID(ev.Response.UDID)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-				dev.LastCheckin = time.Now()
-				if err := db.Save(dev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-			case event := <-checkoutEvents:
-				var ev checkin.Event
-				if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				dev, err := db.DeviceByUDID(ev.Command.UDID)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-				dev.Enrolled = false
-				dev.LastCheckin = time.Now()
-				if err := db.Save(dev); err != nil {
-					fmt.Println(err)
-					continue
-				}
+			case event = <-authenticateEvents:
+				err = db.handleAuthenticate(event)
+			case event = <-tokenUpdateEvents:
+				err = db.handleTokenUpdate(pubsubSvc, event)
+			case event = <-depSyncEvents:
+				err = db.handleDEPSync(event)
+			case event = <-connectEvents:
+				err = db.handleConnect(event)
+			case event = <-checkoutEvents:
+				err = db.handleCheckout(event)
+			case event = <-pushEvents:
+				err = db.handlePush(event)
+			case event = <-pushResetEvents:
+				err = db.handlePushFailureReset(event)
+			case event = <-replayEvents:
+				err = db.handleReplay(event)
+			}
+			if err != nil {
+				fmt.Println(err)
+			}
+			if err := event.Ack(); err != nil {
+				fmt.Println(err)
 			}
 		}
 	}()
 
 	return nil
 }
+
+// handleAuthenticate handles the Authenticate checkin of a new or re-enrolling device.
+func (db *DB) handleAuthenticate(event pubsub.Event) error {
+	var ev checkin.Event
+	if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	return db.authenticate(ev, time.Now())
+}
+
+func (db *DB) authenticate(ev checkin.Event, at time.Time) error {
+	newDevice := new(device.Device)
+	bySerial, err := db.DeviceBySerial(ev.Command.SerialNumber)
+	if err == nil && bySerial != nil { // must be a DEP device
+		newDevice = bySerial
+	}
+	if err != nil && !isNotFound(err) {
+		return err // some other issue is going on
+	}
+	_, err = db.DeviceByUDID(ev.Command.UDID)
+	if err != nil && isNotFound(err) { // never checked in
+		fmt.Printf("checking in new device %s\n", ev.Command.SerialNumber)
+	} else if err != nil {
+		return err
+	} else if err == nil {
+		fmt.Printf("re-enrolling device %s\n", ev.Command.SerialNumber)
+		newDevice.Enrolled = false
+	}
+
+	// only create new UUID on initial enrollment.
+	if newDevice.UUID == "" {
+		newDevice.UUID = uuid.NewV4().String()
+	}
+	newDevice.UDID = ev.Command.UDID
+	newDevice.OSVersion = ev.Command.OSVersion
+	newDevice.BuildVersion = ev.Command.BuildVersion
+	newDevice.ProductName = ev.Command.ProductName
+	newDevice.SerialNumber = ev.Command.SerialNumber
+	newDevice.IMEI = ev.Command.IMEI
+	newDevice.MEID = ev.Command.MEID
+	newDevice.DeviceName = ev.Command.DeviceName
+	newDevice.Model = ev.Command.Model
+	newDevice.ModelName = ev.Command.ModelName
+	setLastCheckin(newDevice, at)
+	// Challenge:    ev.Command.Challenge, // FIXME: @groob why is this commented out?
+
+	return db.Save(newDevice)
+}
+
+// setLastCheckin records a checkin at the time. A replayed checkin does not
+// move the last checkin of a device back.
+func setLastCheckin(dev *device.Device, at time.Time) {
+	if at.After(dev.LastCheckin) {
+		dev.LastCheckin = at
+	}
+}
+
+// handleTokenUpdate updates the push token of a device and publishes
+// the DeviceEnrolledTopic event for newly enrolled devices.
+func (db *DB) handleTokenUpdate(pub pubsub.Publisher, event pubsub.Event) error {
+	var ev checkin.Event
+	if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	newlyEnrolled, err := db.tokenUpdate(ev, time.Now(), false)
+	if err != nil {
+		return err
+	}
+	if newlyEnrolled {
+		fmt.Printf("device %s enrolled\n", ev.Command.UDID)
+		err := pub.Publish(context.TODO(), device.DeviceEnrolledTopic, event.Message)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+	return nil
+}
+
+// tokenUpdate saves the push token of a device and reports whether the
+// device was not enrolled before. A replayed TokenUpdate older than the
+// stored token is skipped, and leaves the push failure of the device alone.
+func (db *DB) tokenUpdate(ev checkin.Event, at time.Time, replay bool) (bool, error) {
+	if ev.Command.UserID != "" {
+		return false, nil
+	}
+	dev, err := db.DeviceByUDID(ev.Command.UDID)
+	if err != nil {
+		return false, err
+	}
+	if replay && !tokenBefore(dev, at) {
+		return false, nil
+	}
+	dev.Token = ev.Command.Token.String()
+	dev.PushMagic = ev.Command.PushMagic
+	dev.UnlockToken = ev.Command.UnlockToken.String()
+	dev.AwaitingConfiguration = ev.Command.AwaitingConfiguration
+	dev.TokenUpdatedAt = at
+	setLastCheckin(dev, at)
+	if !replay {
+		// a new token makes the device reachable again.
+		clearPushFailure(dev)
+	}
+	var newlyEnrolled bool = false
+	if !dev.Enrolled {
+		newlyEnrolled = true
+		dev.Enrolled = true
+	}
+	return newlyEnrolled, db.Save(dev)
+}
+
+// tokenBefore reports whether the stored token of the device is older than
+// the time. Devices saved before the time of the token was stored compare
+// their last checkin instead.
+func tokenBefore(dev *device.Device, at time.Time) bool {
+	if dev.Token == "" {
+		return true
+	}
+	if !dev.TokenUpdatedAt.IsZero() {
+		return dev.TokenUpdatedAt.Before(at)
+	}
+	return dev.LastCheckin.Before(at)
+}
+
+// handlePush updates the push health of a device from the result of a push.
+func (db *DB) handlePush(event pubsub.Event) error {
+	var ev apns.PushEvent
+	if err := apns.UnmarshalPushEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	rejected := apns.TokenRejected(ev.Reason)
+	if ev.Err != "" && !rejected {
+		return nil
+	}
+	dev, err := db.DeviceByUDID(ev.DeviceUDID)
+	if isNotFound(err) {
+		return nil // pushes to user channels are sent to the user GUID.
+	} else if err != nil {
+		return err
+	}
+	if rejected {
+		if !dev.LastCheckin.Before(ev.Time) {
+			return nil // the device checked in after the push failed.
+		}
+		dev.PushUnreachable = true
+		dev.PushFailureReason = ev.Reason
+		dev.PushFailedAt = ev.Time
+	} else {
+		dev.LastPushTime = ev.Time
+	}
+	return db.Save(dev)
+}
+
+// handlePushFailureReset makes a device reachable again after the push
+// failure was reset with the API.
+func (db *DB) handlePushFailureReset(event pubsub.Event) error {
+	var ev apns.PushEvent
+	if err := apns.UnmarshalPushEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	dev, err := db.DeviceByUDID(ev.DeviceUDID)
+	if isNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	clearPushFailure(dev)
+	return db.Save(dev)
+}
+
+// clearPushFailure resumes the pushes to a device, like the PushInfo store
+// does on a TokenUpdate, a Connect or a reset.
+func clearPushFailure(dev *device.Device) {
+	dev.PushUnreachable = false
+	dev.PushFailureReason = ""
+	dev.PushFailedAt = time.Time{}
+}
+
+// handleDEPSync saves the devices returned by a DEP sync.
+func (db *DB) handleDEPSync(event pubsub.Event) error {
+	var ev depsync.Event
+	if err := depsync.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	fmt.Printf("got %d devices from DEP\n", len(ev.Devices))
+	for _, d := range ev.Devices {
+		newDevice := new(device.Device)
+		bySerial, err := db.DeviceBySerial(d.SerialNumber)
+		if err == nil && bySerial != nil { // must be a DEP device
+			fmt.Printf("existing device checked in from DEP: %s\n", d.SerialNumber)
+			newDevice = bySerial
+		}
+		if err != nil && !isNotFound(err) {
+			fmt.Println(err) // some other issue is going on
+			continue
+		}
+		if newDevice.UUID == "" { // previously unknown
+			newDevice.UUID = uuid.NewV4().String()
+		}
+		newDevice.SerialNumber = d.SerialNumber
+		newDevice.Model = d.Model
+		newDevice.Description = d.Description
+		newDevice.Color = d.Color
+		newDevice.AssetTag = d.AssetTag
+		newDevice.DEPProfileStatus = device.DEPProfileStatus(d.ProfileStatus)
+		newDevice.DEPProfileUUID = d.ProfileUUID
+		newDevice.DEPProfileAssignTime = d.ProfileAssignTime
+		newDevice.DEPProfileAssignedDate = d.DeviceAssignedDate
+		newDevice.DEPProfileAssignedBy = d.DeviceAssignedBy
+		// TODO: deal with sync fields OpType, OpDate
+		if err := db.Save(newDevice); err != nil {
+			fmt.Println(err)
+			continue
+		}
+	}
+	return nil
+}
+
+// handleConnect records the last time a device connected and the inventory
+// returned by the command response.
+func (db *DB) handleConnect(event pubsub.Event) error {
+	var ev connect.Event
+	if err := connect.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	dev, err := db.DeviceByUDID(ev.Response.UDID)
+	if err != nil {
+		return err
+	}
+	dev.LastCheckin = time.Now()
+	if ev.Response.UserID == nil || *ev.Response.UserID == "" {
+		// the device received a push with its token.
+		clearPushFailure(dev)
+	}
+	if err := db.updateInventory(dev, ev); err != nil {
+		fmt.Println(err)
+	}
+	return db.Save(dev)
+}
+
+// handleCheckout marks a device which removed its MDM profile as unenrolled.
+func (db *DB) handleCheckout(event pubsub.Event) error {
+	var ev checkin.Event
+	if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	return db.checkout(ev, time.Now())
+}
+
+func (db *DB) checkout(ev checkin.Event, at time.Time) error {
+	dev, err := db.DeviceByUDID(ev.Command.UDID)
+	if err != nil {
+		return err
+	}
+	dev.Enrolled = false
+	setLastCheckin(dev, at)
+	return db.Save(dev)
+}
+
+// handleReplay rebuilds a device from a replayed checkin event, without
+// publishing DeviceEnrolled events. The checkin is recorded at the time of
+// the original event.
+func (db *DB) handleReplay(event pubsub.Event) error {
+	var ev checkin.Event
+	if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+	switch ev.Command.MessageType {
+	case "Authenticate":
+		return db.authenticate(ev, ev.Time)
+	case "TokenUpdate":
+		_, err := db.tokenUpdate(ev, ev.Time, true)
+		return err
+	case "CheckOut":
+		return db.checkout(ev, ev.Time)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/boltdb/bolt"
+	"github.com/groob/plist"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/platform/device"
+)
+
+// InventoryBucket stores the protobuf encoded device.Inventory of every device,
+// keyed by UDID.
+const InventoryBucket = "mdm.DeviceInventory"
+
+// inventoryResponse holds the inventory lists of a command response. A list
+// is nil if the response does not contain it.
+type inventoryResponse struct {
+	QueryResponses           *mdm.QueryResponses
+	SecurityInfo             *mdm.SecurityInfo
+	CertificateList          *mdm.CertificateList
+	InstalledApplicationList *mdm.InstalledApplicationListResponse
+	ProfileList              *mdm.ProfileList
+	OSUpdateStatus           *mdm.OSUpdateStatusResponse
+	AvailableOSUpdates       *mdm.AvailableOSUpdatesResponse
+}
+
+// queryFlags are the boolean query responses, which are nil if the device
+// was not asked for them.
+type queryFlags struct {
+	QueryResponses *struct {
+		IsSupervised            *bool
+		IsActivationLockEnabled *bool
+		AwaitingConfiguration   *bool
+	}
+}
+
+func (r inventoryResponse) empty() bool {
+	return r.QueryResponses == nil && r.SecurityInfo == nil && r.CertificateList == nil &&
+		r.InstalledApplicationList == nil && r.ProfileList == nil &&
+		r.OSUpdateStatus == nil && r.AvailableOSUpdates == nil
+}
+
+// Inventory returns the latest inventory of the device.
+func (db *DB) Inventory(udid string) (*device.Inventory, error) {
+	var inv device.Inventory
+	err := db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(InventoryBucket)).Get([]byte(udid))
+		if v == nil {
+			return &notFound{"Inventory", fmt.Sprintf("udid %s", udid)}
+		}
+		return device.UnmarshalInventory(v, &inv)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (db *DB) saveInventory(inv *device.Inventory) error {
+	data, err := device.MarshalInventory(inv)
+	if err != nil {
+		return errors.Wrap(err, "marshal device inventory")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(InventoryBucket)).Put([]byte(inv.UDID), data)
+	})
+	return errors.Wrap(err, "save device inventory")
+}
+
+// updateInventory decodes the inventory lists of an acknowledged command
+// response. The DeviceInformation query responses update the device,
+// the other lists replace the lists of the device inventory.
+func (db *DB) updateInventory(dev *device.Device, ev connect.Event) error {
+	if ev.Response.Status != "Acknowledged" || len(ev.Raw) == 0 {
+		return nil
+	}
+	var resp inventoryResponse
+	if err := plist.Unmarshal(ev.Raw, &resp); err != nil {
+		return errors.Wrapf(err, "decode %s response of %s", ev.Response.RequestType, dev.UDID)
+	}
+	if resp.empty() {
+		return nil
+	}
+
+	if resp.QueryResponses != nil {
+		var flags queryFlags
+		if err := plist.Unmarshal(ev.Raw, &flags); err != nil {
+			return errors.Wrapf(err, "decode DeviceInformation response of %s", dev.UDID)
+		}
+		updateDevice(dev, resp.QueryResponses, flags)
+		dev.LastQueryResponse = ev.Raw
+		dev.LastQueryResponseTime = ev.Time
+	}
+
+	inv, err := db.Inventory(dev.UDID)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if inv == nil {
+		inv = &device.Inventory{UDID: dev.UDID}
+	}
+	updated := false
+	if resp.InstalledApplicationList != nil {
+		inv.InstalledApplications = *resp.InstalledApplicationList
+		inv.InstalledApplicationsUpdated = ev.Time
+		updated = true
+	}
+	if resp.ProfileList != nil {
+		inv.Profiles = *resp.ProfileList
+		inv.ProfilesUpdated = ev.Time
+		updated = true
+	}
+	if resp.CertificateList != nil {
+		inv.Certificates = *resp.CertificateList
+		inv.CertificatesUpdated = ev.Time
+		updated = true
+	}
+	if resp.SecurityInfo != nil {
+		inv.SecurityInfo = resp.SecurityInfo
+		inv.SecurityInfoUpdated = ev.Time
+		updated = true
+	}
+	if resp.OSUpdateStatus != nil {
+		inv.OSUpdateStatus = *resp.OSUpdateStatus
+		inv.OSUpdateStatusUpdated = ev.Time
+		updated = true
+	}
+	if resp.AvailableOSUpdates != nil {
+		inv.AvailableOSUpdates = *resp.AvailableOSUpdates
+		inv.AvailableOSUpdatesUpdated = ev.Time
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	return db.saveInventory(inv)
+}
+
+// updateDevice copies the reported values of the DeviceInformation query
+// responses to the device. Queries which were not reported keep their value.
+func updateDevice(dev *device.Device, qr *mdm.QueryResponses, flags queryFlags) {
+	setString := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	setString(&dev.SerialNumber, qr.SerialNumber)
+	setString(&dev.DeviceName, qr.DeviceName)
+	setString(&dev.OSVersion, qr.OSVersion)
+	setString(&dev.BuildVersion, qr.BuildVersion)
+	setString(&dev.ProductName, qr.ProductName)
+	setString(&dev.Model, qr.Model)
+	setString(&dev.ModelName, qr.ModelName)
+	setString(&dev.IMEI, qr.IMEI)
+	setString(&dev.MEID, qr.MEID)
+	setString(&dev.WiFiMAC, qr.WiFiMAC)
+	setString(&dev.BluetoothMAC, qr.BluetoothMAC)
+	if qr.DeviceCapacity != 0 {
+		dev.DeviceCapacity = qr.DeviceCapacity
+		dev.AvailableDeviceCapacity = qr.AvailableDeviceCapacity
+	}
+	if qr.BatteryLevel != 0 {
+		dev.BatteryLevel = qr.BatteryLevel
+	}
+	setBool := func(dst *bool, v *bool) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	if f := flags.QueryResponses; f != nil {
+		setBool(&dev.IsSupervised, f.IsSupervised)
+		setBool(&dev.IsActivationLockEnabled, f.IsActivationLockEnabled)
+		setBool(&dev.AwaitingConfiguration, f.AwaitingConfiguration)
+	}
+}
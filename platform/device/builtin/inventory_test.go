@@ -0,0 +1,88 @@
+package builtin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/mdm/test"
+	"github.com/as/micromdm/platform/device"
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+func TestHandleConnect_Inventory(t *testing.T) {
+	db := setupDB(t)
+	udid := "1111111111111111111111111111111111111112"
+	dev := &device.Device{
+		UUID:         "a-b-c-d",
+		UDID:         udid,
+		IsSupervised: true,
+		AssetTag:     "asset-1",
+	}
+	if err := db.Save(dev); err != nil {
+		t.Fatal(err)
+	}
+
+	connectEvent := func(raw string) pubsub.Event {
+		ev := connect.NewEvent(connect.MDMConnectRequest{
+			MDMResponse: mdm.Response{UDID: udid, Status: "Acknowledged"},
+			Raw:         []byte(raw),
+		})
+		msg, err := connect.MarshalEvent(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pubsub.Event{Topic: connect.ConnectTopic, Message: msg}
+	}
+
+	if _, err := db.Inventory(udid); !isNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	if err := db.handleConnect(connectEvent(test.IOS8IphoneQueryResponses)); err != nil {
+		t.Fatal(err)
+	}
+	dev, err := db.DeviceByUDID(udid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := dev.SerialNumber, "000000000001"; have != want {
+		t.Errorf("have serial %q, want %q", have, want)
+	}
+	if have, want := dev.DeviceName, "micromdm-iphone6-ios8"; have != want {
+		t.Errorf("have device name %q, want %q", have, want)
+	}
+	if have, want := dev.OSVersion, "8.3"; have != want {
+		t.Errorf("have OS version %q, want %q", have, want)
+	}
+	if have, want := dev.BluetoothMAC, "de-ad-be-ef-ca-fe"; have != want {
+		t.Errorf("have bluetooth MAC %q, want %q", have, want)
+	}
+	if dev.IsSupervised {
+		t.Error("expected the reported IsSupervised value to be saved")
+	}
+	if have, want := dev.AssetTag, "asset-1"; have != want {
+		t.Errorf("have asset tag %q, want %q", have, want)
+	}
+	if dev.LastQueryResponseTime.IsZero() || len(dev.LastQueryResponse) == 0 {
+		t.Error("expected the last query response to be saved")
+	}
+
+	if err := db.handleConnect(connectEvent(test.IOS8IpadSecurityInfo)); err != nil {
+		t.Fatal(err)
+	}
+	inv, err := db.Inventory(udid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.SecurityInfo == nil || inv.SecurityInfoUpdated.IsZero() {
+		t.Fatalf("expected security info in inventory %+v", inv)
+	}
+	if inv.SecurityInfoUpdated.After(time.Now()) {
+		t.Errorf("security info updated in the future: %s", inv.SecurityInfoUpdated)
+	}
+	if inv.InstalledApplications != nil || !inv.InstalledApplicationsUpdated.IsZero() {
+		t.Errorf("unexpected installed applications in inventory %+v", inv)
+	}
+}
@@ -0,0 +1,147 @@
+package builtin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RobotsAndPencils/buford/push"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/platform/apns"
+	"github.com/as/micromdm/platform/device"
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+func TestHandlePush(t *testing.T) {
+	db := setupDB(t)
+	udid := "1111111111111111111111111111111111111113"
+	checkin := time.Now().Add(-time.Hour)
+	if err := db.Save(&device.Device{UUID: "a-b-c-e", UDID: udid, LastCheckin: checkin}); err != nil {
+		t.Fatal(err)
+	}
+
+	pushEvent := func(err error) pubsub.Event {
+		msg, merr := apns.MarshalPushEvent(apns.NewPushEvent(udid, "push-id", err))
+		if merr != nil {
+			t.Fatal(merr)
+		}
+		return pubsub.Event{Topic: apns.PushTopic, Message: msg}
+	}
+	handle := func(err error) *device.Device {
+		t.Helper()
+		if err := db.handlePush(pushEvent(err)); err != nil {
+			t.Fatal(err)
+		}
+		dev, err := db.DeviceByUDID(udid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dev
+	}
+
+	dev := handle(nil)
+	if dev.LastPushTime.IsZero() || dev.PushUnreachable {
+		t.Errorf("expected a reachable device with a last push time, got %v %v", dev.LastPushTime, dev.PushUnreachable)
+	}
+
+	dev = handle(errors.New("connection reset"))
+	if dev.PushUnreachable {
+		t.Error("expected a transient error to keep the device reachable")
+	}
+
+	dev = handle(&push.Error{Reason: push.ErrBadDeviceToken, Status: 400})
+	if dev.PushUnreachable {
+		t.Error("expected a BadDeviceToken error to keep the device reachable")
+	}
+
+	dev = handle(&push.Error{Reason: push.ErrUnregistered, Status: 410})
+	if !dev.PushUnreachable || dev.PushFailureReason != "Unregistered" || dev.PushFailedAt.IsZero() {
+		t.Errorf("expected an unreachable device, got %v %q %v", dev.PushUnreachable, dev.PushFailureReason, dev.PushFailedAt)
+	}
+
+	opt := device.ListDevicesOption{Selector: device.Selector{PushUnreachable: &dev.PushUnreachable}}
+	devices, _, err := db.ListDevices(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 1 || devices[0].UDID != udid {
+		t.Errorf("expected the unreachable device to be listed, got %d devices", len(devices))
+	}
+
+	reset, err := apns.MarshalPushEvent(&apns.PushEvent{Time: time.Now(), DeviceUDID: udid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.handlePushFailureReset(pubsub.Event{Topic: apns.PushFailureResetTopic, Message: reset}); err != nil {
+		t.Fatal(err)
+	}
+	if dev, _ = db.DeviceByUDID(udid); dev.PushUnreachable || dev.PushFailureReason != "" {
+		t.Errorf("expected the reset to make the device reachable, got %v %q", dev.PushUnreachable, dev.PushFailureReason)
+	}
+
+	handle(&push.Error{Reason: push.ErrUnregistered, Status: 410})
+	ev := connect.NewEvent(connect.MDMConnectRequest{MDMResponse: mdm.Response{UDID: udid, Status: "Idle"}})
+	msg, err := connect.MarshalEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.handleConnect(pubsub.Event{Topic: connect.ConnectTopic, Message: msg}); err != nil {
+		t.Fatal(err)
+	}
+	if dev, _ = db.DeviceByUDID(udid); dev.PushUnreachable {
+		t.Error("expected a connect to make the device reachable")
+	}
+}
+
+func TestReplayTokenUpdate(t *testing.T) {
+	db := setupDB(t)
+	udid := "1111111111111111111111111111111111111114"
+	updated := time.Now().Add(-time.Hour)
+	stored := &device.Device{
+		UUID:              "a-b-c-f",
+		UDID:              udid,
+		Token:             "01",
+		TokenUpdatedAt:    updated,
+		LastCheckin:       updated,
+		Enrolled:          true,
+		PushUnreachable:   true,
+		PushFailureReason: "Unregistered",
+		PushFailedAt:      updated,
+	}
+	if err := db.Save(stored); err != nil {
+		t.Fatal(err)
+	}
+
+	replay := func(token []byte, at time.Time) *device.Device {
+		t.Helper()
+		ev := checkin.NewEvent(mdm.CheckinCommand{MessageType: "TokenUpdate", UDID: udid})
+		ev.Command.Token = token
+		ev.Time = at
+		msg, err := checkin.MarshalEvent(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.handleReplay(pubsub.Event{Topic: checkin.ReplayTopic, Message: msg}); err != nil {
+			t.Fatal(err)
+		}
+		dev, err := db.DeviceByUDID(udid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dev
+	}
+
+	if dev := replay([]byte{0x02}, updated.Add(-time.Minute)); dev.Token != "01" {
+		t.Errorf("an older replayed TokenUpdate replaced the token with %s", dev.Token)
+	}
+	dev := replay([]byte{0x03}, updated.Add(time.Minute))
+	if dev.Token != "03" {
+		t.Errorf("have token %s after a newer replayed TokenUpdate, want 03", dev.Token)
+	}
+	if !dev.PushUnreachable || dev.PushFailureReason != "Unregistered" {
+		t.Errorf("a replayed TokenUpdate changed the push failure to %v %q", dev.PushUnreachable, dev.PushFailureReason)
+	}
+}
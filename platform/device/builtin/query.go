@@ -0,0 +1,264 @@
+package builtin
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/device"
+)
+
+// deviceSortIndexBucket holds a nested bucket for every device.SortFields
+// value. The keys of a nested bucket are the sort key of the field followed
+// by a zero byte and the device UUID, the values are the device UUIDs.
+const deviceSortIndexBucket = "mdm.DeviceSortIdx"
+
+// sortValue returns the value of the field which orders the devices.
+func sortValue(field string, dev *device.Device) []byte {
+	switch field {
+	case device.SortBySerial:
+		return []byte(dev.SerialNumber)
+	case device.SortByDeviceName:
+		return []byte(strings.ToLower(dev.DeviceName))
+	case device.SortByModel:
+		return []byte(dev.Model)
+	case device.SortByOSVersion:
+		return versionKey(dev.OSVersion)
+	case device.SortByLastSeen:
+		k := make([]byte, 8)
+		if !dev.LastCheckin.IsZero() {
+			binary.BigEndian.PutUint64(k, uint64(dev.LastCheckin.UnixNano()))
+		}
+		return k
+	default:
+		return []byte(dev.UDID)
+	}
+}
+
+// versionKey pads every number of an OS version, so that 10.13 sorts
+// after 9.3.
+func versionKey(version string) []byte {
+	parts := strings.Split(version, ".")
+	for i, p := range parts {
+		if n, err := strconv.Atoi(p); err == nil {
+			parts[i] = fmt.Sprintf("%06d", n)
+		}
+	}
+	return []byte(strings.Join(parts, "."))
+}
+
+func sortKey(field string, dev *device.Device) []byte {
+	key := sortValue(field, dev)
+	key = append(key, 0)
+	return append(key, dev.UUID...)
+}
+
+// updateSortIndexes replaces the sort keys of the old version of a device
+// with the keys of the new version.
+func updateSortIndexes(tx *bolt.Tx, old, dev *device.Device) error {
+	indexes := tx.Bucket([]byte(deviceSortIndexBucket))
+	for _, field := range device.SortFields {
+		b, err := indexes.CreateBucketIfNotExists([]byte(field))
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			if err := b.Delete(sortKey(field, old)); err != nil {
+				return err
+			}
+		}
+		if err := b.Put(sortKey(field, dev), []byte(dev.UUID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindex creates the sort indexes of the devices saved before the indexes
+// existed.
+func reindex(tx *bolt.Tx) error {
+	return tx.Bucket([]byte(DeviceBucket)).ForEach(func(k, v []byte) error {
+		var dev device.Device
+		if err := device.UnmarshalDevice(v, &dev); err != nil {
+			return err
+		}
+		return updateSortIndexes(tx, nil, &dev)
+	})
+}
+
+// ListDevices returns the devices matching the options, ordered by the sort
+// index of opt.SortBy, and the cursor of the next page.
+func (db *DB) ListDevices(opt device.ListDevicesOption) ([]device.Device, string, error) {
+	field := opt.SortBy
+	if field == "" {
+		field = device.SortByUDID
+	}
+	if !device.ValidSortField(field) {
+		return nil, "", fmt.Errorf("can not sort devices by %q", field)
+	}
+	var after []byte
+	if opt.Cursor != "" {
+		var err error
+		if after, err = base64.RawURLEncoding.DecodeString(opt.Cursor); err != nil {
+			return nil, "", errors.Wrap(err, "invalid cursor")
+		}
+	}
+	limit := opt.PerPage
+	var skip int
+	if opt.Cursor == "" && opt.Page > 1 && limit > 0 {
+		skip = (opt.Page - 1) * limit
+	}
+
+	var (
+		devices []device.Device
+		keys    [][]byte
+	)
+	// add collects a matching device and reports whether more devices are needed.
+	add := func(key []byte, dev device.Device) bool {
+		if !matchFilters(opt, dev) {
+			return true
+		}
+		if skip > 0 {
+			skip--
+			return true
+		}
+		devices = append(devices, dev)
+		keys = append(keys, key)
+		return limit <= 0 || len(devices) <= limit
+	}
+
+	err := db.View(func(tx *bolt.Tx) error {
+		if len(opt.FilterUDID) > 0 || len(opt.FilterSerial) > 0 {
+			return listFiltered(tx, opt, field, after, add)
+		}
+		index := tx.Bucket([]byte(deviceSortIndexBucket)).Bucket([]byte(field))
+		if index == nil {
+			return nil
+		}
+		b := tx.Bucket([]byte(DeviceBucket))
+		c := index.Cursor()
+		next := c.Next
+		var k, v []byte
+		switch {
+		case opt.SortDesc && after != nil:
+			if k, _ = c.Seek(after); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+			next = c.Prev
+		case opt.SortDesc:
+			k, v = c.Last()
+			next = c.Prev
+		case after != nil:
+			if k, v = c.Seek(after); k != nil && bytes.Equal(k, after) {
+				k, v = c.Next()
+			}
+		default:
+			k, v = c.First()
+		}
+		for ; k != nil; k, v = next() {
+			data := b.Get(v)
+			if data == nil {
+				continue
+			}
+			var dev device.Device
+			if err := device.UnmarshalDevice(data, &dev); err != nil {
+				return err
+			}
+			if !add(k, dev) {
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if limit > 0 && len(devices) > limit {
+		devices = devices[:limit]
+		next = base64.RawURLEncoding.EncodeToString(keys[limit-1])
+	}
+	return devices, next, nil
+}
+
+// listFiltered looks up the devices of the UDID or serial filter through the
+// device index instead of scanning a sort index.
+func listFiltered(tx *bolt.Tx, opt device.ListDevicesOption, field string, after []byte, add func([]byte, device.Device) bool) error {
+	lookup := opt.FilterUDID
+	if len(lookup) == 0 {
+		lookup = opt.FilterSerial
+	}
+	b := tx.Bucket([]byte(DeviceBucket))
+	idx := tx.Bucket([]byte(deviceIndexBucket))
+
+	type keyed struct {
+		key []byte
+		dev device.Device
+	}
+	var found []keyed
+	seen := make(map[string]bool)
+	for _, id := range lookup {
+		uuid := idx.Get([]byte(id))
+		if uuid == nil || seen[string(uuid)] {
+			continue
+		}
+		seen[string(uuid)] = true
+		data := b.Get(uuid)
+		if data == nil {
+			continue
+		}
+		var dev device.Device
+		if err := device.UnmarshalDevice(data, &dev); err != nil {
+			return err
+		}
+		found = append(found, keyed{key: sortKey(field, &dev), dev: dev})
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if opt.SortDesc {
+			return bytes.Compare(found[i].key, found[j].key) > 0
+		}
+		return bytes.Compare(found[i].key, found[j].key) < 0
+	})
+	for _, f := range found {
+		if after != nil {
+			cmp := bytes.Compare(f.key, after)
+			if (!opt.SortDesc && cmp <= 0) || (opt.SortDesc && cmp >= 0) {
+				continue
+			}
+		}
+		if !add(f.key, f.dev) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func matchFilters(opt device.ListDevicesOption, dev device.Device) bool {
+	if len(opt.FilterUDID) > 0 && !containsString(opt.FilterUDID, dev.UDID) {
+		return false
+	}
+	if len(opt.FilterSerial) > 0 && !containsString(opt.FilterSerial, dev.SerialNumber) {
+		return false
+	}
+	return opt.Selector.Match(dev)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package builtin
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/as/micromdm/platform/device"
+)
+
+func TestListDevices(t *testing.T) {
+	db := setupDB(t)
+	devices := []device.Device{
+		{UUID: "uuid-1", UDID: "udid-1", SerialNumber: "serial-1", OSVersion: "10.13.2", Enrolled: true},
+		{UUID: "uuid-2", UDID: "udid-2", SerialNumber: "serial-2", OSVersion: "9.3", Enrolled: true},
+		{UUID: "uuid-3", UDID: "udid-3", SerialNumber: "serial-3", OSVersion: "11.2", Enrolled: false},
+		{UUID: "uuid-4", UDID: "udid-4", SerialNumber: "serial-4", OSVersion: "10.12", Enrolled: true},
+		{UUID: "uuid-5", UDID: "udid-5", SerialNumber: "serial-5", OSVersion: "10.13", Enrolled: true},
+	}
+	for i := range devices {
+		devices[i].LastCheckin = time.Unix(int64(100-i), 0)
+		if err := db.Save(&devices[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	enrolled := true
+
+	tests := []struct {
+		name string
+		opt  device.ListDevicesOption
+		want []string
+	}{
+		{
+			name: "default",
+			want: []string{"udid-1", "udid-2", "udid-3", "udid-4", "udid-5"},
+		},
+		{
+			name: "os version",
+			opt:  device.ListDevicesOption{SortBy: device.SortByOSVersion},
+			want: []string{"udid-2", "udid-4", "udid-5", "udid-1", "udid-3"},
+		},
+		{
+			name: "last seen desc",
+			opt:  device.ListDevicesOption{SortBy: device.SortByLastSeen, SortDesc: true},
+			want: []string{"udid-1", "udid-2", "udid-3", "udid-4", "udid-5"},
+		},
+		{
+			name: "selector",
+			opt:  device.ListDevicesOption{Selector: device.Selector{OSVersion: "10", Enrolled: &enrolled}},
+			want: []string{"udid-1", "udid-4", "udid-5"},
+		},
+		{
+			name: "serial",
+			opt:  device.ListDevicesOption{FilterSerial: []string{"serial-4", "serial-2"}, SortDesc: true},
+			want: []string{"udid-4", "udid-2"},
+		},
+		{
+			name: "serial and udid",
+			opt:  device.ListDevicesOption{FilterSerial: []string{"serial-4", "serial-2"}, FilterUDID: []string{"udid-2", "udid-3"}},
+			want: []string{"udid-2"},
+		},
+		{
+			name: "page",
+			opt:  device.ListDevicesOption{Page: 2, PerPage: 2},
+			want: []string{"udid-3", "udid-4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have, _, err := db.ListDevices(tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(udids(have)) != fmt.Sprint(tt.want) {
+				t.Errorf("have %v, want %v", udids(have), tt.want)
+			}
+		})
+	}
+}
+
+func TestListDevices_Cursor(t *testing.T) {
+	db := setupDB(t)
+	for i := 0; i < 7; i++ {
+		dev := &device.Device{
+			UUID:       fmt.Sprintf("uuid-%d", i),
+			UDID:       fmt.Sprintf("udid-%d", i),
+			DeviceName: fmt.Sprintf("Device %d", i%3),
+		}
+		if err := db.Save(dev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, desc := range []bool{false, true} {
+		opt := device.ListDevicesOption{SortBy: device.SortByDeviceName, SortDesc: desc, PerPage: 2}
+		var all []device.Device
+		for {
+			page, next, err := db.ListDevices(opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			all = append(all, page...)
+			if next == "" {
+				break
+			}
+			opt.Cursor = next
+		}
+		want := []string{"udid-0", "udid-3", "udid-6", "udid-1", "udid-4", "udid-2", "udid-5"}
+		if desc {
+			for i, j := 0, len(want)-1; i < j; i, j = i+1, j-1 {
+				want[i], want[j] = want[j], want[i]
+			}
+		}
+		if fmt.Sprint(udids(all)) != fmt.Sprint(want) {
+			t.Errorf("desc=%v: have %v, want %v", desc, udids(all), want)
+		}
+	}
+}
+
+func TestListDevices_Reindex(t *testing.T) {
+	db := setupDB(t)
+	dev := &device.Device{UUID: "uuid-1", UDID: "udid-1", SerialNumber: "b"}
+	if err := db.Save(dev); err != nil {
+		t.Fatal(err)
+	}
+	dev.SerialNumber = "a"
+	if err := db.Save(dev); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Save(&device.Device{UUID: "uuid-2", UDID: "udid-2", SerialNumber: "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	have, _, err := db.ListDevices(device.ListDevicesOption{SortBy: device.SortBySerial})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"udid-1", "udid-2"}; fmt.Sprint(udids(have)) != fmt.Sprint(want) {
+		t.Fatalf("have %v after update, want %v", udids(have), want)
+	}
+
+	// drop the indexes to rebuild them like for a database of an older version.
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket([]byte(deviceSortIndexBucket))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err = NewDB(db.DB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, _, err = db.ListDevices(device.ListDevicesOption{SortBy: device.SortBySerial})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"udid-1", "udid-2"}; fmt.Sprint(udids(have)) != fmt.Sprint(want) {
+		t.Errorf("have %v, want %v", udids(have), want)
+	}
+}
+
+func udids(devices []device.Device) []string {
+	var ids []string
+	for _, d := range devices {
+		ids = append(ids, d.UDID)
+	}
+	return ids
+}
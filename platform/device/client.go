@@ -20,15 +20,27 @@ func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransp
 	{
 		listDevicesEndpoint = httptransport.NewClient(
 			"GET",
-			httputil.CopyURL(u, "/v1/devices"),
-			httputil.EncodeRequestWithToken(token, httptransport.EncodeJSONRequest),
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeListDevicesRequest),
 			decodeListDevicesResponse,
 			opts...,
 		).Endpoint()
 	}
 
+	var getInventoryEndpoint endpoint.Endpoint
+	{
+		getInventoryEndpoint = httptransport.NewClient(
+			"GET",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeGetInventoryRequest),
+			decodeGetInventoryResponse,
+			opts...,
+		).Endpoint()
+	}
+
 	return Endpoints{
-		ListDevicesEndpoint: listDevicesEndpoint,
+		ListDevicesEndpoint:  listDevicesEndpoint,
+		GetInventoryEndpoint: getInventoryEndpoint,
 	}, nil
 
 }
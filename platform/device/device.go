@@ -41,6 +41,28 @@ type Device struct {
 	DEPProfileAssignedBy   string
 	LastCheckin            time.Time
 	LastQueryResponse      []byte
+
+	// Updated from the QueryResponses of DeviceInformation commands.
+	IsSupervised            bool
+	IsActivationLockEnabled bool
+	DeviceCapacity          float32
+	AvailableDeviceCapacity float32
+	BatteryLevel            float32
+	WiFiMAC                 string
+	BluetoothMAC            string
+	LastQueryResponseTime   time.Time
+
+	// Push health, updated from the results of push notifications.
+	// A device is PushUnreachable once APNs rejected its token, until
+	// the next TokenUpdate or Connect, or until the failure is reset.
+	LastPushTime      time.Time
+	PushUnreachable   bool
+	PushFailureReason string
+	PushFailedAt      time.Time
+
+	// TokenUpdatedAt is the time of the TokenUpdate which saved the Token,
+	// PushMagic and UnlockToken.
+	TokenUpdatedAt time.Time
 }
 
 // DEPProfileStatus is the status of the DEP Profile
@@ -86,6 +108,22 @@ func MarshalDevice(dev *Device) ([]byte, error) {
 		DepProfileAssignedBy:   dev.DEPProfileAssignedBy,
 		LastCheckIn:            timeToNano(dev.LastCheckin),
 		LastQueryResponse:      dev.LastQueryResponse,
+
+		IsSupervised:            dev.IsSupervised,
+		IsActivationLockEnabled: dev.IsActivationLockEnabled,
+		DeviceCapacity:          dev.DeviceCapacity,
+		AvailableDeviceCapacity: dev.AvailableDeviceCapacity,
+		BatteryLevel:            dev.BatteryLevel,
+		WifiMac:                 dev.WiFiMAC,
+		BluetoothMac:            dev.BluetoothMAC,
+		LastQueryResponseTime:   timeToNano(dev.LastQueryResponseTime),
+
+		LastPushTime:      timeToNano(dev.LastPushTime),
+		PushUnreachable:   dev.PushUnreachable,
+		PushFailureReason: dev.PushFailureReason,
+		PushFailedAt:      timeToNano(dev.PushFailedAt),
+
+		TokenUpdatedAt: timeToNano(dev.TokenUpdatedAt),
 	}
 	return proto.Marshal(&protodev)
 }
@@ -124,6 +162,19 @@ func UnmarshalDevice(data []byte, dev *Device) error {
 	dev.DEPProfileAssignedBy = pb.GetDepProfileAssignedBy()
 	dev.LastCheckin = timeFromNano(pb.GetLastCheckIn())
 	dev.LastQueryResponse = pb.GetLastQueryResponse()
+	dev.IsSupervised = pb.GetIsSupervised()
+	dev.IsActivationLockEnabled = pb.GetIsActivationLockEnabled()
+	dev.DeviceCapacity = pb.GetDeviceCapacity()
+	dev.AvailableDeviceCapacity = pb.GetAvailableDeviceCapacity()
+	dev.BatteryLevel = pb.GetBatteryLevel()
+	dev.WiFiMAC = pb.GetWifiMac()
+	dev.BluetoothMAC = pb.GetBluetoothMac()
+	dev.LastQueryResponseTime = timeFromNano(pb.GetLastQueryResponseTime())
+	dev.LastPushTime = timeFromNano(pb.GetLastPushTime())
+	dev.PushUnreachable = pb.GetPushUnreachable()
+	dev.PushFailureReason = pb.GetPushFailureReason()
+	dev.PushFailedAt = timeFromNano(pb.GetPushFailedAt())
+	dev.TokenUpdatedAt = timeFromNano(pb.GetTokenUpdatedAt())
 	return nil
 }
 
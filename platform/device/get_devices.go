@@ -3,55 +3,234 @@ package device
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/pkg/errors"
 
 	"github.com/as/micromdm/pkg/httputil"
 )
 
+// The fields devices can be sorted by.
+const (
+	SortByUDID       = "udid"
+	SortBySerial     = "serial_number"
+	SortByDeviceName = "device_name"
+	SortByModel      = "model"
+	SortByOSVersion  = "os_version"
+	SortByLastSeen   = "last_seen"
+)
+
+// SortFields are the valid values of ListDevicesOption.SortBy.
+var SortFields = []string{SortByUDID, SortBySerial, SortByDeviceName, SortByModel, SortByOSVersion, SortByLastSeen}
+
+// ValidSortField reports whether devices can be sorted by the field.
+func ValidSortField(field string) bool {
+	for _, f := range SortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 type ListDevicesOption struct {
-	Page    int `json:"page"`
+	// Page is the 1 based page of PerPage devices to return.
+	// It is ignored if Cursor is set.
+	Page int `json:"page"`
+
+	// PerPage limits the number of devices returned. Zero returns all
+	// devices.
 	PerPage int `json:"per_page"`
 
+	// Cursor continues after the last device of a previous page.
+	Cursor string `json:"cursor,omitempty"`
+
+	// SortBy is one of SortFields. Devices are sorted by UDID by default.
+	SortBy   string `json:"sort_by,omitempty"`
+	SortDesc bool   `json:"sort_desc,omitempty"`
+
 	FilterSerial []string `json:"filter_serial"`
 	FilterUDID   []string `json:"filter_udid"`
+
+	// Selector matches the model, OS version, enrollment and DEP status.
+	Selector Selector `json:"selector"`
+}
+
+// Devices is a page of devices.
+type Devices struct {
+	Devices []DeviceDTO `json:"devices"`
+
+	// NextCursor is set if there are more devices matching the query.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type DeviceDTO struct {
-	SerialNumber     string    `json:"serial_number"`
-	UDID             string    `json:"udid"`
-	EnrollmentStatus bool      `json:"enrollment_status"`
-	LastSeen         time.Time `json:"last_seen"`
+	SerialNumber     string           `json:"serial_number"`
+	UDID             string           `json:"udid"`
+	EnrollmentStatus bool             `json:"enrollment_status"`
+	LastSeen         time.Time        `json:"last_seen"`
+	DeviceName       string           `json:"device_name,omitempty"`
+	Model            string           `json:"model,omitempty"`
+	ModelName        string           `json:"model_name,omitempty"`
+	ProductName      string           `json:"product_name,omitempty"`
+	OSVersion        string           `json:"os_version,omitempty"`
+	BuildVersion     string           `json:"build_version,omitempty"`
+	IsSupervised     bool             `json:"is_supervised"`
+	DEPDevice        bool             `json:"dep_device"`
+	DEPProfileStatus DEPProfileStatus `json:"dep_profile_status,omitempty"`
+	DEPProfileUUID   string           `json:"dep_profile_uuid,omitempty"`
+	AssetTag         string           `json:"asset_tag,omitempty"`
+
+	// Push health. PushUnreachable devices are not pushed to until they
+	// send a new push token or connect, or the failure is reset.
+	LastPush          time.Time `json:"last_push"`
+	PushUnreachable   bool      `json:"push_unreachable"`
+	PushFailureReason string    `json:"push_failure_reason,omitempty"`
+	PushFailedAt      time.Time `json:"push_failed_at"`
 }
 
-func (svc *DeviceService) ListDevices(ctx context.Context, opt ListDevicesOption) ([]DeviceDTO, error) {
-	devices, err := svc.store.List()
-	var dto []DeviceDTO
+func newDeviceDTO(d Device) DeviceDTO {
+	return DeviceDTO{
+		SerialNumber:     d.SerialNumber,
+		UDID:             d.UDID,
+		EnrollmentStatus: d.Enrolled,
+		LastSeen:         d.LastCheckin,
+		DeviceName:       d.DeviceName,
+		Model:            d.Model,
+		ModelName:        d.ModelName,
+		ProductName:      d.ProductName,
+		OSVersion:        d.OSVersion,
+		BuildVersion:     d.BuildVersion,
+		IsSupervised:     d.IsSupervised,
+		DEPDevice:        d.DEPDevice,
+		DEPProfileStatus: d.DEPProfileStatus,
+		DEPProfileUUID:   d.DEPProfileUUID,
+		AssetTag:         d.AssetTag,
+
+		LastPush:          d.LastPushTime,
+		PushUnreachable:   d.PushUnreachable,
+		PushFailureReason: d.PushFailureReason,
+		PushFailedAt:      d.PushFailedAt,
+	}
+}
+
+func (svc *DeviceService) ListDevices(ctx context.Context, opt ListDevicesOption) (*Devices, error) {
+	if opt.SortBy != "" && !ValidSortField(opt.SortBy) {
+		return nil, errors.Errorf("can not sort devices by %q", opt.SortBy)
+	}
+	devices, next, err := svc.store.ListDevices(opt)
+	if err != nil {
+		return nil, errors.Wrap(err, "list devices")
+	}
+	page := Devices{
+		Devices:    make([]DeviceDTO, 0, len(devices)),
+		NextCursor: next,
+	}
 	for _, d := range devices {
-		dto = append(dto, DeviceDTO{
-			SerialNumber:     d.SerialNumber,
-			UDID:             d.UDID,
-			EnrollmentStatus: d.Enrolled,
-			LastSeen:         d.LastCheckin,
-		})
-	}
-	return dto, err
+		page.Devices = append(page.Devices, newDeviceDTO(d))
+	}
+	return &page, nil
 }
 
 type getDevicesRequest struct{ Opts ListDevicesOption }
 type getDevicesResponse struct {
-	Devices []DeviceDTO `json:"devices"`
-	Err     error       `json:"err,omitempty"`
+	*Devices
+	Err error `json:"err,omitempty"`
 }
 
 func (r getDevicesResponse) Failed() error { return r.Err }
 
+// decodeListDevicesRequest reads the options from the URL parameters
+// serial and udid (repeated), model, os_version, enrolled, dep_device,
+// push_unreachable, sort_by, sort_desc, per_page, page and cursor.
 func decodeListDevicesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	defer r.Body.Close()
-	var opts ListDevicesOption
-	req := getDevicesRequest{Opts: opts}
-	return req, nil
+	values := r.URL.Query()
+	opts := ListDevicesOption{
+		Cursor:       values.Get("cursor"),
+		SortBy:       values.Get("sort_by"),
+		FilterSerial: values["serial"],
+		FilterUDID:   values["udid"],
+		Selector: Selector{
+			Model:     values.Get("model"),
+			OSVersion: values.Get("os_version"),
+		},
+	}
+	var err error
+	if opts.Page, err = intParam(values, "page"); err != nil {
+		return nil, err
+	}
+	if opts.PerPage, err = intParam(values, "per_page"); err != nil {
+		return nil, err
+	}
+	if opts.SortDesc, err = boolParam(values, "sort_desc"); err != nil {
+		return nil, err
+	}
+	if v := values.Get("enrolled"); v != "" {
+		enrolled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse enrolled")
+		}
+		opts.Selector.Enrolled = &enrolled
+	}
+	if v := values.Get("dep_device"); v != "" {
+		dep, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse dep_device")
+		}
+		opts.Selector.DEPDevice = &dep
+	}
+	if v := values.Get("push_unreachable"); v != "" {
+		unreachable, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse push_unreachable")
+		}
+		opts.Selector.PushUnreachable = &unreachable
+	}
+	return getDevicesRequest{Opts: opts}, nil
+}
+
+func encodeListDevicesRequest(_ context.Context, r *http.Request, request interface{}) error {
+	opts := request.(getDevicesRequest).Opts
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("cursor", opts.Cursor)
+	set("sort_by", opts.SortBy)
+	set("model", opts.Selector.Model)
+	set("os_version", opts.Selector.OSVersion)
+	for _, serial := range opts.FilterSerial {
+		values.Add("serial", serial)
+	}
+	for _, udid := range opts.FilterUDID {
+		values.Add("udid", udid)
+	}
+	if opts.Page > 0 {
+		values.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		values.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.SortDesc {
+		values.Set("sort_desc", "true")
+	}
+	if opts.Selector.Enrolled != nil {
+		values.Set("enrolled", strconv.FormatBool(*opts.Selector.Enrolled))
+	}
+	if opts.Selector.DEPDevice != nil {
+		values.Set("dep_device", strconv.FormatBool(*opts.Selector.DEPDevice))
+	}
+	if opts.Selector.PushUnreachable != nil {
+		values.Set("push_unreachable", strconv.FormatBool(*opts.Selector.PushUnreachable))
+	}
+	r.Method, r.URL.Path, r.URL.RawQuery = "GET", "/v1/devices", values.Encode()
+	return nil
 }
 
 func decodeListDevicesResponse(_ context.Context, r *http.Response) (interface{}, error) {
@@ -63,19 +242,37 @@ func decodeListDevicesResponse(_ context.Context, r *http.Response) (interface{}
 func MakeListDevicesEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(getDevicesRequest)
-		dto, err := svc.ListDevices(ctx, req.Opts)
+		devices, err := svc.ListDevices(ctx, req.Opts)
 		return getDevicesResponse{
-			Devices: dto,
+			Devices: devices,
 			Err:     err,
 		}, nil
 	}
 }
 
-func (e Endpoints) ListDevices(ctx context.Context, opts ListDevicesOption) ([]DeviceDTO, error) {
+func (e Endpoints) ListDevices(ctx context.Context, opts ListDevicesOption) (*Devices, error) {
 	request := getDevicesRequest{opts}
-	response, err := e.ListDevicesEndpoint(ctx, request.Opts)
+	response, err := e.ListDevicesEndpoint(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 	return response.(getDevicesResponse).Devices, response.(getDevicesResponse).Err
 }
+
+func intParam(values url.Values, key string) (int, error) {
+	v := values.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	return n, errors.Wrapf(err, "parse %s", key)
+}
+
+func boolParam(values url.Values, key string) (bool, error) {
+	v := values.Get(key)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	return b, errors.Wrapf(err, "parse %s", key)
+}
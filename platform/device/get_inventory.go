@@ -0,0 +1,68 @@
+package device
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+func (svc *DeviceService) GetInventory(ctx context.Context, udid string) (*Inventory, error) {
+	inv, err := svc.store.Inventory(udid)
+	return inv, errors.Wrapf(err, "get inventory of device %s", udid)
+}
+
+type getInventoryRequest struct {
+	UDID string
+}
+
+type getInventoryResponse struct {
+	Inventory *Inventory `json:"inventory,omitempty"`
+	Err       error      `json:"err,omitempty"`
+}
+
+func (r getInventoryResponse) Failed() error { return r.Err }
+
+func decodeGetInventoryRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var errBadRoute = errors.New("bad route")
+	vars := mux.Vars(r)
+	udid, ok := vars["udid"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	return getInventoryRequest{UDID: udid}, nil
+}
+
+func encodeGetInventoryRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(getInventoryRequest)
+	r.Method, r.URL.Path = "GET", "/v1/devices/"+url.QueryEscape(req.UDID)+"/inventory"
+	return nil
+}
+
+func decodeGetInventoryResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp getInventoryResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeGetInventoryEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(getInventoryRequest)
+		inv, err := svc.GetInventory(ctx, req.UDID)
+		return getInventoryResponse{Inventory: inv, Err: err}, nil
+	}
+}
+
+func (e Endpoints) GetInventory(ctx context.Context, udid string) (*Inventory, error) {
+	resp, err := e.GetInventoryEndpoint(ctx, getInventoryRequest{UDID: udid})
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(getInventoryResponse)
+	return response.Inventory, response.Err
+}
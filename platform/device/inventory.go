@@ -0,0 +1,230 @@
+package device
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/platform/device/internal/deviceproto"
+)
+
+// Inventory is the latest result of the inventory commands sent to a device.
+// Every list is updated as a whole when the device acknowledges the command
+// which returns it.
+type Inventory struct {
+	UDID string `json:"udid"`
+
+	InstalledApplications        mdm.InstalledApplicationListResponse `json:"installed_applications,omitempty"`
+	InstalledApplicationsUpdated time.Time                            `json:"installed_applications_updated,omitempty"`
+
+	Profiles        mdm.ProfileList `json:"profiles,omitempty"`
+	ProfilesUpdated time.Time       `json:"profiles_updated,omitempty"`
+
+	Certificates        mdm.CertificateList `json:"certificates,omitempty"`
+	CertificatesUpdated time.Time           `json:"certificates_updated,omitempty"`
+
+	SecurityInfo        *mdm.SecurityInfo `json:"security_info,omitempty"`
+	SecurityInfoUpdated time.Time         `json:"security_info_updated,omitempty"`
+
+	OSUpdateStatus        mdm.OSUpdateStatusResponse `json:"os_update_status,omitempty"`
+	OSUpdateStatusUpdated time.Time                  `json:"os_update_status_updated,omitempty"`
+
+	AvailableOSUpdates        mdm.AvailableOSUpdatesResponse `json:"available_os_updates,omitempty"`
+	AvailableOSUpdatesUpdated time.Time                      `json:"available_os_updates_updated,omitempty"`
+}
+
+func MarshalInventory(inv *Inventory) ([]byte, error) {
+	pb := deviceproto.Inventory{
+		Udid:                         inv.UDID,
+		InstalledApplicationsUpdated: timeToNano(inv.InstalledApplicationsUpdated),
+		ProfilesUpdated:              timeToNano(inv.ProfilesUpdated),
+		CertificatesUpdated:          timeToNano(inv.CertificatesUpdated),
+		SecurityInfoUpdated:          timeToNano(inv.SecurityInfoUpdated),
+		OsUpdateStatusUpdated:        timeToNano(inv.OSUpdateStatusUpdated),
+		AvailableOsUpdatesUpdated:    timeToNano(inv.AvailableOSUpdatesUpdated),
+	}
+	for _, app := range inv.InstalledApplications {
+		pb.InstalledApplications = append(pb.InstalledApplications, &deviceproto.InstalledApplication{
+			Identifier:   app.Identifier,
+			Version:      app.Version,
+			ShortVersion: app.ShortVersion,
+			Name:         app.Name,
+			BundleSize:   app.BundleSize,
+			DynamicSize:  app.DynamicSize,
+			IsValidated:  app.IsValidated,
+		})
+	}
+	for _, p := range inv.Profiles {
+		profile := &deviceproto.Profile{
+			HasRemovalPasscode:       p.HasRemovalPasscode,
+			IsEncrypted:              p.IsEncrypted,
+			PayloadDescription:       p.PayloadDescription,
+			PayloadDisplayName:       p.PayloadDisplayName,
+			PayloadIdentifier:        p.PayloadIdentifier,
+			PayloadOrganization:      p.PayloadOrganization,
+			PayloadRemovalDisallowed: p.PayloadRemovalDisallowed,
+			PayloadUuid:              p.PayloadUUID,
+			PayloadVersion:           int64(p.PayloadVersion),
+		}
+		for _, c := range p.PayloadContent {
+			profile.PayloadContent = append(profile.PayloadContent, &deviceproto.PayloadContent{
+				PayloadDescription:  c.PayloadDescription,
+				PayloadDisplayName:  c.PayloadDisplayName,
+				PayloadIdentifier:   c.PayloadIdentifier,
+				PayloadOrganization: c.PayloadOrganization,
+				PayloadVersion:      int64(c.PayloadVersion),
+			})
+		}
+		pb.Profiles = append(pb.Profiles, profile)
+	}
+	for _, c := range inv.Certificates {
+		pb.Certificates = append(pb.Certificates, &deviceproto.Certificate{
+			CommonName: c.CommonName,
+			Data:       c.Data,
+			IsIdentity: c.IsIdentity,
+		})
+	}
+	if si := inv.SecurityInfo; si != nil {
+		pb.SecurityInfo = &deviceproto.SecurityInfo{
+			FdeEnabled:                     si.FDEEnabled,
+			FdeHasPersonalRecoveryKey:      si.FDEHasPersonalRecoveryKey,
+			FdeHasInstitutionalRecoveryKey: si.FDEHasInstitutionalRecoveryKey,
+			HardwareEncryptionCaps:         int64(si.HardwareEncryptionCaps),
+			PasscodeCompliant:              si.PasscodeCompliant,
+			PasscodeCompliantWithProfiles:  si.PasscodeCompliantWithProfiles,
+			PasscodePresent:                si.PasscodePresent,
+		}
+	}
+	for _, s := range inv.OSUpdateStatus {
+		pb.OsUpdateStatus = append(pb.OsUpdateStatus, &deviceproto.OSUpdateStatus{
+			ProductKey:              s.ProductKey,
+			IsDownloaded:            s.IsDownloaded,
+			DownloadPercentComplete: s.DownloadPercentComplete,
+			Status:                  s.Status,
+		})
+	}
+	for _, u := range inv.AvailableOSUpdates {
+		pb.AvailableOsUpdates = append(pb.AvailableOsUpdates, &deviceproto.AvailableOSUpdate{
+			ProductKey:                u.ProductKey,
+			HumanReadableName:         u.HumanReadableName,
+			ProductName:               u.ProductName,
+			Version:                   u.Version,
+			Build:                     u.Build,
+			DownloadSize:              int64(u.DownloadSize),
+			InstallSize:               u.InstallSize,
+			AppIdentifiersToClose:     u.AppIdentifiersToClose,
+			IsCritical:                u.IsCritical,
+			IsConfigurationDataUpdate: u.IsConfigurationDataUpdate,
+			IsFirmwareUpdate:          u.IsFirmwareUpdate,
+			RestartRequired:           u.RestartRequired,
+			AllowsInstallLater:        u.AllowsInstallLater,
+		})
+	}
+	return proto.Marshal(&pb)
+}
+
+func UnmarshalInventory(data []byte, inv *Inventory) error {
+	var pb deviceproto.Inventory
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal proto to inventory")
+	}
+	inv.UDID = pb.GetUdid()
+
+	inv.InstalledApplications = nil
+	for _, app := range pb.GetInstalledApplications() {
+		inv.InstalledApplications = append(inv.InstalledApplications, mdm.InstalledApplicationListItem{
+			Identifier:   app.GetIdentifier(),
+			Version:      app.GetVersion(),
+			ShortVersion: app.GetShortVersion(),
+			Name:         app.GetName(),
+			BundleSize:   app.GetBundleSize(),
+			DynamicSize:  app.GetDynamicSize(),
+			IsValidated:  app.GetIsValidated(),
+		})
+	}
+	inv.InstalledApplicationsUpdated = timeFromNano(pb.GetInstalledApplicationsUpdated())
+
+	inv.Profiles = nil
+	for _, p := range pb.GetProfiles() {
+		profile := mdm.ProfileListItem{
+			HasRemovalPasscode:       p.GetHasRemovalPasscode(),
+			IsEncrypted:              p.GetIsEncrypted(),
+			PayloadDescription:       p.GetPayloadDescription(),
+			PayloadDisplayName:       p.GetPayloadDisplayName(),
+			PayloadIdentifier:        p.GetPayloadIdentifier(),
+			PayloadOrganization:      p.GetPayloadOrganization(),
+			PayloadRemovalDisallowed: p.GetPayloadRemovalDisallowed(),
+			PayloadUUID:              p.GetPayloadUuid(),
+			PayloadVersion:           int(p.GetPayloadVersion()),
+		}
+		for _, c := range p.GetPayloadContent() {
+			profile.PayloadContent = append(profile.PayloadContent, mdm.PayloadContentItem{
+				PayloadDescription:  c.GetPayloadDescription(),
+				PayloadDisplayName:  c.GetPayloadDisplayName(),
+				PayloadIdentifier:   c.GetPayloadIdentifier(),
+				PayloadOrganization: c.GetPayloadOrganization(),
+				PayloadVersion:      int(c.GetPayloadVersion()),
+			})
+		}
+		inv.Profiles = append(inv.Profiles, profile)
+	}
+	inv.ProfilesUpdated = timeFromNano(pb.GetProfilesUpdated())
+
+	inv.Certificates = nil
+	for _, c := range pb.GetCertificates() {
+		inv.Certificates = append(inv.Certificates, mdm.CertificateListItem{
+			CommonName: c.GetCommonName(),
+			Data:       c.GetData(),
+			IsIdentity: c.GetIsIdentity(),
+		})
+	}
+	inv.CertificatesUpdated = timeFromNano(pb.GetCertificatesUpdated())
+
+	inv.SecurityInfo = nil
+	if si := pb.GetSecurityInfo(); si != nil {
+		inv.SecurityInfo = &mdm.SecurityInfo{
+			FDEEnabled:                     si.GetFdeEnabled(),
+			FDEHasPersonalRecoveryKey:      si.GetFdeHasPersonalRecoveryKey(),
+			FDEHasInstitutionalRecoveryKey: si.GetFdeHasInstitutionalRecoveryKey(),
+			HardwareEncryptionCaps:         int(si.GetHardwareEncryptionCaps()),
+			PasscodeCompliant:              si.GetPasscodeCompliant(),
+			PasscodeCompliantWithProfiles:  si.GetPasscodeCompliantWithProfiles(),
+			PasscodePresent:                si.GetPasscodePresent(),
+		}
+	}
+	inv.SecurityInfoUpdated = timeFromNano(pb.GetSecurityInfoUpdated())
+
+	inv.OSUpdateStatus = nil
+	for _, s := range pb.GetOsUpdateStatus() {
+		inv.OSUpdateStatus = append(inv.OSUpdateStatus, mdm.OSUpdateStatusResponseItem{
+			ProductKey:              s.GetProductKey(),
+			IsDownloaded:            s.GetIsDownloaded(),
+			DownloadPercentComplete: s.GetDownloadPercentComplete(),
+			Status:                  s.GetStatus(),
+		})
+	}
+	inv.OSUpdateStatusUpdated = timeFromNano(pb.GetOsUpdateStatusUpdated())
+
+	inv.AvailableOSUpdates = nil
+	for _, u := range pb.GetAvailableOsUpdates() {
+		inv.AvailableOSUpdates = append(inv.AvailableOSUpdates, mdm.AvailableOSUpdatesResponseItem{
+			ProductKey:                u.GetProductKey(),
+			HumanReadableName:         u.GetHumanReadableName(),
+			ProductName:               u.GetProductName(),
+			Version:                   u.GetVersion(),
+			Build:                     u.GetBuild(),
+			DownloadSize:              int(u.GetDownloadSize()),
+			InstallSize:               u.GetInstallSize(),
+			AppIdentifiersToClose:     u.GetAppIdentifiersToClose(),
+			IsCritical:                u.GetIsCritical(),
+			IsConfigurationDataUpdate: u.GetIsConfigurationDataUpdate(),
+			IsFirmwareUpdate:          u.GetIsFirmwareUpdate(),
+			RestartRequired:           u.GetRestartRequired(),
+			AllowsInstallLater:        u.GetAllowsInstallLater(),
+		})
+	}
+	inv.AvailableOSUpdatesUpdated = timeFromNano(pb.GetAvailableOsUpdatesUpdated())
+	return nil
+}
@@ -0,0 +1,53 @@
+package device
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/as/micromdm/mdm"
+)
+
+func TestMarshalInventory(t *testing.T) {
+	now := time.Now().UTC()
+	inv := &Inventory{
+		UDID: "1111111111111111111111111111111111111112",
+		InstalledApplications: mdm.InstalledApplicationListResponse{
+			{Identifier: "com.example.app", Version: "12", ShortVersion: "1.2", Name: "App", BundleSize: 1024, IsValidated: true},
+		},
+		InstalledApplicationsUpdated: now,
+		Profiles: mdm.ProfileList{{
+			PayloadIdentifier: "com.example.profile",
+			PayloadUUID:       "a-b-c-d",
+			PayloadVersion:    1,
+			PayloadContent: []mdm.PayloadContentItem{
+				{PayloadIdentifier: "com.example.profile.wifi", PayloadVersion: 1},
+			},
+		}},
+		ProfilesUpdated:     now,
+		Certificates:        mdm.CertificateList{{CommonName: "example", Data: []byte("cert"), IsIdentity: true}},
+		CertificatesUpdated: now,
+		SecurityInfo:        &mdm.SecurityInfo{HardwareEncryptionCaps: 3, PasscodePresent: true},
+		SecurityInfoUpdated: now,
+		OSUpdateStatus: mdm.OSUpdateStatusResponse{
+			{ProductKey: "iOS11.4", IsDownloaded: true, DownloadPercentComplete: 0.5, Status: "Downloading"},
+		},
+		OSUpdateStatusUpdated: now,
+		AvailableOSUpdates: mdm.AvailableOSUpdatesResponse{
+			{ProductKey: "iOS11.4", Version: "11.4", DownloadSize: 100, InstallSize: 200, AppIdentifiersToClose: []string{"com.example.app"}, RestartRequired: true},
+		},
+		AvailableOSUpdatesUpdated: now,
+	}
+
+	data, err := MarshalInventory(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have Inventory
+	if err := UnmarshalInventory(data, &have); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&have, inv) {
+		t.Errorf("have inventory %+v, want %+v", have, inv)
+	}
+}
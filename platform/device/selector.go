@@ -0,0 +1,46 @@
+package device
+
+import "strings"
+
+// Selector matches devices by their fields. Empty fields match every device.
+type Selector struct {
+	// Model matches the Model, ModelName or ProductName of a device.
+	Model string `json:"model,omitempty"`
+
+	// OSVersion matches devices whose OS version starts with the value,
+	// so "11" matches both "11.0" and "11.2.1".
+	OSVersion string `json:"os_version,omitempty"`
+
+	Enrolled  *bool `json:"enrolled,omitempty"`
+	DEPDevice *bool `json:"dep_device,omitempty"`
+
+	// PushUnreachable matches the devices whose push token was rejected.
+	PushUnreachable *bool `json:"push_unreachable,omitempty"`
+}
+
+// Match reports whether the device matches all the fields of the selector.
+func (s Selector) Match(d Device) bool {
+	if s.Model != "" && s.Model != d.Model && s.Model != d.ModelName && s.Model != d.ProductName {
+		return false
+	}
+	if s.OSVersion != "" && !matchVersion(d.OSVersion, s.OSVersion) {
+		return false
+	}
+	if s.Enrolled != nil && *s.Enrolled != d.Enrolled {
+		return false
+	}
+	if s.DEPDevice != nil && *s.DEPDevice != d.DEPDevice {
+		return false
+	}
+	if s.PushUnreachable != nil && *s.PushUnreachable != d.PushUnreachable {
+		return false
+	}
+	return true
+}
+
+// matchVersion reports whether version is equal to prefix or a more
+// specific version of it.
+func matchVersion(version, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, ".")
+	return version == prefix || strings.HasPrefix(version, prefix+".")
+}
@@ -0,0 +1,38 @@
+package device
+
+import "testing"
+
+func TestSelector_Match(t *testing.T) {
+	yes, no := true, false
+	dev := Device{
+		Model:       "MacBookPro14,1",
+		ModelName:   "MacBook Pro",
+		ProductName: "MacBookPro14,1",
+		OSVersion:   "10.13.2",
+		Enrolled:    true,
+		DEPDevice:   false,
+	}
+
+	tests := []struct {
+		name     string
+		selector Selector
+		want     bool
+	}{
+		{name: "empty", want: true},
+		{name: "model", selector: Selector{Model: "MacBook Pro"}, want: true},
+		{name: "other model", selector: Selector{Model: "iPad"}, want: false},
+		{name: "os major", selector: Selector{OSVersion: "10.13"}, want: true},
+		{name: "os exact", selector: Selector{OSVersion: "10.13.2"}, want: true},
+		{name: "os partial number", selector: Selector{OSVersion: "10.1"}, want: false},
+		{name: "enrolled", selector: Selector{Enrolled: &yes}, want: true},
+		{name: "dep", selector: Selector{DEPDevice: &yes}, want: false},
+		{name: "all", selector: Selector{Model: "MacBookPro14,1", OSVersion: "10", Enrolled: &yes, DEPDevice: &no}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if have := tt.selector.Match(dev); have != tt.want {
+				t.Errorf("have %v, want %v", have, tt.want)
+			}
+		})
+	}
+}
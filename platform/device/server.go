@@ -10,12 +10,14 @@ import (
 )
 
 type Endpoints struct {
-	ListDevicesEndpoint endpoint.Endpoint
+	ListDevicesEndpoint  endpoint.Endpoint
+	GetInventoryEndpoint endpoint.Endpoint
 }
 
 func MakeServerEndpoints(s Service) Endpoints {
 	return Endpoints{
-		ListDevicesEndpoint: MakeListDevicesEndpoint(s),
+		ListDevicesEndpoint:  MakeListDevicesEndpoint(s),
+		GetInventoryEndpoint: MakeGetInventoryEndpoint(s),
 	}
 }
 
@@ -23,6 +25,7 @@ func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
 	r, options := httputil.NewRouter(logger)
 
 	// GET     /v1/devices		get a list of devices managed by the server
+	// GET     /v1/devices/:udid/inventory		get the apps, profiles, certificates and security info of a device
 
 	r.Methods("GET").Path("/v1/devices").Handler(httptransport.NewServer(
 		e.ListDevicesEndpoint,
@@ -31,5 +34,12 @@ func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
 		options...,
 	))
 
+	r.Methods("GET").Path("/v1/devices/{udid}/inventory").Handler(httptransport.NewServer(
+		e.GetInventoryEndpoint,
+		decodeGetInventoryRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
 	return r
 }
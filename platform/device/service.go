@@ -5,11 +5,13 @@ import (
 )
 
 type Service interface {
-	ListDevices(ctx context.Context, opt ListDevicesOption) ([]DeviceDTO, error)
+	ListDevices(ctx context.Context, opt ListDevicesOption) (*Devices, error)
+	GetInventory(ctx context.Context, udid string) (*Inventory, error)
 }
 
 type Store interface {
-	List() ([]Device, error)
+	ListDevices(opt ListDevicesOption) (devices []Device, next string, err error)
+	Inventory(udid string) (*Inventory, error)
 }
 
 type DeviceService struct {
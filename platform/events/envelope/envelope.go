@@ -0,0 +1,233 @@
+// Package envelope converts the pubsub events of the MDM server to the JSON
+// format of event webhooks, the events API, the event archive and the
+// message bus.
+package envelope
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/as/micromdm/dep"
+	"github.com/as/micromdm/dep/depsync"
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/platform/apns"
+	"github.com/as/micromdm/platform/command"
+	"github.com/as/micromdm/platform/config"
+	"github.com/as/micromdm/platform/device"
+	"github.com/as/micromdm/platform/queue"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Version is the version of the Envelope format. It is incremented when a
+// change to the envelope or a payload is not backwards compatible.
+const Version = 1
+
+// Topics are the topics which can be converted to an Envelope.
+var Topics = []string{
+	checkin.AuthenticateTopic,
+	checkin.TokenUpdateTopic,
+	checkin.CheckoutTopic,
+	connect.ConnectTopic,
+	depsync.SyncTopic,
+	device.DeviceEnrolledTopic,
+	command.CommandTopic,
+	queue.CommandQueuedTopic,
+	apns.PushTopic,
+	config.PushCertificateExpiryTopic,
+}
+
+// IsTopic reports whether topic is one of the Topics.
+func IsTopic(topic string) bool {
+	for _, t := range Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// Envelope is the JSON encoding of an event, like the body of an event
+// webhook request.
+type Envelope struct {
+	Version   int         `json:"version"`
+	Topic     string      `json:"topic"`
+	EventID   string      `json:"event_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	UDID      string      `json:"udid,omitempty"`
+	Payload   interface{} `json:"payload"`
+}
+
+// CheckinPayload is the payload of the Authenticate, TokenUpdate, CheckOut
+// and DeviceEnrolled events.
+type CheckinPayload struct {
+	MessageType           string `json:"message_type"`
+	Topic                 string `json:"topic,omitempty"`
+	UDID                  string `json:"udid"`
+	OSVersion             string `json:"os_version,omitempty"`
+	BuildVersion          string `json:"build_version,omitempty"`
+	ProductName           string `json:"product_name,omitempty"`
+	SerialNumber          string `json:"serial_number,omitempty"`
+	IMEI                  string `json:"imei,omitempty"`
+	MEID                  string `json:"meid,omitempty"`
+	DeviceName            string `json:"device_name,omitempty"`
+	Model                 string `json:"model,omitempty"`
+	ModelName             string `json:"model_name,omitempty"`
+	Token                 string `json:"token,omitempty"`
+	PushMagic             string `json:"push_magic,omitempty"`
+	AwaitingConfiguration bool   `json:"awaiting_configuration,omitempty"`
+	UserID                string `json:"user_id,omitempty"`
+	UserLongName          string `json:"user_long_name,omitempty"`
+	UserShortName         string `json:"user_short_name,omitempty"`
+	NotOnConsole          bool   `json:"not_on_console,omitempty"`
+}
+
+// ConnectPayload is the payload of the Connect event.
+type ConnectPayload struct {
+	Response mdm.Response `json:"response"`
+}
+
+// DEPSyncPayload is the payload of the DepSync event.
+type DEPSyncPayload struct {
+	Devices []dep.Device `json:"devices"`
+}
+
+// CommandPayload is the payload of the Command event, published when a
+// command is created.
+type CommandPayload struct {
+	CommandUUID string `json:"command_uuid"`
+	RequestType string `json:"request_type"`
+}
+
+// CommandQueuedPayload is the payload of the CommandQueued event, published
+// when a command is added to the queue of a device.
+type CommandQueuedPayload struct {
+	CommandUUID string `json:"command_uuid"`
+}
+
+// PushPayload is the payload of the Push event.
+type PushPayload struct {
+	PushNotificationID string `json:"push_notification_id,omitempty"`
+	Error              string `json:"error,omitempty"`
+	Status             int    `json:"status,omitempty"`
+	Reason             string `json:"reason,omitempty"`
+	Attempts           int    `json:"attempts,omitempty"`
+}
+
+// PushCertificateExpiryPayload is the payload of the PushCertificateExpiry
+// event. Threshold is the number of seconds before NotAfter which the
+// certificate crossed, and zero once it has expired.
+type PushCertificateExpiryPayload struct {
+	Topic        string    `json:"topic"`
+	Subject      string    `json:"subject"`
+	SerialNumber string    `json:"serial_number"`
+	NotAfter     time.Time `json:"not_after"`
+	Threshold    int64     `json:"threshold"`
+	Expired      bool      `json:"expired"`
+}
+
+// New decodes the pubsub message of the topic into an Envelope. Topics other
+// than the Topics return an error.
+func New(topic string, msg []byte) (*Envelope, error) {
+	env := Envelope{Version: Version, Topic: topic}
+	switch topic {
+	case checkin.AuthenticateTopic, checkin.TokenUpdateTopic, checkin.CheckoutTopic, device.DeviceEnrolledTopic:
+		var ev checkin.Event
+		if err := checkin.UnmarshalEvent(msg, &ev); err != nil {
+			return nil, err
+		}
+		env.EventID, env.CreatedAt, env.UDID = ev.ID, ev.Time, ev.Command.UDID
+		env.Payload = checkinPayload(ev.Command)
+	case connect.ConnectTopic:
+		var ev connect.Event
+		if err := connect.UnmarshalEvent(msg, &ev); err != nil {
+			return nil, err
+		}
+		env.EventID, env.CreatedAt, env.UDID = ev.ID, ev.Time, ev.Response.UDID
+		env.Payload = ConnectPayload{Response: ev.Response}
+	case depsync.SyncTopic:
+		var ev depsync.Event
+		if err := depsync.UnmarshalEvent(msg, &ev); err != nil {
+			return nil, err
+		}
+		env.EventID, env.CreatedAt = ev.ID, ev.Time
+		env.Payload = DEPSyncPayload{Devices: ev.Devices}
+	case command.CommandTopic:
+		var ev command.Event
+		if err := command.UnmarshalEvent(msg, &ev); err != nil {
+			return nil, err
+		}
+		env.EventID, env.CreatedAt, env.UDID = ev.ID, ev.Time, ev.DeviceUDID
+		payload := CommandPayload{CommandUUID: ev.Payload.CommandUUID}
+		if ev.Payload.Command != nil {
+			payload.RequestType = ev.Payload.Command.RequestType
+		}
+		env.Payload = payload
+	case queue.CommandQueuedTopic:
+		cq, err := queue.UnmarshalQueuedCommand(msg)
+		if err != nil {
+			return nil, err
+		}
+		// queued command events carry no ID or time of their own.
+		env.EventID, env.CreatedAt, env.UDID = uuid.NewV4().String(), time.Now().UTC(), cq.DeviceUDID
+		env.Payload = CommandQueuedPayload{CommandUUID: cq.CommandUUID}
+	case apns.PushTopic:
+		var ev apns.PushEvent
+		if err := apns.UnmarshalPushEvent(msg, &ev); err != nil {
+			return nil, err
+		}
+		env.EventID, env.CreatedAt, env.UDID = ev.ID, ev.Time, ev.DeviceUDID
+		env.Payload = PushPayload{
+			PushNotificationID: ev.PushNotificationID,
+			Error:              ev.Err,
+			Status:             ev.Status,
+			Reason:             ev.Reason,
+			Attempts:           ev.Attempts,
+		}
+	case config.PushCertificateExpiryTopic:
+		var ev config.PushCertificateExpiry
+		if err := config.UnmarshalPushCertificateExpiry(msg, &ev); err != nil {
+			return nil, err
+		}
+		env.EventID, env.CreatedAt = ev.ID, ev.Time
+		env.Payload = PushCertificateExpiryPayload{
+			Topic:        ev.Topic,
+			Subject:      ev.Subject,
+			SerialNumber: ev.SerialNumber,
+			NotAfter:     ev.NotAfter,
+			Threshold:    int64(ev.Threshold / time.Second),
+			Expired:      ev.Expired,
+		}
+	default:
+		return nil, fmt.Errorf("envelope: unsupported topic %s", topic)
+	}
+	return &env, nil
+}
+
+func checkinPayload(cmd mdm.CheckinCommand) CheckinPayload {
+	p := CheckinPayload{
+		MessageType:           cmd.MessageType,
+		Topic:                 cmd.Topic,
+		UDID:                  cmd.UDID,
+		OSVersion:             cmd.OSVersion,
+		BuildVersion:          cmd.BuildVersion,
+		ProductName:           cmd.ProductName,
+		SerialNumber:          cmd.SerialNumber,
+		IMEI:                  cmd.IMEI,
+		MEID:                  cmd.MEID,
+		DeviceName:            cmd.DeviceName,
+		Model:                 cmd.Model,
+		ModelName:             cmd.ModelName,
+		PushMagic:             cmd.PushMagic,
+		AwaitingConfiguration: cmd.AwaitingConfiguration,
+		UserID:                cmd.UserID,
+		UserLongName:          cmd.UserLongName,
+		UserShortName:         cmd.UserShortName,
+		NotOnConsole:          cmd.NotOnConsole,
+	}
+	if len(cmd.Token) > 0 {
+		p.Token = cmd.Token.String()
+	}
+	return p
+}
@@ -0,0 +1,204 @@
+// Package events streams MDM activity to API clients as Server-Sent Events.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/events/envelope"
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+const (
+	// clientBuffer is the number of events buffered for each client.
+	// Events are dropped for clients which fall further behind.
+	clientBuffer = 100
+
+	// keepAliveInterval is how often a comment is sent to idle clients,
+	// so proxies do not close the connection.
+	keepAliveInterval = 15 * time.Second
+
+	// recentEvents is the number of past events kept for clients which
+	// reconnect with a Last-Event-ID header.
+	recentEvents = 1000
+)
+
+// Stream fans out pubsub events to the connected SSE clients.
+type Stream struct {
+	mtx     sync.Mutex
+	clients map[*client]struct{}
+	recent  []*envelope.Envelope
+}
+
+type client struct {
+	topics map[string]bool
+	udid   string
+	events chan *envelope.Envelope
+}
+
+func (c *client) wants(env *envelope.Envelope) bool {
+	if len(c.topics) > 0 && !c.topics[env.Topic] {
+		return false
+	}
+	return c.udid == "" || c.udid == env.UDID
+}
+
+func NewStream() *Stream {
+	return &Stream{clients: make(map[*client]struct{})}
+}
+
+// StartListener subscribes to every envelope.Topics topic.
+func (s *Stream) StartListener(sub pubsub.Subscriber) error {
+	for _, topic := range envelope.Topics {
+		events, err := sub.Subscribe(context.TODO(), "eventStream", topic)
+		if err != nil {
+			return errors.Wrapf(err,
+				"subscribing eventStream to %s topic", topic)
+		}
+		go s.listen(events)
+	}
+	return nil
+}
+
+func (s *Stream) listen(events <-chan pubsub.Event) {
+	for event := range events {
+		env, err := envelope.New(event.Topic, event.Message)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			s.broadcast(env)
+		}
+		if err := event.Ack(); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+func (s *Stream) broadcast(env *envelope.Envelope) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.recent) == recentEvents {
+		copy(s.recent, s.recent[1:])
+		s.recent = s.recent[:recentEvents-1]
+	}
+	s.recent = append(s.recent, env)
+	for c := range s.clients {
+		if !c.wants(env) {
+			continue
+		}
+		select {
+		case c.events <- env:
+		default: // the client is too slow, drop the event.
+		}
+	}
+}
+
+// register adds the client and returns the recent events it wants which
+// were broadcast after the lastEventID. No events are returned if the
+// lastEventID is empty or no longer among the recent events.
+func (s *Stream) register(c *client, lastEventID string) []*envelope.Envelope {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.clients[c] = struct{}{}
+	if lastEventID == "" {
+		return nil
+	}
+	var missed []*envelope.Envelope
+	for i := len(s.recent) - 1; i >= 0; i-- {
+		if s.recent[i].EventID != lastEventID {
+			continue
+		}
+		for _, env := range s.recent[i+1:] {
+			if c.wants(env) {
+				missed = append(missed, env)
+			}
+		}
+		break
+	}
+	return missed
+}
+
+func (s *Stream) unregister(c *client) {
+	s.mtx.Lock()
+	delete(s.clients, c)
+	s.mtx.Unlock()
+}
+
+// ServeHTTP streams events until the client disconnects. The topic query
+// parameter, which can be repeated, and the udid query parameter filter
+// the events. A client which reconnects with the Last-Event-ID header
+// first receives the recent events it missed.
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	c := &client{
+		topics: make(map[string]bool),
+		udid:   r.URL.Query().Get("udid"),
+		events: make(chan *envelope.Envelope, clientBuffer),
+	}
+	for _, topic := range r.URL.Query()["topic"] {
+		if !envelope.IsTopic(topic) {
+			http.Error(w, fmt.Sprintf("unknown topic %q", topic), http.StatusBadRequest)
+			return
+		}
+		c.topics[topic] = true
+	}
+	missed := s.register(c, r.Header.Get("Last-Event-ID"))
+	defer s.unregister(c)
+
+	// The stream outlives the read and write timeouts of the server,
+	// which would otherwise end it. Writers without deadline support
+	// have no timeouts to clear.
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	for _, env := range missed {
+		if err := writeEvent(w, env); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case env := <-c.events:
+			if err := writeEvent(w, env); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes the envelope as an SSE message named after the topic.
+func writeEvent(w http.ResponseWriter, env *envelope.Envelope) error {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", env.EventID, env.Topic, data)
+	return err
+}
@@ -0,0 +1,232 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/platform/events/envelope"
+	"github.com/as/micromdm/platform/pubsub/inmem"
+)
+
+func TestStream_Filters(t *testing.T) {
+	ps := inmem.NewPubSub()
+	stream := NewStream()
+	if err := stream.StartListener(ps); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(stream)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?topic=" + connect.ConnectTopic + "&udid=udid-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("have Content-Type %s, want text/event-stream", ct)
+	}
+	r := bufio.NewReader(resp.Body)
+	if line, _ := r.ReadString('\n'); line != ": connected\n" {
+		t.Fatalf("have first line %q", line)
+	}
+
+	ctx := context.Background()
+	publishConnect(t, ps, "udid-b", "cmd-b")
+	auth, err := checkin.MarshalEvent(checkin.NewEvent(mdm.CheckinCommand{MessageType: "Authenticate", UDID: "udid-a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.Publish(ctx, checkin.AuthenticateTopic, auth); err != nil {
+		t.Fatal(err)
+	}
+	publishConnect(t, ps, "udid-a", "cmd-a")
+
+	messages := readMessages(r)
+
+	select {
+	case m := <-messages:
+		if m.err != nil {
+			t.Fatal(m.err)
+		}
+		if m.event != connect.ConnectTopic {
+			t.Fatalf("have event %s, want %s", m.event, connect.ConnectTopic)
+		}
+		var env envelope.Envelope
+		if err := json.Unmarshal([]byte(m.data), &env); err != nil {
+			t.Fatal(err)
+		}
+		if env.UDID != "udid-a" {
+			t.Errorf("have udid %s, want udid-a", env.UDID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestStream_UnknownTopic(t *testing.T) {
+	srv := httptest.NewServer(NewStream())
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "?topic=mdm.Unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("have status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func publishConnect(t *testing.T, ps *inmem.Inmem, udid, commandUUID string) {
+	t.Helper()
+	msg, err := connect.MarshalEvent(connect.NewEvent(connect.MDMConnectRequest{
+		MDMResponse: mdm.Response{UDID: udid, Status: "Acknowledged", CommandUUID: commandUUID},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.Publish(context.Background(), connect.ConnectTopic, msg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type message struct {
+	id, event, data string
+	err             error
+}
+
+// readMessages sends the SSE messages read from r to the returned channel.
+func readMessages(r *bufio.Reader) <-chan message {
+	messages := make(chan message)
+	go func() {
+		var m message
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				messages <- message{err: err}
+				return
+			}
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				m.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				m.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				m.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && m.data != "":
+				messages <- m
+				m = message{}
+			}
+		}
+	}()
+	return messages
+}
+
+func receive(t *testing.T, messages <-chan message, timeout time.Duration) message {
+	t.Helper()
+	select {
+	case m := <-messages:
+		if m.err != nil {
+			t.Fatal(m.err)
+		}
+		return m
+	case <-time.After(timeout):
+		t.Fatal("timed out waiting for event")
+	}
+	return message{}
+}
+
+// TestStream_ServerTimeouts streams through a TLS server with the timeouts
+// of go4/httputil, scaled down, and expects the stream to outlive them.
+func TestStream_ServerTimeouts(t *testing.T) {
+	ps := inmem.NewPubSub()
+	stream := NewStream()
+	if err := stream.StartListener(ps); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewUnstartedServer(stream)
+	srv.Config.ReadHeaderTimeout = 50 * time.Millisecond
+	srv.Config.ReadTimeout = 100 * time.Millisecond
+	srv.Config.WriteTimeout = 200 * time.Millisecond
+	srv.Config.IdleTimeout = 200 * time.Millisecond
+	srv.StartTLS()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	messages := readMessages(bufio.NewReader(resp.Body))
+
+	time.Sleep(2 * srv.Config.WriteTimeout)
+	publishConnect(t, ps, "udid-a", "cmd-a")
+	if m := receive(t, messages, time.Second); m.event != connect.ConnectTopic {
+		t.Errorf("have event %s, want %s", m.event, connect.ConnectTopic)
+	}
+}
+
+func TestStream_LastEventID(t *testing.T) {
+	ps := inmem.NewPubSub()
+	stream := NewStream()
+	if err := stream.StartListener(ps); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(stream)
+	defer srv.Close()
+
+	for _, uuid := range []string{"cmd-a", "cmd-b", "cmd-c"} {
+		publishConnect(t, ps, "udid-a", uuid)
+	}
+	var lastEventID string
+	for deadline := time.Now().Add(time.Second); ; {
+		stream.mtx.Lock()
+		if len(stream.recent) == 3 {
+			lastEventID = stream.recent[0].EventID
+		}
+		stream.mtx.Unlock()
+		if lastEventID != "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for events")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", lastEventID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	messages := readMessages(bufio.NewReader(resp.Body))
+
+	for _, want := range []string{"cmd-b", "cmd-c"} {
+		m := receive(t, messages, time.Second)
+		var env struct {
+			Payload struct {
+				Response mdm.Response `json:"response"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal([]byte(m.data), &env); err != nil {
+			t.Fatal(err)
+		}
+		if have := env.Payload.Response.CommandUUID; have != want {
+			t.Errorf("have command %s, want %s", have, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package pubsub
+
+import (
+	"fmt"
+	"sync"
+)
+
+// AckQueue acknowledges the events of a subscription in the order they were
+// received, once the work started for an event is done. Acks are cumulative,
+// so an event is only acknowledged after the events received before it.
+//
+// Work which failed stops the acknowledgements of the subscription, so a
+// durable Subscriber delivers the event and every later event again after a
+// restart. The zero value is ready to use.
+type AckQueue struct {
+	mtx     sync.Mutex
+	pending []*pendingAck
+	stalled bool
+}
+
+type pendingAck struct {
+	event     Event
+	remaining int
+}
+
+// Add tracks the event until n pieces of work are done, and returns the done
+// function which must be called once for each of them.
+func (q *AckQueue) Add(event Event, n int) func(error) {
+	p := &pendingAck{event: event, remaining: n}
+	q.mtx.Lock()
+	if !q.stalled {
+		q.pending = append(q.pending, p)
+		q.flush()
+	}
+	q.mtx.Unlock()
+	return func(err error) {
+		q.mtx.Lock()
+		defer q.mtx.Unlock()
+		if q.stalled {
+			return
+		}
+		if err != nil {
+			fmt.Printf("pubsub: not acknowledging %s events after failed work: %s\n", event.Topic, err)
+			q.stalled, q.pending = true, nil
+			return
+		}
+		p.remaining--
+		q.flush()
+	}
+}
+
+// flush acknowledges the done events at the front of the queue.
+func (q *AckQueue) flush() {
+	for len(q.pending) > 0 && q.pending[0].remaining <= 0 {
+		if err := q.pending[0].event.Ack(); err != nil {
+			fmt.Println(err)
+		}
+		q.pending = q.pending[1:]
+	}
+}
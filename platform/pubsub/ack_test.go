@@ -0,0 +1,30 @@
+package pubsub
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAckQueue_Stall(t *testing.T) {
+	var acked []int
+	var q AckQueue
+	event := func(i int) Event {
+		return Event{}.WithAck(func() error {
+			acked = append(acked, i)
+			return nil
+		})
+	}
+	done0 := q.Add(event(0), 1)
+	done1 := q.Add(event(1), 2)
+	done1(nil)
+	done0(nil)
+	if len(acked) != 1 || acked[0] != 0 {
+		t.Fatalf("have acked %v, want [0]", acked)
+	}
+	done1(errors.New("lost"))
+	done2 := q.Add(event(2), 1)
+	done2(nil)
+	if len(acked) != 1 {
+		t.Errorf("have acked %v after a lost delivery, want [0]", acked)
+	}
+}
@@ -0,0 +1,417 @@
+// Package builtin implements a durable pubsub.PublishSubscriber backed by BoltDB.
+//
+// Every topic is stored as an ordered log. Subscribers are identified by
+// the name passed to Subscribe and receive the events of a topic in the order
+// they were published. The offset of a subscriber is only advanced when an
+// event is acknowledged, so a subscriber which resumes after a restart
+// receives every event it did not acknowledge again.
+//
+// A subscriber name is only known to the log once it subscribed. Events
+// published to a topic before a name subscribed for the first time are never
+// delivered to it, and neither are the events published while the offset of a
+// name was expired by Compact.
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+const (
+	// LogBucket holds a nested bucket for every topic. The keys of a topic
+	// bucket are the big endian sequence numbers of its events.
+	LogBucket = "mdm.PubSubLog"
+
+	// OffsetBucket holds the next sequence number to deliver for every
+	// subscriber name and topic, followed by the time the offset was last used
+	// in unix nanoseconds.
+	OffsetBucket = "mdm.PubSubOffsets"
+
+	// DefaultMaxEvents is the number of events kept for a topic without
+	// subscribers by a PubSub created without WithMaxEvents.
+	DefaultMaxEvents = 10000
+
+	// DefaultOffsetExpiry is the time an unused offset is kept by a PubSub
+	// created without WithOffsetExpiry.
+	DefaultOffsetExpiry = 30 * 24 * time.Hour
+
+	// readBatch is the maximum number of events read from the log at once.
+	readBatch = 100
+)
+
+type PubSub struct {
+	db           *bolt.DB
+	maxEvents    int
+	offsetExpiry time.Duration
+
+	mtx           sync.Mutex
+	subscriptions map[string][]*subscription
+}
+
+type subscription struct {
+	name  string
+	topic string
+	wake  chan struct{}
+}
+
+type Option func(*PubSub)
+
+// WithMaxEvents sets the number of events kept for a topic which no
+// subscriber has an offset for. Zero keeps every event.
+func WithMaxEvents(n int) Option {
+	return func(p *PubSub) {
+		p.maxEvents = n
+	}
+}
+
+// WithOffsetExpiry sets the time after which the offset of a subscriber which
+// neither subscribed nor acknowledged an event is removed. Zero keeps every
+// offset.
+func WithOffsetExpiry(d time.Duration) Option {
+	return func(p *PubSub) {
+		p.offsetExpiry = d
+	}
+}
+
+// NewPubSub creates the buckets of the pubsub log and starts a janitor which
+// removes the events acknowledged by every subscriber.
+func NewPubSub(db *bolt.DB, opts ...Option) (*PubSub, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists([]byte(LogBucket)); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists([]byte(OffsetBucket))
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating pubsub buckets")
+	}
+	ps := &PubSub{
+		db:            db,
+		maxEvents:     DefaultMaxEvents,
+		offsetExpiry:  DefaultOffsetExpiry,
+		subscriptions: make(map[string][]*subscription),
+	}
+	for _, opt := range opts {
+		opt(ps)
+	}
+	go ps.janitor(time.Hour)
+	return ps, nil
+}
+
+// Publish appends the message to the log of the topic.
+func (p *PubSub) Publish(_ context.Context, topic string, msg []byte) error {
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket([]byte(LogBucket)).CreateBucketIfNotExists([]byte(topic))
+		if err != nil {
+			return err
+		}
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		return b.Put(seqKey(seq), msg)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "publish to topic %s", topic)
+	}
+
+	p.mtx.Lock()
+	for _, sub := range p.subscriptions[topic] {
+		select {
+		case sub.wake <- struct{}{}:
+		default: // a wake up is already pending
+		}
+	}
+	p.mtx.Unlock()
+	return nil
+}
+
+// Subscribe delivers the events of the topic in order, starting with the first
+// event not acknowledged by the named subscriber. A name which never subscribed
+// to the topic before, or whose offset expired, starts with the events
+// published after this call. The channel is closed when the context is
+// cancelled.
+func (p *PubSub) Subscribe(ctx context.Context, name, topic string) (<-chan pubsub.Event, error) {
+	var next uint64
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket([]byte(LogBucket)).CreateBucketIfNotExists([]byte(topic))
+		if err != nil {
+			return err
+		}
+		offsets := tx.Bucket([]byte(OffsetBucket))
+		key := offsetKey(name, topic)
+		if v := offsets.Get(key); v != nil {
+			next, _ = decodeOffset(v)
+		} else {
+			next = b.Sequence() + 1
+		}
+		return offsets.Put(key, encodeOffset(next, time.Now()))
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "subscribe %s to topic %s", name, topic)
+	}
+
+	sub := &subscription{
+		name:  name,
+		topic: topic,
+		wake:  make(chan struct{}, 1),
+	}
+	p.mtx.Lock()
+	p.subscriptions[topic] = append(p.subscriptions[topic], sub)
+	p.mtx.Unlock()
+
+	events := make(chan pubsub.Event)
+	go p.deliver(ctx, sub, next, events)
+	return events, nil
+}
+
+func (p *PubSub) deliver(ctx context.Context, sub *subscription, next uint64, events chan<- pubsub.Event) {
+	defer func() {
+		p.unsubscribe(sub)
+		close(events)
+	}()
+	for {
+		batch, err := p.read(sub, next)
+		if err != nil {
+			fmt.Println(errors.Wrapf(err, "read events of %s for %s", sub.topic, sub.name))
+		}
+		for _, ev := range batch {
+			select {
+			case events <- ev.event:
+				next = ev.seq + 1
+			case <-ctx.Done():
+				return
+			}
+		}
+		if len(batch) == readBatch {
+			continue
+		}
+		select {
+		case <-sub.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type logEvent struct {
+	seq   uint64
+	event pubsub.Event
+}
+
+// read returns up to readBatch events of the subscribed topic,
+// starting at the sequence next.
+func (p *PubSub) read(sub *subscription, next uint64) ([]logEvent, error) {
+	var batch []logEvent
+	err := p.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(LogBucket)).Bucket([]byte(sub.topic))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(seqKey(next)); k != nil && len(batch) < readBatch; k, v = c.Next() {
+			seq := binary.BigEndian.Uint64(k)
+			msg := make([]byte, len(v))
+			copy(msg, v)
+			batch = append(batch, logEvent{
+				seq:   seq,
+				event: p.event(sub, msg, seq),
+			})
+		}
+		return nil
+	})
+	return batch, err
+}
+
+func (p *PubSub) event(sub *subscription, msg []byte, seq uint64) pubsub.Event {
+	ev := pubsub.Event{Topic: sub.topic, Message: msg}
+	return ev.WithAck(func() error {
+		return p.ack(sub.name, sub.topic, seq)
+	})
+}
+
+func (p *PubSub) unsubscribe(sub *subscription) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	subs := p.subscriptions[sub.topic]
+	for i, s := range subs {
+		if s == sub {
+			p.subscriptions[sub.topic] = append(subs[:i:i], subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// ack advances the offset of the named subscriber past seq.
+func (p *PubSub) ack(name, topic string, seq uint64) error {
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		offsets := tx.Bucket([]byte(OffsetBucket))
+		key := offsetKey(name, topic)
+		if v := offsets.Get(key); v != nil {
+			if next, _ := decodeOffset(v); next > seq {
+				return nil // a later event was already acknowledged
+			}
+		}
+		return offsets.Put(key, encodeOffset(seq+1, time.Now()))
+	})
+	return errors.Wrapf(err, "ack event %d of %s for %s", seq, topic, name)
+}
+
+func (p *PubSub) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := p.Compact(); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// Compact removes the events of every topic which were acknowledged by all
+// subscribers of the topic. Topics without subscribers are trimmed to the
+// newest events, and the offsets of names which did not subscribe or
+// acknowledge an event for longer than the offset expiry are removed.
+func (p *PubSub) Compact() error {
+	now := time.Now()
+	live := p.liveOffsets()
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		offsets := tx.Bucket([]byte(OffsetBucket))
+
+		// the lowest offset of any subscriber, by topic.
+		low := make(map[string]uint64)
+		var expired, touched [][]byte
+		err := offsets.ForEach(func(k, v []byte) error {
+			next, used := decodeOffset(v)
+			switch {
+			case live[string(k)] || used.IsZero():
+				// refresh the offsets in use and the ones stored without a time.
+				touched = append(touched, append([]byte{}, k...))
+			case p.offsetExpiry > 0 && now.Sub(used) > p.offsetExpiry:
+				expired = append(expired, append([]byte{}, k...))
+				return nil
+			}
+			_, topic := splitOffsetKey(k)
+			if seq, ok := low[topic]; !ok || next < seq {
+				low[topic] = next
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range expired {
+			if err := offsets.Delete(k); err != nil {
+				return err
+			}
+		}
+		for _, k := range touched {
+			next, _ := decodeOffset(offsets.Get(k))
+			if err := offsets.Put(k, encodeOffset(next, now)); err != nil {
+				return err
+			}
+		}
+
+		logs := tx.Bucket([]byte(LogBucket))
+		var topics [][]byte
+		err = logs.ForEach(func(k, v []byte) error {
+			if v == nil {
+				topics = append(topics, append([]byte{}, k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, topic := range topics {
+			b := logs.Bucket(topic)
+			next, ok := low[string(topic)]
+			if !ok {
+				if p.maxEvents <= 0 || b.Sequence() <= uint64(p.maxEvents) {
+					continue
+				}
+				next = b.Sequence() - uint64(p.maxEvents) + 1
+			}
+			if err := deleteBefore(b, next); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return errors.Wrap(err, "compact pubsub log")
+}
+
+// liveOffsets returns the offset keys of the subscriptions of this process.
+func (p *PubSub) liveOffsets() map[string]bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	live := make(map[string]bool)
+	for topic, subs := range p.subscriptions {
+		for _, sub := range subs {
+			live[string(offsetKey(sub.name, topic))] = true
+		}
+	}
+	return live
+}
+
+// deleteBefore removes the events of the topic bucket with a sequence number
+// lower than next.
+func deleteBefore(b *bolt.Bucket, next uint64) error {
+	var keys [][]byte
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil && bytes.Compare(k, seqKey(next)) < 0; k, _ = c.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seqKey(seq uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
+
+func encodeOffset(next uint64, used time.Time) []byte {
+	v := make([]byte, 16)
+	binary.BigEndian.PutUint64(v, next)
+	binary.BigEndian.PutUint64(v[8:], uint64(used.UnixNano()))
+	return v
+}
+
+// decodeOffset returns the next sequence number of an offset and the time it
+// was last used. Offsets written before the time was stored have a zero time.
+func decodeOffset(v []byte) (next uint64, used time.Time) {
+	next = binary.BigEndian.Uint64(v)
+	if len(v) >= 16 {
+		used = time.Unix(0, int64(binary.BigEndian.Uint64(v[8:])))
+	}
+	return next, used
+}
+
+// offsetKey is the key of a subscriber offset. Subscribers of a topic
+// with the same name share the offset.
+func offsetKey(name, topic string) []byte {
+	return []byte(name + "\x00" + topic)
+}
+
+func splitOffsetKey(k []byte) (name, topic string) {
+	i := bytes.IndexByte(k, 0)
+	if i < 0 {
+		return string(k), ""
+	}
+	return string(k[:i]), string(k[i+1:])
+}
@@ -0,0 +1,292 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+func TestOrderedDelivery(t *testing.T) {
+	ps, teardown := setupPubSub(t)
+	defer teardown()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := ps.Subscribe(ctx, "ordered", "topic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2*readBatch+5; i++ {
+		if err := ps.Publish(ctx, "topic", []byte(fmt.Sprint(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 2*readBatch+5; i++ {
+		if have, want := string(receive(t, events).Message), fmt.Sprint(i); have != want {
+			t.Fatalf("have event %s, want %s", have, want)
+		}
+	}
+}
+
+func TestResumeUnacknowledged(t *testing.T) {
+	f, _ := ioutil.TempFile("", "bolt-")
+	f.Close()
+	defer os.Remove(f.Name())
+
+	db, err := bolt.Open(f.Name(), 0777, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps, err := NewPubSub(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := ps.Subscribe(ctx, "devices", "checkin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		if err := ps.Publish(ctx, "checkin", []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	receive(t, events)
+	if err := receive(t, events).Ack(); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, events) // delivered but never acknowledged
+	cancel()
+	for range events {
+	}
+
+	// simulate a restart of the server.
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if db, err = bolt.Open(f.Name(), 0777, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if ps, err = NewPubSub(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	resumed, err := ps.Subscribe(ctx, "devices", "checkin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"c", "d"} {
+		if have := string(receive(t, resumed).Message); have != want {
+			t.Errorf("have event %s, want %s", have, want)
+		}
+	}
+
+	// a new subscriber only receives events published after it subscribed.
+	late, err := ps.Subscribe(ctx, "late", "checkin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.Publish(ctx, "checkin", []byte("e")); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := string(receive(t, late).Message), "e"; have != want {
+		t.Errorf("have event %s, want %s", have, want)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	ps, teardown := setupPubSub(t)
+	defer teardown()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fast, err := ps.Subscribe(ctx, "fast", "topic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slow, err := ps.Subscribe(ctx, "slow", "topic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range []string{"a", "b", "c"} {
+		if err := ps.Publish(ctx, "topic", []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if err := receive(t, fast).Ack(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := receive(t, slow).Ack(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ps.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	var remaining int
+	err = ps.db.View(func(tx *bolt.Tx) error {
+		remaining = tx.Bucket([]byte(LogBucket)).Bucket([]byte("topic")).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := remaining, 2; have != want {
+		t.Errorf("have %d events after compaction, want %d", have, want)
+	}
+}
+
+func TestCompact_Unsubscribed(t *testing.T) {
+	ps, teardown := setupPubSub(t, WithMaxEvents(2))
+	defer teardown()
+	ctx := context.Background()
+
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		if err := ps.Publish(ctx, "topic", []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ps.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := topicEvents(t, ps, "topic"), []string{"c", "d"}; fmt.Sprint(have) != fmt.Sprint(want) {
+		t.Errorf("have events %v after compaction, want %v", have, want)
+	}
+}
+
+func TestCompact_ExpireOffsets(t *testing.T) {
+	ps, teardown := setupPubSub(t, WithOffsetExpiry(time.Hour))
+	defer teardown()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	live, err := ps.Subscribe(ctx, "live", "topic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range []string{"a", "b", "c"} {
+		if err := ps.Publish(ctx, "topic", []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := receive(t, live).Ack(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a subscriber which stopped at the first event a day ago, and the live
+	// subscriber which did not acknowledge anything for as long.
+	stale := time.Now().Add(-24 * time.Hour)
+	err = ps.db.Update(func(tx *bolt.Tx) error {
+		offsets := tx.Bucket([]byte(OffsetBucket))
+		if err := offsets.Put(offsetKey("gone", "topic"), encodeOffset(1, stale)); err != nil {
+			return err
+		}
+		return offsets.Put(offsetKey("live", "topic"), encodeOffset(2, stale))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ps.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	err = ps.db.View(func(tx *bolt.Tx) error {
+		offsets := tx.Bucket([]byte(OffsetBucket))
+		if offsets.Get(offsetKey("gone", "topic")) != nil {
+			t.Error("expired offset was not removed")
+		}
+		v := offsets.Get(offsetKey("live", "topic"))
+		if v == nil {
+			t.Fatal("offset of a live subscriber was removed")
+		}
+		if _, used := decodeOffset(v); !used.After(stale) {
+			t.Errorf("offset of a live subscriber was not refreshed, last used %s", used)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := topicEvents(t, ps, "topic"), []string{"b", "c"}; fmt.Sprint(have) != fmt.Sprint(want) {
+		t.Errorf("have events %v after compaction, want %v", have, want)
+	}
+}
+
+func TestSubscribe_Cancel(t *testing.T) {
+	ps, teardown := setupPubSub(t)
+	defer teardown()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := ps.Subscribe(ctx, "cancelled", "topic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected no events after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected channel to be closed after cancel")
+	}
+}
+
+func receive(t *testing.T, events <-chan pubsub.Event) pubsub.Event {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return pubsub.Event{}
+}
+
+func topicEvents(t *testing.T, ps *PubSub, topic string) []string {
+	var msgs []string
+	err := ps.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(LogBucket)).Bucket([]byte(topic)).ForEach(func(_, v []byte) error {
+			msgs = append(msgs, string(v))
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msgs
+}
+
+func setupPubSub(t *testing.T, opts ...Option) (*PubSub, func()) {
+	f, _ := ioutil.TempFile("", "bolt-")
+	f.Close()
+	db, err := bolt.Open(f.Name(), 0777, nil)
+	if err != nil {
+		t.Fatalf("couldn't open bolt, err %s\n", err)
+	}
+	ps, err := NewPubSub(db, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teardown := func() {
+		db.Close()
+		os.Remove(f.Name())
+	}
+	return ps, teardown
+}
@@ -0,0 +1,137 @@
+// Package bus mirrors selected pubsub topics to an external message bus.
+//
+// PubSub wraps an existing pubsub.PublishSubscriber. Every event is published
+// to the wrapped PublishSubscriber as before, and events of the mirrored topics
+// are also sent to a Sink in the background, so a slow or unavailable bus
+// never blocks the MDM server.
+//
+// Events are sent as the JSON envelope.Envelope of the event, the same format
+// as event webhooks and the events API, never as the internal protobuf
+// message, which carries secrets like the unlock token of a device.
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/as/micromdm/platform/events/envelope"
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+// DefaultQueueSize is the number of events waiting to be sent to the sink
+// before new events are dropped.
+const DefaultQueueSize = 1000
+
+// Sink sends a message to an external bus.
+type Sink interface {
+	Send(ctx context.Context, subject string, msg []byte) error
+}
+
+type PubSub struct {
+	next   pubsub.PublishSubscriber
+	sink   Sink
+	topics map[string]bool
+	prefix string
+	queue  chan message
+}
+
+type message struct {
+	subject string
+	topic   string
+	msg     []byte
+}
+
+type Option func(*PubSub)
+
+// WithTopics selects the topics which are mirrored to the sink. Without this
+// option, every one of the envelope.Topics is mirrored. Other topics are
+// never mirrored, as they have no envelope.
+func WithTopics(topics ...string) Option {
+	return func(p *PubSub) {
+		p.topics = make(map[string]bool)
+		for _, t := range topics {
+			p.topics[t] = true
+		}
+	}
+}
+
+// WithSubjectPrefix sets a prefix which is prepended to the topic to create
+// the subject of a message. For example the prefix "micromdm." sends
+// mdm.Connect events with the subject "micromdm.mdm.Connect".
+func WithSubjectPrefix(prefix string) Option {
+	return func(p *PubSub) {
+		p.prefix = prefix
+	}
+}
+
+// WithQueueSize sets the number of events waiting to be sent to the sink.
+func WithQueueSize(size int) Option {
+	return func(p *PubSub) {
+		p.queue = make(chan message, size)
+	}
+}
+
+// New creates a PubSub which publishes to next and mirrors events to sink.
+func New(next pubsub.PublishSubscriber, sink Sink, opts ...Option) *PubSub {
+	p := &PubSub{
+		next:  next,
+		sink:  sink,
+		queue: make(chan message, DefaultQueueSize),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	go p.run()
+	return p
+}
+
+// Publish publishes the event to the wrapped PublishSubscriber and queues it
+// for the sink if the topic is mirrored.
+func (p *PubSub) Publish(ctx context.Context, topic string, msg []byte) error {
+	if err := p.next.Publish(ctx, topic, msg); err != nil {
+		return err
+	}
+	if !p.mirrored(topic) {
+		return nil
+	}
+	select {
+	case p.queue <- message{subject: p.prefix + topic, topic: topic, msg: msg}:
+	default:
+		fmt.Printf("bus: queue full, dropped %s event\n", topic)
+	}
+	return nil
+}
+
+// mirrored reports whether events of the topic are sent to the sink.
+func (p *PubSub) mirrored(topic string) bool {
+	if !envelope.IsTopic(topic) {
+		return false
+	}
+	return p.topics == nil || p.topics[topic]
+}
+
+func (p *PubSub) Subscribe(ctx context.Context, name, topic string) (<-chan pubsub.Event, error) {
+	return p.next.Subscribe(ctx, name, topic)
+}
+
+func (p *PubSub) run() {
+	for m := range p.queue {
+		msg, err := encodeEnvelope(m.topic, m.msg)
+		if err != nil {
+			fmt.Printf("bus: encode %s event: %s\n", m.topic, err)
+			continue
+		}
+		if err := p.sink.Send(context.Background(), m.subject, msg); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+func encodeEnvelope(topic string, msg []byte) ([]byte, error) {
+	env, err := envelope.New(topic, msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(env)
+}
@@ -6,29 +6,61 @@ import (
 	"github.com/as/micromdm/platform/pubsub"
 )
 
-func (p *Inmem) Subscribe(_ context.Context, name, topic string) (<-chan pubsub.Event, error) {
-	events := make(chan pubsub.Event)
-	sub := subscription{
-		name:      name,
-		topic:     topic,
-		eventChan: events,
+// Subscribe returns a channel which receives the events published to the
+// topic after the call. The subscription is removed and the channel closed
+// when the context is cancelled.
+func (p *Inmem) Subscribe(ctx context.Context, name, topic string) (<-chan pubsub.Event, error) {
+	sub := &subscription{
+		name:    name,
+		topic:   topic,
+		queue:   make(chan pubsub.Event, p.bufferSize),
+		done:    ctx.Done(),
+		depth:   p.depth.With("topic", topic, "subscriber", name),
+		dropped: p.dropped.With("topic", topic, "subscriber", name),
 	}
 	p.mtx.Lock()
 	p.subscriptions[topic] = append(p.subscriptions[topic], sub)
 	p.mtx.Unlock()
 
+	events := make(chan pubsub.Event)
+	go p.forward(ctx, sub, events)
 	return events, nil
 }
 
-func (p *Inmem) dispatch() {
+// forward moves events from the subscriber buffer to the events channel
+// until the context is cancelled.
+func (p *Inmem) forward(ctx context.Context, sub *subscription, events chan<- pubsub.Event) {
+	defer func() {
+		p.unsubscribe(sub)
+		close(events)
+	}()
 	for {
 		select {
-		case ev := <-p.publish:
-			p.mtx.Lock() // TODO(as): fix
-			for _, sub := range p.subscriptions[ev.Topic] {
-				go func(s subscription) { s.eventChan <- ev }(sub)
+		case ev := <-sub.queue:
+			sub.setDepth()
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
 			}
-			p.mtx.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *Inmem) unsubscribe(sub *subscription) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	subs := p.subscriptions[sub.topic]
+	for i, s := range subs {
+		if s == sub {
+			p.subscriptions[sub.topic] = append(subs[:i:i], subs[i+1:]...)
+			break
 		}
 	}
+	if len(p.subscriptions[sub.topic]) == 0 {
+		delete(p.subscriptions, sub.topic)
+	}
+	sub.depth.Set(0)
 }
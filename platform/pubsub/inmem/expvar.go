@@ -0,0 +1,66 @@
+package inmem
+
+import (
+	"expvar"
+	"strings"
+
+	"github.com/go-kit/kit/metrics"
+)
+
+// WithExpvar publishes the queue depth and dropped events of every
+// subscriber as the expvar maps <prefix>_queue_depth and <prefix>_dropped,
+// keyed by "topic/subscriber".
+func WithExpvar(prefix string) Option {
+	depth := expvarMap(prefix + "_queue_depth")
+	dropped := expvarMap(prefix + "_dropped")
+	return func(p *Inmem) {
+		p.depth = expvarGauge{m: depth}
+		p.dropped = expvarCounter{m: dropped}
+	}
+}
+
+// expvarMap returns the published map with the name, because expvar
+// panics if a name is published twice.
+func expvarMap(name string) *expvar.Map {
+	if m, ok := expvar.Get(name).(*expvar.Map); ok {
+		return m
+	}
+	return expvar.NewMap(name)
+}
+
+// labelKey joins the values of the "topic" and "subscriber" labels.
+func labelKey(labelValues []string) string {
+	var values []string
+	for i := 1; i < len(labelValues); i += 2 {
+		values = append(values, labelValues[i])
+	}
+	return strings.Join(values, "/")
+}
+
+type expvarGauge struct {
+	m   *expvar.Map
+	key string
+}
+
+func (g expvarGauge) With(labelValues ...string) metrics.Gauge {
+	return expvarGauge{m: g.m, key: labelKey(labelValues)}
+}
+
+func (g expvarGauge) Set(value float64) {
+	v := new(expvar.Float)
+	v.Set(value)
+	g.m.Set(g.key, v)
+}
+
+func (g expvarGauge) Add(delta float64) { g.m.AddFloat(g.key, delta) }
+
+type expvarCounter struct {
+	m   *expvar.Map
+	key string
+}
+
+func (c expvarCounter) With(labelValues ...string) metrics.Counter {
+	return expvarCounter{m: c.m, key: labelKey(labelValues)}
+}
+
+func (c expvarCounter) Add(delta float64) { c.m.AddFloat(c.key, delta) }
@@ -2,9 +2,13 @@ package inmem
 
 import (
 	"context"
+	"expvar"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/go-kit/kit/metrics"
 )
 
 func TestPubSub(t *testing.T) {
@@ -47,3 +51,185 @@ func TestPubSub(t *testing.T) {
 		}
 	}
 }
+
+func TestSubscribe_Cancel(t *testing.T) {
+	inmem := NewPubSub()
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := inmem.Subscribe(ctx, "cancel", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected closed channel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+
+	inmem.mtx.RLock()
+	subs := len(inmem.subscriptions["a"])
+	inmem.mtx.RUnlock()
+	if subs != 0 {
+		t.Errorf("have %d subscriptions after cancel, want 0", subs)
+	}
+	if err := inmem.Publish(context.Background(), "a", []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOverflowPolicy(t *testing.T) {
+	tests := []struct {
+		policy OverflowPolicy
+		want   []string
+	}{
+		{policy: DropNewest, want: []string{"1", "2", "3"}},
+		{policy: DropOldest, want: []string{"1", "4", "5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			depth := new(gauge)
+			dropped := new(gauge)
+			inmem := NewPubSub(
+				WithBufferSize(2),
+				WithOverflowPolicy(tt.policy),
+				WithQueueDepth(depth),
+				WithDropped(counter{dropped}),
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := inmem.Subscribe(ctx, "slow", "a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			sub := inmem.subscriptions["a"][0]
+
+			// the first event is held by the subscription until it is received.
+			publish(t, inmem, "1")
+			waitEmpty(t, sub)
+			for _, msg := range []string{"2", "3", "4", "5"} {
+				publish(t, inmem, msg)
+			}
+			if have, want := sub.depth.(*gauge).value(), 2.0; have != want {
+				t.Errorf("have queue depth %v, want %v", have, want)
+			}
+			if have, want := sub.dropped.(counter).value(), 2.0; have != want {
+				t.Errorf("have %v dropped events, want %v", have, want)
+			}
+
+			for _, want := range tt.want {
+				select {
+				case ev := <-events:
+					if have := string(ev.Message); have != want {
+						t.Errorf("have %s, want %s", have, want)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("timed out waiting for %s", want)
+				}
+			}
+			select {
+			case ev := <-events:
+				t.Errorf("unexpected event %s", ev.Message)
+			case <-time.After(10 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestOverflowPolicy_Block(t *testing.T) {
+	// Block is the default policy.
+	inmem := NewPubSub(WithBufferSize(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := inmem.Subscribe(ctx, "slow", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := inmem.subscriptions["a"][0]
+	publish(t, inmem, "1")
+	waitEmpty(t, sub)
+	publish(t, inmem, "2")
+
+	pubCtx, pubCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer pubCancel()
+	if err := inmem.Publish(pubCtx, "a", []byte("3")); err != context.DeadlineExceeded {
+		t.Fatalf("have err %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	for _, want := range []string{"1", "2"} {
+		if ev := <-events; string(ev.Message) != want {
+			t.Errorf("have %s, want %s", ev.Message, want)
+		}
+	}
+}
+
+func TestWithExpvar(t *testing.T) {
+	inmem := NewPubSub(WithBufferSize(1), WithOverflowPolicy(DropNewest), WithExpvar("test_pubsub"))
+	dropped := func() float64 {
+		if v, ok := expvar.Get("test_pubsub_dropped").(*expvar.Map).Get("a/slow").(*expvar.Float); ok {
+			return v.Value()
+		}
+		return 0
+	}
+	before := dropped()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := inmem.Subscribe(ctx, "slow", "a"); err != nil {
+		t.Fatal(err)
+	}
+	sub := inmem.subscriptions["a"][0]
+	publish(t, inmem, "1")
+	waitEmpty(t, sub)
+	publish(t, inmem, "2")
+	publish(t, inmem, "3")
+
+	if have := expvar.Get("test_pubsub_queue_depth").(*expvar.Map).Get("a/slow").String(); have != "1" {
+		t.Errorf("have queue depth %s, want 1", have)
+	}
+	if have := dropped() - before; have != 1 {
+		t.Errorf("have %v dropped events, want 1", have)
+	}
+}
+
+func publish(t *testing.T, inmem *Inmem, msg string) {
+	t.Helper()
+	if err := inmem.Publish(context.Background(), "a", []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitEmpty waits until the subscription took every event from its buffer.
+func waitEmpty(t *testing.T, sub *subscription) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for len(sub.queue) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for empty queue")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// gauge is a metrics.Gauge which ignores labels.
+type gauge struct {
+	mtx sync.Mutex
+	v   float64
+}
+
+func (g *gauge) With(...string) metrics.Gauge { return g }
+func (g *gauge) Set(v float64)                { g.mtx.Lock(); g.v = v; g.mtx.Unlock() }
+func (g *gauge) Add(delta float64)            { g.mtx.Lock(); g.v += delta; g.mtx.Unlock() }
+
+func (g *gauge) value() float64 {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.v
+}
+
+// counter adapts gauge to metrics.Counter.
+type counter struct{ *gauge }
+
+func (c counter) With(...string) metrics.Counter { return c }
@@ -2,37 +2,188 @@ package inmem
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+
 	"github.com/as/micromdm/platform/pubsub"
 )
 
-func NewPubSub() *Inmem {
-	publish := make(chan pubsub.Event)
-	subscriptions := make(map[string][]subscription)
+// DefaultBufferSize is the number of events buffered for each subscriber.
+const DefaultBufferSize = 100
+
+// OverflowPolicy decides what happens to an event published to a subscriber
+// whose buffer is full.
+type OverflowPolicy int
+
+const (
+	// Block waits until the subscriber receives an event,
+	// its subscription is cancelled or the publish context is done.
+	// A consumer which publishes can deadlock with a Block subscriber,
+	// and publishers which can not wait must use a bounded context.
+	Block OverflowPolicy = iota
+
+	// DropOldest discards the oldest buffered event to make room for the new one.
+	DropOldest
+
+	// DropNewest discards the new event.
+	DropNewest
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case DropOldest:
+		return "drop-oldest"
+	case DropNewest:
+		return "drop-newest"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// ParseOverflowPolicy returns the policy named by s.
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	for _, p := range []OverflowPolicy{Block, DropOldest, DropNewest} {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return Block, fmt.Errorf("unknown overflow policy %q, must be block, drop-oldest or drop-newest", s)
+}
+
+type Option func(*Inmem)
+
+// WithBufferSize sets the number of events buffered for each subscriber.
+func WithBufferSize(size int) Option {
+	return func(p *Inmem) {
+		p.bufferSize = size
+	}
+}
+
+// WithOverflowPolicy sets what happens to events published to a subscriber
+// whose buffer is full. The default is Block.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(p *Inmem) {
+		p.overflow = policy
+	}
+}
+
+// WithQueueDepth reports the number of events buffered for each subscriber.
+// The gauge is labeled with "topic" and "subscriber".
+func WithQueueDepth(depth metrics.Gauge) Option {
+	return func(p *Inmem) {
+		p.depth = depth
+	}
+}
+
+// WithDropped counts the events discarded by the DropOldest and DropNewest
+// policies. The counter is labeled with "topic" and "subscriber".
+func WithDropped(dropped metrics.Counter) Option {
+	return func(p *Inmem) {
+		p.dropped = dropped
+	}
+}
+
+func NewPubSub(opts ...Option) *Inmem {
 	inmem := &Inmem{
-		publish:       publish,
-		subscriptions: subscriptions,
+		subscriptions: make(map[string][]*subscription),
+		bufferSize:    DefaultBufferSize,
+		overflow:      Block,
+		depth:         discard.NewGauge(),
+		dropped:       discard.NewCounter(),
+	}
+	for _, opt := range opts {
+		opt(inmem)
+	}
+	if inmem.bufferSize < 1 {
+		inmem.bufferSize = 1
 	}
-	go inmem.dispatch()
 	return inmem
 }
 
 type Inmem struct {
 	mtx           sync.RWMutex
-	subscriptions map[string][]subscription
+	subscriptions map[string][]*subscription
 
-	publish chan pubsub.Event
+	bufferSize int
+	overflow   OverflowPolicy
+	depth      metrics.Gauge
+	dropped    metrics.Counter
 }
 
 type subscription struct {
-	name      string
-	topic     string
-	eventChan chan<- pubsub.Event
+	name  string
+	topic string
+	queue chan pubsub.Event
+	done  <-chan struct{}
+
+	depth   metrics.Gauge
+	dropped metrics.Counter
+}
+
+// setDepth reports the number of events waiting for the subscriber.
+func (sub *subscription) setDepth() {
+	sub.depth.Set(float64(len(sub.queue)))
 }
 
-func (p *Inmem) Publish(_ context.Context, topic string, msg []byte) error {
+// Publish sends the event to every subscriber of the topic. With the Block
+// policy, Publish returns the context error if the context is done before
+// every subscriber buffered the event.
+func (p *Inmem) Publish(ctx context.Context, topic string, msg []byte) error {
 	event := pubsub.Event{Topic: topic, Message: msg}
-	go func() { p.publish <- event }()
+	p.mtx.RLock()
+	subs := p.subscriptions[topic]
+	p.mtx.RUnlock()
+	for _, sub := range subs {
+		if err := p.enqueue(ctx, sub, event); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func (p *Inmem) enqueue(ctx context.Context, sub *subscription, event pubsub.Event) error {
+	defer sub.setDepth()
+	select {
+	case sub.queue <- event:
+		return nil
+	case <-sub.done:
+		return nil
+	default:
+	}
+
+	switch p.overflow {
+	case DropNewest:
+		sub.dropped.Add(1)
+		return nil
+	case DropOldest:
+		for {
+			select {
+			case <-sub.queue:
+				sub.dropped.Add(1)
+			default:
+			}
+			select {
+			case sub.queue <- event:
+				return nil
+			case <-sub.done:
+				return nil
+			default:
+				// another publisher filled the slot, drop again.
+			}
+		}
+	default:
+		select {
+		case sub.queue <- event:
+			return nil
+		case <-sub.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
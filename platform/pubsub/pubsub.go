@@ -5,6 +5,26 @@ import "context"
 type Event struct {
 	Topic   string
 	Message []byte
+
+	ack func() error
+}
+
+// WithAck returns a copy of the event which calls ack when the event is
+// acknowledged. It is used by durable Subscriber implementations.
+func (e Event) WithAck(ack func() error) Event {
+	e.ack = ack
+	return e
+}
+
+// Ack acknowledges that the subscriber processed the event and every event
+// of the topic delivered before it. Durable Subscriber implementations deliver
+// unacknowledged events again when the subscription is resumed.
+// Ack is a no-op for events which do not require acknowledgement.
+func (e Event) Ack() error {
+	if e.ack == nil {
+		return nil
+	}
+	return e.ack()
 }
 
 type Publisher interface {
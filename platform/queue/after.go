@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/platform/queue/internal/devicecommandproto"
+)
+
+// releaseAfter returns the UUIDs of the pending commands which can be sent,
+// because every command they must be sent after was acknowledged by the
+// device. A command which must be sent after a command that failed or expired
+// is moved to Failed. A command which must be sent after a command that is
+// pending, was cancelled or is not known yet waits.
+func releaseAfter(tx *bolt.Tx, dc *DeviceCommand, now time.Time) (map[string]bool, error) {
+	statuses := make(map[string]string)
+	status := func(uuid string) (string, error) {
+		if s, ok := statuses[uuid]; ok {
+			return s, nil
+		}
+		s, err := commandStatus(tx, dc, uuid)
+		statuses[uuid] = s
+		return s, err
+	}
+
+	var released map[string]bool
+	for failed := true; failed; {
+		failed = false
+		released = make(map[string]bool)
+		for _, list := range []*[]Command{&dc.Commands, &dc.NotNow} {
+			for i := 0; i < len(*list); {
+				cmd := (*list)[i]
+				ready, reason, err := afterStatus(cmd, status)
+				if err != nil {
+					return nil, err
+				}
+				if reason == "" {
+					released[cmd.UUID] = ready
+					i++
+					continue
+				}
+				*list = append((*list)[:i], (*list)[i+1:]...)
+				resp := mdm.Response{
+					Status:     "Error",
+					ErrorChain: mdm.ErrorChain{{USEnglishDescription: reason}},
+				}
+				if err := markFailed(&cmd, resp, now); err != nil {
+					return nil, err
+				}
+				dc.Failed = append(dc.Failed, cmd)
+				statuses[cmd.UUID] = StatusFailed
+				failed = true
+			}
+		}
+	}
+	return released, nil
+}
+
+// afterStatus reports whether every command cmd must be sent after was
+// acknowledged. If one of them failed or expired, the reason why cmd fails
+// as well is returned.
+func afterStatus(cmd Command, status func(uuid string) (string, error)) (bool, string, error) {
+	ready := true
+	for _, uuid := range cmd.After {
+		if uuid == cmd.UUID {
+			continue
+		}
+		s, err := status(uuid)
+		if err != nil {
+			return false, "", err
+		}
+		switch s {
+		case StatusCompleted:
+		case StatusFailed, StatusExpired:
+			return false, fmt.Sprintf("command %s which must be sent before is %s", uuid, s), nil
+		default:
+			ready = false
+		}
+	}
+	return ready, "", nil
+}
+
+// commandStatus returns the status of a command in the queue or the command
+// history of the device, or an empty string if the command is not known.
+func commandStatus(tx *bolt.Tx, dc *DeviceCommand, uuid string) (string, error) {
+	lists := []struct {
+		status   string
+		commands []Command
+	}{
+		{StatusPending, dc.Commands},
+		{StatusNotNow, dc.NotNow},
+		{StatusCompleted, dc.Completed},
+		{StatusFailed, dc.Failed},
+		{StatusExpired, dc.Expired},
+	}
+	for _, l := range lists {
+		for _, cmd := range l.commands {
+			if cmd.UUID == uuid {
+				return l.status, nil
+			}
+		}
+	}
+
+	var status string
+	prefix := historyPrefix(dc.DeviceUDID)
+	c := tx.Bucket([]byte(DeviceCommandHistoryBucket)).Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if string(k[len(prefix)+8:]) != uuid {
+			continue
+		}
+		var pb devicecommandproto.HistoryCommand
+		if err := proto.Unmarshal(v, &pb); err != nil {
+			return "", errors.Wrap(err, "unmarshal proto to HistoryCommand")
+		}
+		status = pb.GetStatus()
+	}
+	return status, nil
+}
@@ -0,0 +1,99 @@
+package queue
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// CancelCommand removes a command which was not yet acknowledged by the device
+// from the queue.
+func (svc *QueueService) CancelCommand(ctx context.Context, udid, uuid string) error {
+	err := svc.store.UpdateDeviceCommand(udid, func(dc *DeviceCommand) error {
+		var cmd *Command
+		cmd, dc.Commands = cut(dc.Commands, uuid)
+		if cmd == nil {
+			cmd, dc.NotNow = cut(dc.NotNow, uuid)
+		}
+		if cmd == nil {
+			return &notFound{"Command", "pending command uuid " + uuid}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return svc.publishQueueEvent(ctx, NewQueueEvent(ActionCancel, udid, uuid))
+}
+
+func (svc *QueueService) publishQueueEvent(ctx context.Context, event *QueueEvent) error {
+	msg, err := MarshalQueueEvent(event)
+	if err != nil {
+		return errors.Wrap(err, "marshal queue event")
+	}
+	if err := svc.publisher.Publish(ctx, QueueChangedTopic, msg); err != nil {
+		return errors.Wrapf(err, "publish queue event on topic: %s", QueueChangedTopic)
+	}
+	return nil
+}
+
+type cancelCommandRequest struct {
+	UDID string
+	UUID string
+}
+
+type cancelCommandResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r cancelCommandResponse) Failed() error { return r.Err }
+
+func decodeCancelCommandRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var errBadRoute = errors.New("bad route")
+	vars := mux.Vars(r)
+	udid, ok := vars["udid"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	uuid, ok := vars["uuid"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	return cancelCommandRequest{UDID: udid, UUID: uuid}, nil
+}
+
+func encodeCancelCommandRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(cancelCommandRequest)
+	udid, uuid := url.QueryEscape(req.UDID), url.QueryEscape(req.UUID)
+	r.Method, r.URL.Path = "DELETE", "/v1/devices/"+udid+"/commands/"+uuid
+	return nil
+}
+
+func decodeCancelCommandResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp cancelCommandResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeCancelCommandEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(cancelCommandRequest)
+		err = svc.CancelCommand(ctx, req.UDID, req.UUID)
+		return cancelCommandResponse{Err: err}, nil
+	}
+}
+
+func (e Endpoints) CancelCommand(ctx context.Context, udid, uuid string) error {
+	request := cancelCommandRequest{UDID: udid, UUID: uuid}
+	resp, err := e.CancelCommandEndpoint(ctx, request)
+	if err != nil {
+		return err
+	}
+	return resp.(cancelCommandResponse).Err
+}
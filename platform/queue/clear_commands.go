@@ -0,0 +1,88 @@
+package queue
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// ClearCommands removes all pending commands, including the ones the device
+// refused with NotNow, from the queue of a device.
+// The Completed and Failed history is kept.
+func (svc *QueueService) ClearCommands(ctx context.Context, udid string) error {
+	var uuids []string
+	err := svc.store.UpdateDeviceCommand(udid, func(dc *DeviceCommand) error {
+		for _, commands := range [][]Command{dc.Commands, dc.NotNow} {
+			for _, cmd := range commands {
+				uuids = append(uuids, cmd.UUID)
+			}
+		}
+		dc.Commands, dc.NotNow = nil, nil
+		return nil
+	})
+	if isNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if len(uuids) == 0 {
+		return nil
+	}
+	return svc.publishQueueEvent(ctx, NewQueueEvent(ActionClear, udid, uuids...))
+}
+
+type clearCommandsRequest struct {
+	UDID string
+}
+
+type clearCommandsResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r clearCommandsResponse) Failed() error { return r.Err }
+
+func decodeClearCommandsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var errBadRoute = errors.New("bad route")
+	vars := mux.Vars(r)
+	udid, ok := vars["udid"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	return clearCommandsRequest{UDID: udid}, nil
+}
+
+func encodeClearCommandsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(clearCommandsRequest)
+	udid := url.QueryEscape(req.UDID)
+	r.Method, r.URL.Path = "DELETE", "/v1/devices/"+udid+"/commands"
+	return nil
+}
+
+func decodeClearCommandsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp clearCommandsResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeClearCommandsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(clearCommandsRequest)
+		err = svc.ClearCommands(ctx, req.UDID)
+		return clearCommandsResponse{Err: err}, nil
+	}
+}
+
+func (e Endpoints) ClearCommands(ctx context.Context, udid string) error {
+	request := clearCommandsRequest{UDID: udid}
+	resp, err := e.ClearCommandsEndpoint(ctx, request)
+	if err != nil {
+		return err
+	}
+	return resp.(clearCommandsResponse).Err
+}
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransport.ClientOption) (Service, error) {
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var getCommandsEndpoint endpoint.Endpoint
+	{
+		getCommandsEndpoint = httptransport.NewClient(
+			"GET",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeGetCommandsRequest),
+			decodeGetCommandsResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var cancelCommandEndpoint endpoint.Endpoint
+	{
+		cancelCommandEndpoint = httptransport.NewClient(
+			"DELETE",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeCancelCommandRequest),
+			decodeCancelCommandResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var retryCommandEndpoint endpoint.Endpoint
+	{
+		retryCommandEndpoint = httptransport.NewClient(
+			"POST",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeRetryCommandRequest),
+			decodeRetryCommandResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var clearCommandsEndpoint endpoint.Endpoint
+	{
+		clearCommandsEndpoint = httptransport.NewClient(
+			"DELETE",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeClearCommandsRequest),
+			decodeClearCommandsResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var getBatchEndpoint endpoint.Endpoint
+	{
+		getBatchEndpoint = httptransport.NewClient(
+			"GET",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeGetBatchRequest),
+			decodeGetBatchResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	return Endpoints{
+		GetCommandsEndpoint:   getCommandsEndpoint,
+		CancelCommandEndpoint: cancelCommandEndpoint,
+		RetryCommandEndpoint:  retryCommandEndpoint,
+		ClearCommandsEndpoint: clearCommandsEndpoint,
+		GetBatchEndpoint:      getBatchEndpoint,
+	}, nil
+}
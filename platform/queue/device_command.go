@@ -21,154 +21,139 @@ type Command struct {
 
 	LastStatus     string
 	FailureMessage []byte
+
+	// ExpiresAt is the time after which the command is moved to Expired
+	// if the device has not acknowledged it.
+	ExpiresAt time.Time
+
+	// NotNowCount is the number of times the device responded with NotNow.
+	NotNowCount int
+
+	// Priority orders the pending commands. Higher priorities are sent first.
+	Priority int
+
+	// After holds the UUIDs of commands which the device must acknowledge
+	// before this command is sent. The command fails if one of them fails
+	// or expires.
+	After []string
 }
 
 type DeviceCommand struct {
 	DeviceUDID string
 	Commands   []Command
 
-	// These are going to scale great. We'll have to see.
+	// Completed, Failed and Expired are moved to the history bucket when
+	// the DeviceCommand is saved. See Store.History.
 	Completed []Command
 	Failed    []Command
 	NotNow    []Command
+	Expired   []Command
 }
 
+// Command statuses, as reported by the queue API. Each status corresponds
+// to one of the DeviceCommand lists.
+const (
+	StatusPending   = "pending"
+	StatusNotNow    = "not_now"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusExpired   = "expired"
+)
+
 func MarshalDeviceCommand(c *DeviceCommand) ([]byte, error) {
 	protoc := devicecommandproto.DeviceCommand{
 		DeviceUdid: c.DeviceUDID,
+		Commands:   commandsToProto(c.Commands),
+		Completed:  commandsToProto(c.Completed),
+		Failed:     commandsToProto(c.Failed),
+		NotNow:     commandsToProto(c.NotNow),
+		Expired:    commandsToProto(c.Expired),
 	}
+	return proto.Marshal(&protoc)
+}
 
-	// TODO add helper here to reduce copy/pasted boilerplate.
-	for _, command := range c.Commands {
-		protoc.Commands = append(protoc.Commands, &devicecommandproto.Command{
-			Uuid:         command.UUID,
-			Payload:      command.Payload,
-			CreatedAt:    command.CreatedAt.UnixNano(),
-			LastSentAt:   command.LastSentAt.UnixNano(),
-			Acknowledged: command.Acknowledged.UnixNano(),
-
-			TimesSent: int64(command.TimesSent),
-
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
+func UnmarshalDeviceCommand(data []byte, c *DeviceCommand) error {
+	var pb devicecommandproto.DeviceCommand
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal proto to DeviceCommand")
 	}
+	c.DeviceUDID = pb.GetDeviceUdid()
+	c.Commands = commandsFromProto(pb.GetCommands())
+	c.Completed = commandsFromProto(pb.GetCompleted())
+	c.Failed = commandsFromProto(pb.GetFailed())
+	c.NotNow = commandsFromProto(pb.GetNotNow())
+	c.Expired = commandsFromProto(pb.GetExpired())
+	return nil
+}
 
-	for _, command := range c.Completed {
-		protoc.Completed = append(protoc.Completed, &devicecommandproto.Command{
-			Uuid:         command.UUID,
-			Payload:      command.Payload,
-			CreatedAt:    command.CreatedAt.UnixNano(),
-			LastSentAt:   command.LastSentAt.UnixNano(),
-			Acknowledged: command.Acknowledged.UnixNano(),
-
-			TimesSent: int64(command.TimesSent),
-
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
+func commandsToProto(commands []Command) []*devicecommandproto.Command {
+	var pb []*devicecommandproto.Command
+	for _, command := range commands {
+		pb = append(pb, commandToProto(command))
 	}
+	return pb
+}
 
-	for _, command := range c.Failed {
-		protoc.Failed = append(protoc.Failed, &devicecommandproto.Command{
-			Uuid:         command.UUID,
-			Payload:      command.Payload,
-			CreatedAt:    command.CreatedAt.UnixNano(),
-			LastSentAt:   command.LastSentAt.UnixNano(),
-			Acknowledged: command.Acknowledged.UnixNano(),
-
-			TimesSent: int64(command.TimesSent),
+func commandToProto(command Command) *devicecommandproto.Command {
+	return &devicecommandproto.Command{
+		Uuid:         command.UUID,
+		Payload:      command.Payload,
+		CreatedAt:    timeToNano(command.CreatedAt),
+		LastSentAt:   timeToNano(command.LastSentAt),
+		Acknowledged: timeToNano(command.Acknowledged),
 
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
-	}
+		TimesSent: int64(command.TimesSent),
 
-	for _, command := range c.NotNow {
-		protoc.NotNow = append(protoc.NotNow, &devicecommandproto.Command{
-			Uuid:         command.UUID,
-			Payload:      command.Payload,
-			CreatedAt:    command.CreatedAt.UnixNano(),
-			LastSentAt:   command.LastSentAt.UnixNano(),
-			Acknowledged: command.Acknowledged.UnixNano(),
+		LastStatus:     command.LastStatus,
+		FailureMessage: command.FailureMessage,
 
-			TimesSent: int64(command.TimesSent),
+		ExpiresAt:   timeToNano(command.ExpiresAt),
+		NotNowCount: int64(command.NotNowCount),
 
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
+		Priority: int64(command.Priority),
+		After:    command.After,
 	}
-	return proto.Marshal(&protoc)
 }
 
-func UnmarshalDeviceCommand(data []byte, c *DeviceCommand) error {
-	var pb devicecommandproto.DeviceCommand
-	if err := proto.Unmarshal(data, &pb); err != nil {
-		return errors.Wrap(err, "unmarshal proto to DeviceCommand")
-	}
-	c.DeviceUDID = pb.GetDeviceUdid()
-	protoCommands := pb.GetCommands()
-	protoCommandsCompleted := pb.GetCompleted()
-	protoCommandsFailed := pb.GetFailed()
-	protoCommandsNotNow := pb.GetNotNow()
-	for _, command := range protoCommands {
-		c.Commands = append(c.Commands, Command{
-			UUID:         command.GetUuid(),
-			Payload:      command.GetPayload(),
-			CreatedAt:    time.Unix(0, command.GetCreatedAt()).UTC(),
-			LastSentAt:   time.Unix(0, command.GetLastSentAt()).UTC(),
-			Acknowledged: time.Unix(0, command.GetAcknowledged()).UTC(),
-
-			TimesSent: int(command.TimesSent),
-
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
+func commandsFromProto(pb []*devicecommandproto.Command) []Command {
+	var commands []Command
+	for _, command := range pb {
+		commands = append(commands, commandFromProto(command))
 	}
+	return commands
+}
 
-	for _, command := range protoCommandsCompleted {
-		c.Completed = append(c.Completed, Command{
-			UUID:         command.GetUuid(),
-			Payload:      command.GetPayload(),
-			CreatedAt:    time.Unix(0, command.GetCreatedAt()).UTC(),
-			LastSentAt:   time.Unix(0, command.GetLastSentAt()).UTC(),
-			Acknowledged: time.Unix(0, command.GetAcknowledged()).UTC(),
+func commandFromProto(command *devicecommandproto.Command) Command {
+	return Command{
+		UUID:         command.GetUuid(),
+		Payload:      command.GetPayload(),
+		CreatedAt:    timeFromNano(command.GetCreatedAt()),
+		LastSentAt:   timeFromNano(command.GetLastSentAt()),
+		Acknowledged: timeFromNano(command.GetAcknowledged()),
 
-			TimesSent: int(command.TimesSent),
+		TimesSent: int(command.GetTimesSent()),
 
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
-	}
+		LastStatus:     command.GetLastStatus(),
+		FailureMessage: command.GetFailureMessage(),
 
-	for _, command := range protoCommandsFailed {
-		c.Failed = append(c.Failed, Command{
-			UUID:         command.GetUuid(),
-			Payload:      command.GetPayload(),
-			CreatedAt:    time.Unix(0, command.GetCreatedAt()).UTC(),
-			LastSentAt:   time.Unix(0, command.GetLastSentAt()).UTC(),
-			Acknowledged: time.Unix(0, command.GetAcknowledged()).UTC(),
+		ExpiresAt:   timeFromNano(command.GetExpiresAt()),
+		NotNowCount: int(command.GetNotNowCount()),
 
-			TimesSent: int(command.TimesSent),
-
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
+		Priority: int(command.GetPriority()),
+		After:    command.GetAfter(),
 	}
+}
 
-	for _, command := range protoCommandsNotNow {
-		c.NotNow = append(c.NotNow, Command{
-			UUID:         command.GetUuid(),
-			Payload:      command.GetPayload(),
-			CreatedAt:    time.Unix(0, command.GetCreatedAt()).UTC(),
-			LastSentAt:   time.Unix(0, command.GetLastSentAt()).UTC(),
-			Acknowledged: time.Unix(0, command.GetAcknowledged()).UTC(),
-
-			TimesSent: int(command.TimesSent),
+func timeToNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
 
-			LastStatus:     command.LastStatus,
-			FailureMessage: command.FailureMessage,
-		})
+func timeFromNano(nano int64) time.Time {
+	if nano == 0 {
+		return time.Time{}
 	}
-	return nil
+	return time.Unix(0, nano).UTC()
 }
@@ -0,0 +1,69 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/as/micromdm/pkg/backoff"
+)
+
+// NotNowPolicy limits how often a command the device refused with NotNow
+// is sent again. The zero value resends the command whenever the device
+// has no other pending commands.
+type NotNowPolicy struct {
+	// MaxRetries is the number of times a command is sent again after a
+	// NotNow response before it expires. Zero retries forever.
+	MaxRetries int
+
+	// Backoff is the delay after the first NotNow response before the
+	// command is sent again. The delay doubles with each NotNow response.
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between retries. Zero means no cap.
+	MaxBackoff time.Duration
+}
+
+// WithNotNowPolicy sets the retry policy of commands refused with NotNow.
+func WithNotNowPolicy(policy NotNowPolicy) Option {
+	return func(db *Store) {
+		db.notNow = policy
+	}
+}
+
+// delay returns the time to wait after the last send of a command which was
+// refused notNowCount times.
+func (p NotNowPolicy) delay(notNowCount int) time.Duration {
+	return backoff.Policy{Backoff: p.Backoff, MaxBackoff: p.MaxBackoff}.Delay(notNowCount)
+}
+
+// exhausted reports whether a command was refused more often than the
+// policy allows.
+func (p NotNowPolicy) exhausted(cmd *Command) bool {
+	return p.MaxRetries > 0 && cmd.NotNowCount > p.MaxRetries
+}
+
+// due reports whether the back-off of a refused command has passed.
+func (p NotNowPolicy) due(cmd Command, now time.Time) bool {
+	return !cmd.LastSentAt.Add(p.delay(cmd.NotNowCount)).After(now)
+}
+
+// expire moves the pending commands which are past their ExpiresAt
+// time to Expired.
+func expire(dc *DeviceCommand, now time.Time) {
+	var expired []Command
+	expired, dc.Commands = cutExpired(dc.Commands, now)
+	dc.Expired = append(dc.Expired, expired...)
+	expired, dc.NotNow = cutExpired(dc.NotNow, now)
+	dc.Expired = append(dc.Expired, expired...)
+}
+
+func cutExpired(all []Command, now time.Time) (expired, rest []Command) {
+	rest = all[:0]
+	for _, cmd := range all {
+		if !cmd.ExpiresAt.IsZero() && !cmd.ExpiresAt.After(now) {
+			expired = append(expired, cmd)
+			continue
+		}
+		rest = append(rest, cmd)
+	}
+	return expired, rest
+}
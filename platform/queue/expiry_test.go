@@ -0,0 +1,161 @@
+package queue
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/as/micromdm/mdm"
+)
+
+func TestNext_Expired(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, Command{UUID: "xCmd", ExpiresAt: now.Add(-time.Minute)})
+	dc.Commands = append(dc.Commands, Command{UUID: "yCmd", ExpiresAt: now.Add(time.Hour)})
+	dc.NotNow = append(dc.NotNow, Command{UUID: "zCmd", ExpiresAt: now.Add(-time.Second)})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd, err := store.Next(context.Background(), mdm.Response{UDID: dc.DeviceUDID, Status: "Idle"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd == nil || cmd.UUID != "yCmd" {
+		t.Fatalf("expected yCmd to be sent, got %+v", cmd)
+	}
+
+	history, err := store.History(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(history.Expired), []string{"xCmd", "zCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+}
+
+func TestGetCommands_Expired(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	expiring := func(uuid string, expiresAt time.Time) Command {
+		cmd := testCommand(t, uuid, "DeviceInformation", now)
+		cmd.ExpiresAt = expiresAt
+		return cmd
+	}
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, expiring("xCmd", now.Add(-time.Minute)))
+	dc.Commands = append(dc.Commands, expiring("yCmd", now.Add(time.Hour)))
+	dc.NotNow = append(dc.NotNow, expiring("zCmd", now.Add(-time.Second)))
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(store, nil, nil)
+
+	commands, err := svc.GetCommands(context.Background(), dc.DeviceUDID, GetCommandsOption{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := make(map[string]string)
+	for _, cmd := range commands {
+		have[cmd.UUID] = cmd.Status
+	}
+	want := map[string]string{"xCmd": StatusExpired, "yCmd": StatusPending, "zCmd": StatusExpired}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+}
+
+func TestNext_NotNowBackoff(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+	WithNotNowPolicy(NotNowPolicy{MaxRetries: 2, Backoff: time.Hour})(store)
+
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, Command{UUID: "xCmd"})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	idle := mdm.Response{UDID: dc.DeviceUDID, Status: "Idle"}
+	notNow := mdm.Response{UDID: dc.DeviceUDID, CommandUUID: "xCmd", Status: "NotNow"}
+
+	// age moves the last send of the refused command into the past.
+	age := func(d time.Duration) {
+		dc, err := store.DeviceCommand(dc.DeviceUDID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range dc.NotNow {
+			dc.NotNow[i].LastSentAt = dc.NotNow[i].LastSentAt.Add(-d)
+		}
+		if err := store.Save(dc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 1; i <= 3; i++ {
+		cmd, err := store.Next(ctx, idle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd == nil || cmd.UUID != "xCmd" {
+			t.Fatalf("attempt %d: expected xCmd to be sent, got %+v", i, cmd)
+		}
+		if _, err := store.Next(ctx, notNow); err != nil {
+			t.Fatal(err)
+		}
+
+		// the back-off doubles with every NotNow response.
+		backoff := time.Duration(1<<uint(i-1)) * time.Hour
+		age(backoff - time.Minute)
+		if cmd, err := store.Next(ctx, idle); err != nil {
+			t.Fatal(err)
+		} else if cmd != nil {
+			t.Fatalf("attempt %d: expected no command during back-off, got %s", i, cmd.UUID)
+		}
+		age(time.Minute)
+	}
+
+	cmd, err := store.Next(ctx, idle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd != nil {
+		t.Fatalf("expected command to expire after max retries, got %s", cmd.UUID)
+	}
+	history, err := store.History(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Expired) != 1 || history.Expired[0].NotNowCount != 3 {
+		t.Errorf("expected xCmd to expire after 3 NotNow responses, got %+v", history.Expired)
+	}
+}
+
+func TestNotNowPolicy_delay(t *testing.T) {
+	p := NotNowPolicy{Backoff: time.Minute, MaxBackoff: 10 * time.Minute}
+	tests := []struct {
+		count int
+		want  time.Duration
+	}{
+		{0, 0},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{5, 10 * time.Minute},
+		{100, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if have := p.delay(tt.count); have != tt.want {
+			t.Errorf("count %d: have %s, want %s", tt.count, have, tt.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package queue
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+	"github.com/as/micromdm/platform/command"
+)
+
+// StatusUnknown is reported for a batch command which is not in the queue
+// of the device. The command was cancelled, removed by the history retention
+// or has not been queued yet.
+const StatusUnknown = "unknown"
+
+// BatchStore provides the batches of commands created by the command service.
+type BatchStore interface {
+	Batch(id string) (*command.Batch, error)
+}
+
+// BatchDTO describes the progress of a batch of commands.
+type BatchDTO struct {
+	ID          string         `json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	RequestType string         `json:"request_type"`
+	Total       int            `json:"total"`
+	Counts      map[string]int `json:"counts"`
+
+	// Done is true once every command of the batch is completed,
+	// failed or expired.
+	Done bool `json:"done"`
+
+	Commands []BatchCommandDTO `json:"commands"`
+}
+
+// BatchCommandDTO is the status of a single command of a batch.
+type BatchCommandDTO struct {
+	UDID        string `json:"udid"`
+	CommandUUID string `json:"command_uuid"`
+	Status      string `json:"status"`
+	LastStatus  string `json:"last_status,omitempty"`
+}
+
+func (svc *QueueService) GetBatch(ctx context.Context, id string) (*BatchDTO, error) {
+	if svc.batches == nil {
+		return nil, errors.New("command batches are not supported")
+	}
+	batch, err := svc.batches.Batch(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get command batch %s", id)
+	}
+
+	dto := &BatchDTO{
+		ID:          batch.ID,
+		CreatedAt:   batch.CreatedAt,
+		RequestType: batch.RequestType,
+		Total:       len(batch.Commands),
+		Counts:      make(map[string]int),
+		Done:        true,
+	}
+	statuses := make(map[string]map[string]BatchCommandDTO)
+	for _, bc := range batch.Commands {
+		byUUID, ok := statuses[bc.UDID]
+		if !ok {
+			byUUID, err = svc.commandStatuses(bc.UDID)
+			if err != nil {
+				return nil, err
+			}
+			statuses[bc.UDID] = byUUID
+		}
+		c, ok := byUUID[bc.CommandUUID]
+		if !ok {
+			c = BatchCommandDTO{UDID: bc.UDID, CommandUUID: bc.CommandUUID, Status: StatusUnknown}
+		}
+		switch c.Status {
+		case StatusCompleted, StatusFailed, StatusExpired:
+		default:
+			dto.Done = false
+		}
+		dto.Counts[c.Status]++
+		dto.Commands = append(dto.Commands, c)
+	}
+	return dto, nil
+}
+
+// commandStatuses returns the status of every command in the queue and
+// history of a device, by command UUID.
+func (svc *QueueService) commandStatuses(udid string) (map[string]BatchCommandDTO, error) {
+	dc, history, err := svc.deviceCommands(udid, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	byUUID := make(map[string]BatchCommandDTO)
+	lists := []struct {
+		status   string
+		commands []Command
+	}{
+		{StatusPending, dc.Commands},
+		{StatusNotNow, dc.NotNow},
+		{StatusCompleted, history.Completed},
+		{StatusFailed, history.Failed},
+		{StatusExpired, history.Expired},
+		{StatusExpired, dc.Expired},
+	}
+	for _, l := range lists {
+		for _, cmd := range l.commands {
+			byUUID[cmd.UUID] = BatchCommandDTO{
+				UDID:        udid,
+				CommandUUID: cmd.UUID,
+				Status:      l.status,
+				LastStatus:  cmd.LastStatus,
+			}
+		}
+	}
+	return byUUID, nil
+}
+
+type getBatchRequest struct {
+	ID string
+}
+
+type getBatchResponse struct {
+	Batch *BatchDTO `json:"batch,omitempty"`
+	Err   error     `json:"err,omitempty"`
+}
+
+func (r getBatchResponse) Failed() error { return r.Err }
+
+func decodeGetBatchRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var errBadRoute = errors.New("bad route")
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	return getBatchRequest{ID: id}, nil
+}
+
+func encodeGetBatchRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(getBatchRequest)
+	r.Method, r.URL.Path = "GET", "/v1/batches/"+url.QueryEscape(req.ID)
+	return nil
+}
+
+func decodeGetBatchResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp getBatchResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeGetBatchEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(getBatchRequest)
+		batch, err := svc.GetBatch(ctx, req.ID)
+		return getBatchResponse{Batch: batch, Err: err}, nil
+	}
+}
+
+func (e Endpoints) GetBatch(ctx context.Context, id string) (*BatchDTO, error) {
+	request := getBatchRequest{ID: id}
+	resp, err := e.GetBatchEndpoint(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(getBatchResponse).Batch, resp.(getBatchResponse).Err
+}
@@ -0,0 +1,194 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/groob/plist"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// GetCommandsOption filters the commands returned by GetCommands.
+// Zero values are ignored.
+type GetCommandsOption struct {
+	Status      string    `json:"status,omitempty"`
+	RequestType string    `json:"request_type,omitempty"`
+	After       time.Time `json:"after,omitempty"`
+	Before      time.Time `json:"before,omitempty"`
+}
+
+// CommandDTO describes a single command in a device queue.
+type CommandDTO struct {
+	UUID         string    `json:"uuid"`
+	Status       string    `json:"status"`
+	RequestType  string    `json:"request_type"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastSentAt   time.Time `json:"last_sent_at"`
+	Acknowledged time.Time `json:"acknowledged"`
+	TimesSent    int       `json:"times_sent"`
+	LastStatus   string    `json:"last_status,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	NotNowCount  int       `json:"not_now_count"`
+	Priority     int       `json:"priority"`
+	After        []string  `json:"after,omitempty"`
+
+	// ErrorChain is the error reported by the device for failed commands.
+	ErrorChain mdm.ErrorChain `json:"error_chain,omitempty"`
+}
+
+func (svc *QueueService) GetCommands(ctx context.Context, udid string, opt GetCommandsOption) ([]CommandDTO, error) {
+	dc, history, err := svc.deviceCommands(udid, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	dto := []CommandDTO{}
+	lists := []struct {
+		status   string
+		commands []Command
+	}{
+		{StatusPending, dc.Commands},
+		{StatusNotNow, dc.NotNow},
+		{StatusCompleted, history.Completed},
+		{StatusFailed, history.Failed},
+		{StatusExpired, history.Expired},
+		{StatusExpired, dc.Expired},
+	}
+	for _, l := range lists {
+		if opt.Status != "" && opt.Status != l.status {
+			continue
+		}
+		for _, cmd := range l.commands {
+			if !opt.After.IsZero() && !cmd.CreatedAt.After(opt.After) {
+				continue
+			}
+			if !opt.Before.IsZero() && !cmd.CreatedAt.Before(opt.Before) {
+				continue
+			}
+			requestType := payloadRequestType(cmd.Payload)
+			if opt.RequestType != "" && opt.RequestType != requestType {
+				continue
+			}
+			c := CommandDTO{
+				UUID:         cmd.UUID,
+				Status:       l.status,
+				RequestType:  requestType,
+				CreatedAt:    cmd.CreatedAt,
+				LastSentAt:   cmd.LastSentAt,
+				Acknowledged: cmd.Acknowledged,
+				TimesSent:    cmd.TimesSent,
+				LastStatus:   cmd.LastStatus,
+				ExpiresAt:    cmd.ExpiresAt,
+				NotNowCount:  cmd.NotNowCount,
+				Priority:     cmd.Priority,
+				After:        cmd.After,
+			}
+			if len(cmd.FailureMessage) > 0 {
+				if err := json.Unmarshal(cmd.FailureMessage, &c.ErrorChain); err != nil {
+					return nil, errors.Wrapf(err, "unmarshal ErrorChain of command %s", cmd.UUID)
+				}
+			}
+			dto = append(dto, c)
+		}
+	}
+	return dto, nil
+}
+
+// deviceCommands returns the queue and the history of a device. Commands
+// of the queue which are past their ExpiresAt are returned in the Expired
+// list of the queue, without waiting for the next check-in to move them.
+func (svc *QueueService) deviceCommands(udid string, now time.Time) (*DeviceCommand, *DeviceCommand, error) {
+	dc, err := svc.store.DeviceCommand(udid)
+	if err != nil && !isNotFound(err) {
+		return nil, nil, errors.Wrapf(err, "get device command from queue, udid: %s", udid)
+	}
+	if dc == nil {
+		dc = &DeviceCommand{DeviceUDID: udid}
+	}
+	history, err := svc.store.History(udid)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "get command history, udid: %s", udid)
+	}
+	expire(dc, now)
+	return dc, history, nil
+}
+
+// payloadRequestType returns the RequestType of a queued plist payload.
+func payloadRequestType(payload []byte) string {
+	var p mdm.Payload
+	if err := plist.Unmarshal(payload, &p); err != nil || p.Command == nil {
+		return ""
+	}
+	return p.Command.RequestType
+}
+
+type getCommandsRequest struct {
+	UDID string
+	Opts GetCommandsOption
+}
+
+type getCommandsResponse struct {
+	Commands []CommandDTO `json:"commands"`
+	Err      error        `json:"err,omitempty"`
+}
+
+func (r getCommandsResponse) Failed() error { return r.Err }
+
+func decodeGetCommandsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var errBadRoute = errors.New("bad route")
+	var req getCommandsRequest
+	vars := mux.Vars(r)
+	udid, ok := vars["udid"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	req.UDID = udid
+	// the filter options are optional, so an empty body is allowed.
+	if err := json.NewDecoder(r.Body).Decode(&req.Opts); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return req, nil
+}
+
+func encodeGetCommandsRequest(ctx context.Context, r *http.Request, request interface{}) error {
+	req := request.(getCommandsRequest)
+	udid := url.QueryEscape(req.UDID)
+	r.Method, r.URL.Path = "GET", "/v1/devices/"+udid+"/commands"
+	return httptransport.EncodeJSONRequest(ctx, r, req.Opts)
+}
+
+func decodeGetCommandsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp getCommandsResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeGetCommandsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(getCommandsRequest)
+		commands, err := svc.GetCommands(ctx, req.UDID, req.Opts)
+		return getCommandsResponse{
+			Commands: commands,
+			Err:      err,
+		}, nil
+	}
+}
+
+func (e Endpoints) GetCommands(ctx context.Context, udid string, opt GetCommandsOption) ([]CommandDTO, error) {
+	request := getCommandsRequest{UDID: udid, Opts: opt}
+	response, err := e.GetCommandsEndpoint(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response.(getCommandsResponse).Commands, response.(getCommandsResponse).Err
+}
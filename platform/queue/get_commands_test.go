@@ -0,0 +1,68 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/groob/plist"
+
+	"github.com/as/micromdm/mdm"
+)
+
+func TestGetCommands(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, testCommand(t, "xCmd", "DeviceInformation", now))
+	dc.NotNow = append(dc.NotNow, testCommand(t, "yCmd", "InstallProfile", now.Add(-time.Hour)))
+	dc.Failed = append(dc.Failed, testCommand(t, "zCmd", "InstallProfile", now.Add(-2*time.Hour)))
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(store, nil, nil)
+
+	tests := []struct {
+		name string
+		udid string
+		opt  GetCommandsOption
+		want []string
+	}{
+		{name: "all", udid: dc.DeviceUDID, want: []string{"xCmd", "yCmd", "zCmd"}},
+		{name: "status", udid: dc.DeviceUDID, opt: GetCommandsOption{Status: StatusFailed}, want: []string{"zCmd"}},
+		{name: "request_type", udid: dc.DeviceUDID, opt: GetCommandsOption{RequestType: "InstallProfile"}, want: []string{"yCmd", "zCmd"}},
+		{name: "after", udid: dc.DeviceUDID, opt: GetCommandsOption{After: now.Add(-90 * time.Minute)}, want: []string{"xCmd", "yCmd"}},
+		{name: "before", udid: dc.DeviceUDID, opt: GetCommandsOption{Before: now.Add(-90 * time.Minute)}, want: []string{"zCmd"}},
+		{name: "unknown device", udid: "UnknownDevice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := svc.GetCommands(context.Background(), tt.udid, tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := len(commands), len(tt.want); have != want {
+				t.Fatalf("have %d commands, want %d", have, want)
+			}
+			for i, cmd := range commands {
+				if have, want := cmd.UUID, tt.want[i]; have != want {
+					t.Errorf("have %s, want %s, index %d", have, want, i)
+				}
+			}
+		})
+	}
+}
+
+func testCommand(t *testing.T, uuid, requestType string, created time.Time) Command {
+	payload, err := plist.Marshal(&mdm.Payload{
+		CommandUUID: uuid,
+		Command:     &mdm.Command{RequestType: requestType},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Command{UUID: uuid, Payload: payload, CreatedAt: created}
+}
@@ -0,0 +1,313 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/queue/internal/devicecommandproto"
+)
+
+// DeviceCommandHistoryBucket holds the Completed, Failed and Expired commands
+// of all devices. Keys are ordered by device and by the time the device responded,
+// which keeps the per-device record in DeviceCommandBucket small.
+const DeviceCommandHistoryBucket = "mdm.DeviceCommandHistory"
+
+// historyJanitorInterval is how often history entries older than
+// RetentionPolicy.MaxAge are removed.
+const historyJanitorInterval = time.Hour
+
+// RetentionPolicy limits the command history kept for each device.
+// Zero values keep the history forever.
+type RetentionPolicy struct {
+	// MaxEntries is the number of Completed, Failed and Expired commands
+	// kept per device. The oldest entries are removed first.
+	MaxEntries int
+
+	// MaxAge is the duration after which a Completed, Failed or Expired
+	// command is removed.
+	MaxAge time.Duration
+}
+
+type Option func(*Store)
+
+// WithRetention sets the retention policy of the command history.
+func WithRetention(policy RetentionPolicy) Option {
+	return func(db *Store) {
+		db.retention = policy
+	}
+}
+
+// History returns the Completed, Failed and Expired commands of a device,
+// oldest first.
+func (db *Store) History(udid string) (*DeviceCommand, error) {
+	dc := DeviceCommand{DeviceUDID: udid}
+	err := db.View(func(tx *bolt.Tx) error {
+		prefix := historyPrefix(udid)
+		c := tx.Bucket([]byte(DeviceCommandHistoryBucket)).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var pb devicecommandproto.HistoryCommand
+			if err := proto.Unmarshal(v, &pb); err != nil {
+				return errors.Wrap(err, "unmarshal proto to HistoryCommand")
+			}
+			cmd := commandFromProto(pb.GetCommand())
+			switch pb.GetStatus() {
+			case StatusCompleted:
+				dc.Completed = append(dc.Completed, cmd)
+			case StatusFailed:
+				dc.Failed = append(dc.Failed, cmd)
+			case StatusExpired:
+				dc.Expired = append(dc.Expired, cmd)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dc, nil
+}
+
+// Requeue moves a Failed or Expired command from the history of a device to
+// the end of its pending commands, in a single transaction. reset is called
+// with the command before it is queued. If the command failed or expired more
+// than once, the latest entry is requeued and the other entries are kept.
+func (db *Store) Requeue(udid, uuid string, reset func(*Command)) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(DeviceCommandHistoryBucket))
+		prefix := historyPrefix(udid)
+		var (
+			cmd *Command
+			key []byte
+		)
+		c := bkt.Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if string(k[len(prefix)+8:]) != uuid {
+				continue
+			}
+			var pb devicecommandproto.HistoryCommand
+			if err := proto.Unmarshal(v, &pb); err != nil {
+				return errors.Wrap(err, "unmarshal proto to HistoryCommand")
+			}
+			if status := pb.GetStatus(); status == StatusFailed || status == StatusExpired {
+				found := commandFromProto(pb.GetCommand())
+				cmd, key = &found, append([]byte{}, k...)
+			}
+		}
+		if cmd == nil {
+			return &notFound{"Command", "failed or expired command uuid " + uuid}
+		}
+		if err := bkt.Delete(key); err != nil {
+			return errors.Wrap(err, "delete command history")
+		}
+
+		dc, err := deviceCommand(tx, udid)
+		if isNotFound(err) {
+			dc = &DeviceCommand{DeviceUDID: udid}
+		} else if err != nil {
+			return errors.Wrapf(err, "get device command from queue, udid: %s", udid)
+		}
+		reset(cmd)
+		dc.Commands = append(dc.Commands, *cmd)
+		return db.put(tx, dc)
+	})
+}
+
+// archive moves the Completed, Failed and Expired commands of a DeviceCommand
+// to the history bucket and applies the retention policy to the history of
+// the device.
+func (db *Store) archive(tx *bolt.Tx, dc *DeviceCommand) error {
+	if len(dc.Completed) == 0 && len(dc.Failed) == 0 && len(dc.Expired) == 0 {
+		return nil
+	}
+	bkt := tx.Bucket([]byte(DeviceCommandHistoryBucket))
+	if bkt == nil {
+		return fmt.Errorf("bucket %q not found!", DeviceCommandHistoryBucket)
+	}
+	lists := []struct {
+		status   string
+		commands []Command
+	}{
+		{StatusCompleted, dc.Completed},
+		{StatusFailed, dc.Failed},
+		{StatusExpired, dc.Expired},
+	}
+	now := time.Now().UTC()
+	for _, l := range lists {
+		for _, cmd := range l.commands {
+			v, err := proto.Marshal(&devicecommandproto.HistoryCommand{
+				DeviceUdid: dc.DeviceUDID,
+				Status:     l.status,
+				Command:    commandToProto(cmd),
+			})
+			if err != nil {
+				return errors.Wrap(err, "marshalling HistoryCommand")
+			}
+			if err := bkt.Put(historyKey(dc.DeviceUDID, historyTime(cmd, now), cmd.UUID), v); err != nil {
+				return errors.Wrap(err, "put HistoryCommand to boltdb")
+			}
+		}
+	}
+	return db.pruneDevice(bkt, dc.DeviceUDID, now)
+}
+
+// pruneDevice removes the history entries of a device which exceed the
+// retention policy. Entries are ordered by time, so the oldest entries are
+// removed until one is young enough and within the MaxEntries.
+func (db *Store) pruneDevice(bkt *bolt.Bucket, udid string, now time.Time) error {
+	if db.retention.MaxEntries <= 0 && db.retention.MaxAge <= 0 {
+		return nil
+	}
+	prefix := historyPrefix(udid)
+	c := bkt.Cursor()
+
+	var excess int
+	if max := db.retention.MaxEntries; max > 0 {
+		var n int
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			n++
+		}
+		excess = n - max
+	}
+	var cutoff int64
+	if db.retention.MaxAge > 0 {
+		cutoff = now.Add(-db.retention.MaxAge).UnixNano()
+	}
+
+	var keys [][]byte
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		if len(keys) < excess {
+			keys = append(keys, append([]byte{}, k...))
+			continue
+		}
+		at := historyKeyTime(k, len(prefix))
+		if at == 0 {
+			continue // an entry without a time is kept.
+		}
+		if at >= cutoff {
+			break
+		}
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err := bkt.Delete(k); err != nil {
+			return errors.Wrap(err, "delete command history")
+		}
+	}
+	return nil
+}
+
+// pruneHistory removes the history entries of all devices which are older
+// than the RetentionPolicy.MaxAge. Entries without a time are kept.
+func (db *Store) pruneHistory(now time.Time) error {
+	if db.retention.MaxAge <= 0 {
+		return nil
+	}
+	cutoff := now.Add(-db.retention.MaxAge).UnixNano()
+	return db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(DeviceCommandHistoryBucket))
+		var keys [][]byte
+		err := bkt.ForEach(func(k, _ []byte) error {
+			sep := bytes.IndexByte(k, '/')
+			if sep < 0 || len(k) < sep+9 {
+				return nil
+			}
+			if at := historyKeyTime(k, sep+1); at != 0 && at < cutoff {
+				keys = append(keys, append([]byte{}, k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return errors.Wrap(err, "delete command history")
+			}
+		}
+		return nil
+	})
+}
+
+// migrateHistory moves the Completed and Failed commands which are still
+// kept in the per-device records to the history bucket.
+func (db *Store) migrateHistory() error {
+	return db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(DeviceCommandBucket))
+		var records []*DeviceCommand
+		err := bkt.ForEach(func(k, v []byte) error {
+			var dc DeviceCommand
+			if err := UnmarshalDeviceCommand(v, &dc); err != nil {
+				return err
+			}
+			if len(dc.Completed) > 0 || len(dc.Failed) > 0 {
+				records = append(records, &dc)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, dc := range records {
+			if err := db.put(tx, dc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (db *Store) startHistoryJanitor() {
+	if db.retention.MaxAge <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(historyJanitorInterval)
+		defer ticker.Stop()
+		for {
+			if err := db.pruneHistory(time.Now().UTC()); err != nil {
+				fmt.Println(err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func historyPrefix(udid string) []byte {
+	return []byte(udid + "/")
+}
+
+// historyKey returns a key of the form udid/<timestamp><uuid> where the
+// timestamp is encoded as big endian nanoseconds.
+func historyKey(udid string, at time.Time, uuid string) []byte {
+	key := historyPrefix(udid)
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(timeToNano(at)))
+	key = append(key, ts[:]...)
+	return append(key, uuid...)
+}
+
+func historyKeyTime(key []byte, offset int) int64 {
+	return int64(binary.BigEndian.Uint64(key[offset : offset+8]))
+}
+
+// historyTime is the time a command was completed, failed or expired.
+// Commands saved without any time, like the commands moved out of the device
+// queues by migrateHistory, are stamped with the time they are archived.
+func historyTime(cmd Command, now time.Time) time.Time {
+	if !cmd.Acknowledged.IsZero() {
+		return cmd.Acknowledged
+	}
+	if !cmd.ExpiresAt.IsZero() {
+		return cmd.ExpiresAt
+	}
+	if !cmd.CreatedAt.IsZero() {
+		return cmd.CreatedAt
+	}
+	return now
+}
@@ -0,0 +1,253 @@
+package queue
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/as/micromdm/platform/queue/internal/devicecommandproto"
+)
+
+func TestSave_MovesHistory(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, Command{UUID: "xCmd"})
+	dc.Completed = append(dc.Completed, Command{UUID: "yCmd", Acknowledged: now.Add(-time.Minute)})
+	dc.Failed = append(dc.Failed, Command{UUID: "zCmd", Acknowledged: now.Add(-2 * time.Minute)})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := store.DeviceCommand(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending.Completed) != 0 || len(pending.Failed) != 0 {
+		t.Errorf("expected history to be removed from the device queue, got %v %v",
+			uuids(pending.Completed), uuids(pending.Failed))
+	}
+	if have, want := uuids(pending.Commands), []string{"xCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("pending: have %v, want %v", have, want)
+	}
+
+	history, err := store.History(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(history.Completed), []string{"yCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("completed: have %v, want %v", have, want)
+	}
+	if have, want := uuids(history.Failed), []string{"zCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("failed: have %v, want %v", have, want)
+	}
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	now := time.Now().UTC()
+	history := []Command{
+		{UUID: "aCmd", Acknowledged: now.Add(-4 * time.Hour)},
+		{UUID: "bCmd", Acknowledged: now.Add(-3 * time.Hour)},
+		{UUID: "cCmd", Acknowledged: now.Add(-2 * time.Hour)},
+		{UUID: "dCmd", Acknowledged: now.Add(-1 * time.Hour)},
+	}
+
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		want   []string
+	}{
+		{name: "unlimited", want: []string{"aCmd", "bCmd", "cCmd", "dCmd"}},
+		{name: "max_entries", policy: RetentionPolicy{MaxEntries: 2}, want: []string{"cCmd", "dCmd"}},
+		{name: "max_age", policy: RetentionPolicy{MaxAge: 150 * time.Minute}, want: []string{"cCmd", "dCmd"}},
+		{name: "both", policy: RetentionPolicy{MaxEntries: 3, MaxAge: 90 * time.Minute}, want: []string{"dCmd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, teardown := setupDB(t)
+			defer teardown()
+			WithRetention(tt.policy)(store)
+
+			// save the history in reverse to verify it is ordered by time.
+			for i := len(history) - 1; i >= 0; i-- {
+				dc := &DeviceCommand{DeviceUDID: "TestDevice", Completed: history[i : i+1]}
+				if err := store.Save(dc); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := store.History("TestDevice")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := uuids(got.Completed); !reflect.DeepEqual(have, tt.want) {
+				t.Errorf("have %v, want %v", have, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneHistory(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	for _, udid := range []string{"aDevice", "bDevice"} {
+		dc := &DeviceCommand{DeviceUDID: udid}
+		dc.Completed = append(dc.Completed, Command{UUID: "old", Acknowledged: now.Add(-48 * time.Hour)})
+		dc.Failed = append(dc.Failed, Command{UUID: "new", Acknowledged: now.Add(-time.Hour)})
+		if err := store.Save(dc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	WithRetention(RetentionPolicy{MaxAge: 24 * time.Hour})(store)
+	if err := store.pruneHistory(now); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, udid := range []string{"aDevice", "bDevice"} {
+		got, err := store.History(udid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Completed) != 0 {
+			t.Errorf("%s: expected expired history to be removed, got %v", udid, uuids(got.Completed))
+		}
+		if have, want := uuids(got.Failed), []string{"new"}; !reflect.DeepEqual(have, want) {
+			t.Errorf("%s: have %v, want %v", udid, have, want)
+		}
+	}
+}
+
+func TestPruneHistory_NoTime(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	// an entry archived without a time by an earlier version.
+	v, err := proto.Marshal(&devicecommandproto.HistoryCommand{
+		DeviceUdid: "TestDevice",
+		Status:     StatusCompleted,
+		Command:    commandToProto(Command{UUID: "legacy"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(DeviceCommandHistoryBucket)).Put(historyKey("TestDevice", time.Time{}, "legacy"), v)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	WithRetention(RetentionPolicy{MaxAge: time.Hour})(store)
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Completed = append(dc.Completed, Command{UUID: "old", Acknowledged: now.Add(-2 * time.Hour)})
+	dc.Completed = append(dc.Completed, Command{UUID: "new", Acknowledged: now.Add(-time.Minute)})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.pruneHistory(now); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.History("TestDevice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(got.Completed), []string{"legacy", "new"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+}
+
+func TestRequeue_KeepsOtherEntries(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Completed = append(dc.Completed, Command{UUID: "xCmd", Acknowledged: now.Add(-2 * time.Hour)})
+	dc.Failed = append(dc.Failed, Command{UUID: "xCmd", Acknowledged: now.Add(-time.Hour)})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Requeue(dc.DeviceUDID, "xCmd", func(*Command) {}); err != nil {
+		t.Fatal(err)
+	}
+	history, err := store.History(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(history.Completed), []string{"xCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("completed: have %v, want %v", have, want)
+	}
+	if len(history.Failed) != 0 {
+		t.Errorf("expected the requeued command to leave the history, got %v", uuids(history.Failed))
+	}
+	pending, err := store.DeviceCommand(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(pending.Commands), []string{"xCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("pending: have %v, want %v", have, want)
+	}
+}
+
+func TestMigrateHistory(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	// a device queue saved before the history bucket existed.
+	legacy := &DeviceCommand{DeviceUDID: "TestDevice"}
+	legacy.Commands = append(legacy.Commands, Command{UUID: "xCmd"})
+	legacy.Completed = append(legacy.Completed, Command{UUID: "yCmd"})
+	v, err := MarshalDeviceCommand(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(DeviceCommandBucket)).Put([]byte(legacy.DeviceUDID), v)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.migrateHistory(); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := store.DeviceCommand(legacy.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending.Completed) != 0 || len(pending.Commands) != 1 {
+		t.Errorf("have %d pending and %d completed commands in the queue, want 1 and 0",
+			len(pending.Commands), len(pending.Completed))
+	}
+	history, err := store.History(legacy.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(history.Completed), []string{"yCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+	err = store.View(func(tx *bolt.Tx) error {
+		prefix := historyPrefix(legacy.DeviceUDID)
+		k, _ := tx.Bucket([]byte(DeviceCommandHistoryBucket)).Cursor().Seek(prefix)
+		if historyKeyTime(k, len(prefix)) == 0 {
+			t.Error("expected the migrated command to be stamped with the time of the migration")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
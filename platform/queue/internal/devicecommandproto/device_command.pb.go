@@ -10,6 +10,7 @@ It is generated from these files:
 It has these top-level messages:
 	Command
 	DeviceCommand
+	HistoryCommand
 */
 package devicecommandproto
 
@@ -29,14 +30,18 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type Command struct {
-	Uuid           string `protobuf:"bytes,1,opt,name=uuid" json:"uuid,omitempty"`
-	Payload        []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	CreatedAt      int64  `protobuf:"varint,3,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
-	LastSentAt     int64  `protobuf:"varint,4,opt,name=last_sent_at,json=lastSentAt" json:"last_sent_at,omitempty"`
-	Acknowledged   int64  `protobuf:"varint,5,opt,name=acknowledged" json:"acknowledged,omitempty"`
-	TimesSent      int64  `protobuf:"varint,6,opt,name=times_sent,json=timesSent" json:"times_sent,omitempty"`
-	LastStatus     string `protobuf:"bytes,7,opt,name=last_status,json=lastStatus" json:"last_status,omitempty"`
-	FailureMessage []byte `protobuf:"bytes,8,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	Uuid           string   `protobuf:"bytes,1,opt,name=uuid" json:"uuid,omitempty"`
+	Payload        []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAt      int64    `protobuf:"varint,3,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	LastSentAt     int64    `protobuf:"varint,4,opt,name=last_sent_at,json=lastSentAt" json:"last_sent_at,omitempty"`
+	Acknowledged   int64    `protobuf:"varint,5,opt,name=acknowledged" json:"acknowledged,omitempty"`
+	TimesSent      int64    `protobuf:"varint,6,opt,name=times_sent,json=timesSent" json:"times_sent,omitempty"`
+	LastStatus     string   `protobuf:"bytes,7,opt,name=last_status,json=lastStatus" json:"last_status,omitempty"`
+	FailureMessage []byte   `protobuf:"bytes,8,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	ExpiresAt      int64    `protobuf:"varint,9,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	NotNowCount    int64    `protobuf:"varint,10,opt,name=not_now_count,json=notNowCount" json:"not_now_count,omitempty"`
+	Priority       int64    `protobuf:"varint,11,opt,name=priority" json:"priority,omitempty"`
+	After          []string `protobuf:"bytes,12,rep,name=after" json:"after,omitempty"`
 }
 
 func (m *Command) Reset()                    { *m = Command{} }
@@ -100,12 +105,41 @@ func (m *Command) GetFailureMessage() []byte {
 	return nil
 }
 
+func (m *Command) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Command) GetNotNowCount() int64 {
+	if m != nil {
+		return m.NotNowCount
+	}
+	return 0
+}
+
+func (m *Command) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Command) GetAfter() []string {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
 type DeviceCommand struct {
 	DeviceUdid string     `protobuf:"bytes,1,opt,name=device_udid,json=deviceUdid" json:"device_udid,omitempty"`
 	Commands   []*Command `protobuf:"bytes,2,rep,name=commands" json:"commands,omitempty"`
 	Completed  []*Command `protobuf:"bytes,3,rep,name=completed" json:"completed,omitempty"`
 	Failed     []*Command `protobuf:"bytes,4,rep,name=failed" json:"failed,omitempty"`
 	NotNow     []*Command `protobuf:"bytes,5,rep,name=not_now,json=notNow" json:"not_now,omitempty"`
+	Expired    []*Command `protobuf:"bytes,6,rep,name=expired" json:"expired,omitempty"`
 }
 
 func (m *DeviceCommand) Reset()                    { *m = DeviceCommand{} }
@@ -148,34 +182,80 @@ func (m *DeviceCommand) GetNotNow() []*Command {
 	return nil
 }
 
+func (m *DeviceCommand) GetExpired() []*Command {
+	if m != nil {
+		return m.Expired
+	}
+	return nil
+}
+
+type HistoryCommand struct {
+	DeviceUdid string   `protobuf:"bytes,1,opt,name=device_udid,json=deviceUdid" json:"device_udid,omitempty"`
+	Status     string   `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	Command    *Command `protobuf:"bytes,3,opt,name=command" json:"command,omitempty"`
+}
+
+func (m *HistoryCommand) Reset()                    { *m = HistoryCommand{} }
+func (m *HistoryCommand) String() string            { return proto.CompactTextString(m) }
+func (*HistoryCommand) ProtoMessage()               {}
+func (*HistoryCommand) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *HistoryCommand) GetDeviceUdid() string {
+	if m != nil {
+		return m.DeviceUdid
+	}
+	return ""
+}
+
+func (m *HistoryCommand) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *HistoryCommand) GetCommand() *Command {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Command)(nil), "devicecommandproto.Command")
 	proto.RegisterType((*DeviceCommand)(nil), "devicecommandproto.DeviceCommand")
+	proto.RegisterType((*HistoryCommand)(nil), "devicecommandproto.HistoryCommand")
 }
 
 func init() { proto.RegisterFile("device_command.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 324 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x41, 0x4b, 0xeb, 0x40,
-	0x10, 0xc7, 0x49, 0xd2, 0x36, 0xed, 0xb4, 0xef, 0x3d, 0x18, 0xde, 0x61, 0xe1, 0xf1, 0x68, 0xe8,
-	0xc5, 0x9c, 0x7a, 0xb0, 0x82, 0x78, 0x2c, 0x7a, 0xd5, 0x43, 0xc4, 0x73, 0x58, 0xb3, 0x63, 0x09,
-	0x26, 0xbb, 0xa5, 0x3b, 0xb1, 0xf8, 0x01, 0x3c, 0xfb, 0x95, 0x25, 0xbb, 0xdb, 0x8a, 0x78, 0xa8,
-	0xb7, 0xe4, 0xc7, 0x7f, 0xe6, 0x3f, 0xfb, 0x83, 0xbf, 0x8a, 0x5e, 0xea, 0x8a, 0xca, 0xca, 0xb4,
-	0xad, 0xd4, 0x6a, 0xb9, 0xdd, 0x19, 0x36, 0x88, 0x9e, 0x06, 0xe8, 0xd8, 0xe2, 0x2d, 0x86, 0xf4,
-	0xda, 0x03, 0x44, 0x18, 0x74, 0x5d, 0xad, 0x44, 0x94, 0x45, 0xf9, 0xa4, 0x70, 0xdf, 0x28, 0x20,
-	0xdd, 0xca, 0xd7, 0xc6, 0x48, 0x25, 0xe2, 0x2c, 0xca, 0x67, 0xc5, 0xe1, 0x17, 0xff, 0x03, 0x54,
-	0x3b, 0x92, 0x4c, 0xaa, 0x94, 0x2c, 0x92, 0x2c, 0xca, 0x93, 0x62, 0x12, 0xc8, 0x9a, 0x31, 0x83,
-	0x59, 0x23, 0x2d, 0x97, 0x96, 0x34, 0xf7, 0x81, 0x81, 0x0b, 0x40, 0xcf, 0xee, 0x49, 0xf3, 0x9a,
-	0x71, 0x01, 0x33, 0x59, 0x3d, 0x6b, 0xb3, 0x6f, 0x48, 0x6d, 0x48, 0x89, 0xa1, 0x4b, 0x7c, 0x61,
-	0x7d, 0x09, 0xd7, 0x2d, 0x59, 0xb7, 0x46, 0x8c, 0x7c, 0x89, 0x23, 0xfd, 0x12, 0x9c, 0xc3, 0xd4,
-	0x97, 0xb0, 0xe4, 0xce, 0x8a, 0xd4, 0x1d, 0xee, 0x3b, 0x1c, 0xc1, 0x33, 0xf8, 0xf3, 0x24, 0xeb,
-	0xa6, 0xdb, 0x51, 0xd9, 0x92, 0xb5, 0x72, 0x43, 0x62, 0xec, 0x9e, 0xf1, 0x3b, 0xe0, 0x5b, 0x4f,
-	0x17, 0xef, 0x31, 0xfc, 0xba, 0x71, 0x7a, 0x0e, 0x36, 0xe6, 0x30, 0x0d, 0x16, 0x3b, 0x75, 0x94,
-	0x02, 0x1e, 0x3d, 0xa8, 0x5a, 0xe1, 0x25, 0x8c, 0x83, 0x4a, 0x2b, 0xe2, 0x2c, 0xc9, 0xa7, 0xe7,
-	0xff, 0x96, 0xdf, 0x0d, 0x2f, 0xc3, 0xbe, 0xe2, 0x18, 0xc6, 0x2b, 0x98, 0x54, 0xa6, 0xdd, 0x36,
-	0xc4, 0xa4, 0x44, 0x72, 0x7a, 0xf2, 0x33, 0x8d, 0x2b, 0x18, 0xf5, 0x87, 0x93, 0x12, 0x83, 0xd3,
-	0x73, 0x21, 0x8a, 0x17, 0x90, 0x6a, 0xc3, 0xa5, 0x36, 0x7b, 0x31, 0xfc, 0xc1, 0x94, 0x36, 0x7c,
-	0x67, 0xf6, 0x8f, 0x23, 0x87, 0x57, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x83, 0x8d, 0x73, 0x78,
-	0x4c, 0x02, 0x00, 0x00,
+	// 423 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x3f, 0x8f, 0xd4, 0x30,
+	0x10, 0xc5, 0x95, 0x64, 0x37, 0xd9, 0x4c, 0xf6, 0x0e, 0xc9, 0x3a, 0x21, 0x0b, 0x84, 0x2e, 0xda,
+	0x86, 0x54, 0x5b, 0x70, 0x20, 0x44, 0x79, 0x3a, 0x0a, 0x1a, 0x28, 0x82, 0xa8, 0x23, 0x13, 0xcf,
+	0x9d, 0x2c, 0x92, 0x38, 0x8a, 0x27, 0x2c, 0xdb, 0xf1, 0xe9, 0xf8, 0x50, 0x54, 0xc8, 0x7f, 0x76,
+	0x11, 0xa2, 0xc8, 0x75, 0x99, 0x9f, 0xdf, 0xf8, 0x4d, 0xe6, 0x19, 0xae, 0x24, 0x7e, 0x57, 0x2d,
+	0x36, 0xad, 0xee, 0x7b, 0x31, 0xc8, 0xfd, 0x38, 0x69, 0xd2, 0x8c, 0x79, 0x1a, 0xa0, 0x63, 0xbb,
+	0xdf, 0x31, 0x64, 0x77, 0x1e, 0x30, 0x06, 0xab, 0x79, 0x56, 0x92, 0x47, 0x65, 0x54, 0xe5, 0xb5,
+	0xfb, 0x66, 0x1c, 0xb2, 0x51, 0x1c, 0x3b, 0x2d, 0x24, 0x8f, 0xcb, 0xa8, 0xda, 0xd6, 0xa7, 0x92,
+	0xbd, 0x00, 0x68, 0x27, 0x14, 0x84, 0xb2, 0x11, 0xc4, 0x93, 0x32, 0xaa, 0x92, 0x3a, 0x0f, 0xe4,
+	0x96, 0x58, 0x09, 0xdb, 0x4e, 0x18, 0x6a, 0x0c, 0x0e, 0x64, 0x05, 0x2b, 0x27, 0x00, 0xcb, 0x3e,
+	0xe3, 0x40, 0xb7, 0xc4, 0x76, 0xb0, 0x15, 0xed, 0xb7, 0x41, 0x1f, 0x3a, 0x94, 0x0f, 0x28, 0xf9,
+	0xda, 0x29, 0xfe, 0x61, 0xd6, 0x84, 0x54, 0x8f, 0xc6, 0x5d, 0xc3, 0x53, 0x6f, 0xe2, 0x88, 0xbd,
+	0x84, 0x5d, 0x43, 0xe1, 0x4d, 0x48, 0xd0, 0x6c, 0x78, 0xe6, 0x06, 0xf7, 0x1e, 0x8e, 0xb0, 0x97,
+	0xf0, 0xe4, 0x5e, 0xa8, 0x6e, 0x9e, 0xb0, 0xe9, 0xd1, 0x18, 0xf1, 0x80, 0x7c, 0xe3, 0x7e, 0xe3,
+	0x32, 0xe0, 0x8f, 0x9e, 0x5a, 0x23, 0xfc, 0x31, 0xaa, 0x09, 0x8d, 0x1d, 0x36, 0xf7, 0x46, 0x81,
+	0xb8, 0x59, 0x2f, 0x06, 0x4d, 0xcd, 0xa0, 0x0f, 0x4d, 0xab, 0xe7, 0x81, 0x38, 0x38, 0x45, 0x31,
+	0x68, 0xfa, 0xa4, 0x0f, 0x77, 0x16, 0xb1, 0x67, 0xb0, 0x19, 0x27, 0xa5, 0x27, 0x45, 0x47, 0x5e,
+	0xb8, 0xe3, 0x73, 0xcd, 0xae, 0x60, 0x2d, 0xee, 0x09, 0x27, 0xbe, 0x2d, 0x93, 0x2a, 0xaf, 0x7d,
+	0xb1, 0xfb, 0x15, 0xc3, 0xc5, 0x7b, 0x97, 0xc9, 0x29, 0x82, 0x6b, 0x28, 0x42, 0x74, 0xb3, 0x3c,
+	0x27, 0x01, 0x1e, 0x7d, 0x91, 0x4a, 0xb2, 0xb7, 0xb0, 0x09, 0xf9, 0x19, 0x1e, 0x97, 0x49, 0x55,
+	0xbc, 0x7a, 0xbe, 0xff, 0x3f, 0xd6, 0x7d, 0xb8, 0xaf, 0x3e, 0x8b, 0xd9, 0x3b, 0xc8, 0x5b, 0xdd,
+	0x8f, 0x1d, 0x12, 0x4a, 0x9e, 0x2c, 0x77, 0xfe, 0x55, 0xb3, 0x1b, 0x48, 0xed, 0xb6, 0x50, 0xf2,
+	0xd5, 0x72, 0x5f, 0x90, 0xb2, 0xd7, 0x90, 0x85, 0x8d, 0xf1, 0xf5, 0x23, 0xba, 0xfc, 0x22, 0xd9,
+	0x1b, 0xc8, 0xfc, 0xd2, 0x25, 0x4f, 0x97, 0xbb, 0x4e, 0xda, 0xdd, 0xcf, 0x08, 0x2e, 0x3f, 0x28,
+	0x43, 0x7a, 0x3a, 0x3e, 0x7a, 0x93, 0x4f, 0x21, 0x0d, 0xcf, 0x26, 0x76, 0x67, 0xa1, 0xb2, 0x23,
+	0x04, 0x33, 0xf7, 0xa8, 0x97, 0x46, 0x08, 0xf4, 0x6b, 0xea, 0xf8, 0xcd, 0x9f, 0x01, 0x00, 0x53,
+	0xcc, 0x00, 0x5b, 0x7b, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,3 @@
+package queueeventproto
+
+//go:generate protoc --go_out=. queue_event.proto
@@ -3,7 +3,9 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/groob/plist"
@@ -22,6 +24,8 @@ const (
 
 type Store struct {
 	*bolt.DB
+	retention RetentionPolicy
+	notNow    NotNowPolicy
 }
 
 func (db *Store) Next(ctx context.Context, resp mdm.Response) (*Command, error) {
@@ -30,14 +34,24 @@ func (db *Store) Next(ctx context.Context, resp mdm.Response) (*Command, error)
 		// use the user id for user level commands
 		udid = *resp.UserID
 	}
-	dc, err := db.DeviceCommand(udid)
+	var cmd *Command
+	err := db.updateDeviceCommand(udid, false, func(tx *bolt.Tx, dc *DeviceCommand) error {
+		var err error
+		cmd, err = db.next(tx, dc, resp, time.Now().UTC())
+		return err
+	})
+	if isNotFound(err) {
+		return nil, nil
+	}
 	if err != nil {
-		if isNotFound(err) {
-			return nil, nil
-		}
-		return nil, errors.Wrapf(err, "get device command from queue, udid: %s", resp.UDID)
+		return nil, errors.Wrapf(err, "get next command from queue, udid: %s", resp.UDID)
 	}
+	return cmd, nil
+}
 
+// next updates the queue of a device with the response, and returns the next
+// command to send.
+func (db *Store) next(tx *bolt.Tx, dc *DeviceCommand, resp mdm.Response, now time.Time) (*Command, error) {
 	var cmd *Command
 	switch resp.Status {
 	case "NotNow":
@@ -48,6 +62,12 @@ func (db *Store) Next(ctx context.Context, resp mdm.Response) (*Command, error)
 		if x == nil {
 			break
 		}
+		x.LastStatus = resp.Status
+		x.NotNowCount++
+		if db.notNow.exhausted(x) {
+			dc.Expired = append(dc.Expired, *x)
+			break
+		}
 		dc.NotNow = append(dc.NotNow, *x)
 
 	case "Acknowledged":
@@ -57,6 +77,8 @@ func (db *Store) Next(ctx context.Context, resp mdm.Response) (*Command, error)
 		if x == nil {
 			break
 		}
+		x.Acknowledged = now
+		x.LastStatus = resp.Status
 		dc.Completed = append(dc.Completed, *x)
 	case "Error":
 		// move to failed, send next
@@ -65,6 +87,9 @@ func (db *Store) Next(ctx context.Context, resp mdm.Response) (*Command, error)
 		if x == nil { // must've already bin ackd
 			break
 		}
+		if err := markFailed(x, resp, now); err != nil {
+			return nil, err
+		}
 		dc.Failed = append(dc.Failed, *x)
 
 	case "CommandFormatError":
@@ -74,6 +99,9 @@ func (db *Store) Next(ctx context.Context, resp mdm.Response) (*Command, error)
 		if x == nil {
 			break
 		}
+		if err := markFailed(x, resp, now); err != nil {
+			return nil, err
+		}
 		dc.Failed = append(dc.Failed, *x)
 
 	case "Idle":
@@ -83,33 +111,68 @@ func (db *Store) Next(ctx context.Context, resp mdm.Response) (*Command, error)
 		return nil, fmt.Errorf("unknown response status: %s", resp.Status)
 	}
 
-	// pop the first command from the queue and add it to the end.
-	// If the regular queue is empty, send a command that got
-	// refused with NotNow before.
-	cmd, dc.Commands = popFirst(dc.Commands)
-	if cmd != nil {
-		dc.Commands = append(dc.Commands, *cmd)
-	} else if resp.Status != "NotNow" {
-		cmd, dc.NotNow = popFirst(dc.NotNow)
-		if cmd != nil {
-			dc.Commands = append(dc.Commands, *cmd)
-		}
-	}
+	expire(dc, now)
 
-	if err := db.Save(dc); err != nil {
+	// pop the command with the highest priority from the queue and add
+	// it to the end. If the regular queue is empty, send a command that got
+	// refused with NotNow before, once its back-off has passed.
+	// Commands which must be sent after commands the device did not
+	// acknowledge yet are skipped.
+	released, err := releaseAfter(tx, dc, now)
+	if err != nil {
 		return nil, err
 	}
+	cmd, dc.Commands = popNext(dc.Commands, func(c Command) bool {
+		return released[c.UUID]
+	})
+	if cmd == nil && resp.Status != "NotNow" {
+		cmd, dc.NotNow = popNext(dc.NotNow, func(c Command) bool {
+			return released[c.UUID] && db.notNow.due(c, now)
+		})
+	}
+	if cmd != nil {
+		cmd.LastSentAt = now
+		cmd.TimesSent++
+		dc.Commands = append(dc.Commands, *cmd)
+	}
 
 	return cmd, nil
 }
 
-func popFirst(all []Command) (*Command, []Command) {
-	if len(all) == 0 {
+// markFailed records the device response of a failed command.
+// The ErrorChain is kept as JSON in the FailureMessage.
+func markFailed(cmd *Command, resp mdm.Response, now time.Time) error {
+	cmd.Acknowledged = now
+	cmd.LastStatus = resp.Status
+	if len(resp.ErrorChain) == 0 {
+		return nil
+	}
+	msg, err := json.Marshal(resp.ErrorChain)
+	if err != nil {
+		return errors.Wrap(err, "marshal command ErrorChain")
+	}
+	cmd.FailureMessage = msg
+	return nil
+}
+
+// popNext removes the first command with the highest priority for which
+// ready returns true.
+func popNext(all []Command, ready func(Command) bool) (*Command, []Command) {
+	next := -1
+	for i, cmd := range all {
+		if !ready(cmd) {
+			continue
+		}
+		if next < 0 || cmd.Priority > all[next].Priority {
+			next = i
+		}
+	}
+	if next < 0 {
 		return nil, all
 	}
-	first := all[0]
-	all = append(all[:0], all[1:]...)
-	return &first, all
+	cmd := all[next]
+	all = append(all[:next], all[next+1:]...)
+	return &cmd, all
 }
 
 func cut(all []Command, uuid string) (*Command, []Command) {
@@ -122,31 +185,50 @@ func cut(all []Command, uuid string) (*Command, []Command) {
 	return nil, all
 }
 
-func NewQueue(db *bolt.DB, pubsub pubsub.PublishSubscriber) (*Store, error) {
+func NewQueue(db *bolt.DB, pubsub pubsub.PublishSubscriber, opts ...Option) (*Store, error) {
 	err := db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte(DeviceCommandBucket))
+		if _, err := tx.CreateBucketIfNotExists([]byte(DeviceCommandBucket)); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists([]byte(DeviceCommandHistoryBucket))
 		return err
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "creating %s bucket", DeviceCommandBucket)
 	}
 	datastore := &Store{DB: db}
+	for _, opt := range opts {
+		opt(datastore)
+	}
+	if err := datastore.migrateHistory(); err != nil {
+		return nil, errors.Wrap(err, "move command history out of device queues")
+	}
+	datastore.startHistoryJanitor()
 	if err := datastore.pollCommands(pubsub); err != nil {
 		return nil, err
 	}
 	return datastore, nil
 }
 
+// Save stores the pending commands of a device. Completed and Failed
+// commands are moved to the command history.
 func (db *Store) Save(cmd *DeviceCommand) error {
-	tx, err := db.DB.Begin(true)
-	if err != nil {
-		return errors.Wrap(err, "begin transaction")
-	}
+	return db.Update(func(tx *bolt.Tx) error {
+		return db.put(tx, cmd)
+	})
+}
+
+func (db *Store) put(tx *bolt.Tx, cmd *DeviceCommand) error {
 	bkt := tx.Bucket([]byte(DeviceCommandBucket))
 	if bkt == nil {
 		return fmt.Errorf("bucket %q not found!", DeviceCommandBucket)
 	}
-	devproto, err := MarshalDeviceCommand(cmd)
+	if err := db.archive(tx, cmd); err != nil {
+		return err
+	}
+	pending := *cmd
+	pending.Completed, pending.Failed, pending.Expired = nil, nil, nil
+	devproto, err := MarshalDeviceCommand(&pending)
 	if err != nil {
 		return errors.Wrap(err, "marshalling DeviceCommand")
 	}
@@ -154,22 +236,59 @@ func (db *Store) Save(cmd *DeviceCommand) error {
 	if err := bkt.Put(key, devproto); err != nil {
 		return errors.Wrap(err, "put DeviceCommand to boltdb")
 	}
-	return tx.Commit()
+	return nil
+}
+
+// UpdateDeviceCommand reads, updates and saves the pending commands of a
+// device in a single transaction. Nothing is saved if fn returns an error.
+func (db *Store) UpdateDeviceCommand(udid string, fn func(*DeviceCommand) error) error {
+	return db.updateDeviceCommand(udid, false, func(_ *bolt.Tx, dc *DeviceCommand) error {
+		return fn(dc)
+	})
+}
+
+// updateDeviceCommand is UpdateDeviceCommand, which passes an empty queue
+// to fn if create is true and the device has no queue yet. fn is called with
+// the transaction of the update.
+func (db *Store) updateDeviceCommand(udid string, create bool, fn func(*bolt.Tx, *DeviceCommand) error) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		dc, err := deviceCommand(tx, udid)
+		if create && isNotFound(err) {
+			dc, err = &DeviceCommand{DeviceUDID: udid}, nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(tx, dc); err != nil {
+			return err
+		}
+		return db.put(tx, dc)
+	})
 }
 
 func (db *Store) DeviceCommand(udid string) (*DeviceCommand, error) {
-	var dev DeviceCommand
+	var dev *DeviceCommand
 	err := db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte(DeviceCommandBucket))
-		v := b.Get([]byte(udid))
-		if v == nil {
-			return &notFound{"DeviceCommand", fmt.Sprintf("udid %s", udid)}
-		}
-		return UnmarshalDeviceCommand(v, &dev)
+		var err error
+		dev, err = deviceCommand(tx, udid)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
+	return dev, nil
+}
+
+func deviceCommand(tx *bolt.Tx, udid string) (*DeviceCommand, error) {
+	var dev DeviceCommand
+	b := tx.Bucket([]byte(DeviceCommandBucket))
+	v := b.Get([]byte(udid))
+	if v == nil {
+		return nil, &notFound{"DeviceCommand", fmt.Sprintf("udid %s", udid)}
+	}
+	if err := UnmarshalDeviceCommand(v, &dev); err != nil {
+		return nil, err
+	}
 	return &dev, nil
 }
 
@@ -189,48 +308,14 @@ func (db *Store) pollCommands(pubsub pubsub.PublishSubscriber) error {
 			"subscribing push to %s topic", command.CommandTopic)
 	}
 	go func() {
-		for {
-			select {
-			case event := <-commandEvents:
-				var ev command.Event
-				if err := command.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-
-				cmd := new(DeviceCommand)
-				cmd.DeviceUDID = ev.DeviceUDID
-				byUDID, err := db.DeviceCommand(ev.DeviceUDID)
-				if err == nil && byUDID != nil {
-					cmd = byUDID
-				}
-				newPayload, err := plist.Marshal(&ev.Payload)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-				newCmd := Command{
-					UUID:    ev.Payload.CommandUUID,
-					Payload: newPayload,
-				}
-				cmd.Commands = append(cmd.Commands, newCmd)
-				if err := db.Save(cmd); err != nil {
-					fmt.Println(err)
-					continue
-				}
-				fmt.Printf("queued event for device: %s\n", ev.DeviceUDID)
-
-				cq := new(QueueCommandQueued)
-				cq.DeviceUDID = ev.DeviceUDID
-				cq.CommandUUID = ev.Payload.CommandUUID
-
-				msgBytes, err := MarshalQueuedCommand(cq)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-
-				pubsub.Publish(context.TODO(), CommandQueuedTopic, msgBytes)
+		for event := range commandEvents {
+			if err := db.queueCommand(pubsub, event); err != nil {
+				fmt.Println(err)
+			}
+			// commands which can not be queued are not retried, and a
+			// restarted queue must not queue a command twice.
+			if err := event.Ack(); err != nil {
+				fmt.Println(err)
 			}
 		}
 	}()
@@ -238,6 +323,49 @@ func (db *Store) pollCommands(pubsub pubsub.PublishSubscriber) error {
 	return nil
 }
 
+// queueCommand adds the command of a command event to the device queue and
+// publishes a CommandQueued event.
+func (db *Store) queueCommand(pub pubsub.Publisher, event pubsub.Event) error {
+	var ev command.Event
+	if err := command.UnmarshalEvent(event.Message, &ev); err != nil {
+		return err
+	}
+
+	newPayload, err := plist.Marshal(&ev.Payload)
+	if err != nil {
+		return err
+	}
+	newCmd := Command{
+		UUID:      ev.Payload.CommandUUID,
+		Payload:   newPayload,
+		CreatedAt: ev.Time,
+	}
+	if ev.TTL > 0 {
+		newCmd.ExpiresAt = ev.Time.Add(ev.TTL)
+	}
+	newCmd.Priority = ev.Priority
+	newCmd.After = ev.After
+	err = db.updateDeviceCommand(ev.DeviceUDID, true, func(_ *bolt.Tx, dc *DeviceCommand) error {
+		dc.Commands = append(dc.Commands, newCmd)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "queue command %s for %s", newCmd.UUID, ev.DeviceUDID)
+	}
+	fmt.Printf("queued event for device: %s\n", ev.DeviceUDID)
+
+	cq := new(QueueCommandQueued)
+	cq.DeviceUDID = ev.DeviceUDID
+	cq.CommandUUID = ev.Payload.CommandUUID
+
+	msgBytes, err := MarshalQueuedCommand(cq)
+	if err != nil {
+		return err
+	}
+
+	return pub.Publish(context.TODO(), CommandQueuedTopic, msgBytes)
+}
+
 func isNotFound(err error) bool {
 	if _, ok := err.(*notFound); ok {
 		return true
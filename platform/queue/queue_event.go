@@ -0,0 +1,66 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/queue/internal/queueeventproto"
+)
+
+// QueueChangedTopic is a PubSub topic that queue events are published to
+// when a device queue is modified outside of the MDM protocol.
+const QueueChangedTopic = "mdm.QueueChanged"
+
+// Actions recorded in a QueueEvent.
+const (
+	ActionCancel = "cancel"
+	ActionRetry  = "retry"
+	ActionClear  = "clear"
+)
+
+// QueueEvent describes a change to the command queue of a device.
+type QueueEvent struct {
+	Action       string
+	DeviceUDID   string
+	CommandUUIDs []string
+	Time         time.Time
+}
+
+// NewQueueEvent returns a QueueEvent with the current time.
+func NewQueueEvent(action, udid string, uuids ...string) *QueueEvent {
+	return &QueueEvent{
+		Action:       action,
+		DeviceUDID:   udid,
+		CommandUUIDs: uuids,
+		Time:         time.Now().UTC(),
+	}
+}
+
+// MarshalQueueEvent serializes an event to a protocol buffer wire format.
+func MarshalQueueEvent(e *QueueEvent) ([]byte, error) {
+	if e == nil {
+		return nil, errors.New("marshalling nil QueueEvent")
+	}
+	return proto.Marshal(&queueeventproto.Event{
+		Action:       e.Action,
+		DeviceUdid:   e.DeviceUDID,
+		CommandUuids: e.CommandUUIDs,
+		Time:         timeToNano(e.Time),
+	})
+}
+
+// UnmarshalQueueEvent parses a protocol buffer representation of data into
+// the QueueEvent.
+func UnmarshalQueueEvent(data []byte, e *QueueEvent) error {
+	var pb queueeventproto.Event
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal proto to QueueEvent")
+	}
+	e.Action = pb.GetAction()
+	e.DeviceUDID = pb.GetDeviceUdid()
+	e.CommandUUIDs = pb.GetCommandUuids()
+	e.Time = timeFromNano(pb.GetTime())
+	return nil
+}
@@ -2,11 +2,20 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/platform/command"
+	"github.com/as/micromdm/platform/pubsub"
+	pubsubbuiltin "github.com/as/micromdm/platform/pubsub/builtin"
+	"github.com/as/micromdm/platform/pubsub/inmem"
 	"github.com/boltdb/bolt"
 )
 
@@ -166,12 +175,283 @@ func setupDB(t *testing.T) (*Store, func()) {
 		t.Fatalf("couldn't open bolt, err %s\n", err)
 	}
 	err = db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte(DeviceCommandBucket))
+		if _, err := tx.CreateBucketIfNotExists([]byte(DeviceCommandBucket)); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists([]byte(DeviceCommandHistoryBucket))
 		return err
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
-	store := &Store{db}
+	store := &Store{DB: db}
 	return store, teardown
 }
+
+func TestNext_RecordsHistory(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, Command{UUID: "xCmd"})
+	dc.Commands = append(dc.Commands, Command{UUID: "yCmd"})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cmd, err := store.Next(ctx, mdm.Response{UDID: dc.DeviceUDID, Status: "Idle"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.TimesSent != 1 || cmd.LastSentAt.IsZero() {
+		t.Errorf("expected send to be recorded, have TimesSent %d, LastSentAt %s", cmd.TimesSent, cmd.LastSentAt)
+	}
+
+	chain := mdm.ErrorChain{{ErrorCode: 4001, ErrorDomain: "MCProfileErrorDomain", USEnglishDescription: "Profile Installation Failed"}}
+	_, err = store.Next(ctx, mdm.Response{
+		UDID:        dc.DeviceUDID,
+		CommandUUID: "xCmd",
+		Status:      "Error",
+		ErrorChain:  chain,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Next(ctx, mdm.Response{UDID: dc.DeviceUDID, CommandUUID: "yCmd", Status: "Acknowledged"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dc, err = store.History(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dc.Failed) != 1 || len(dc.Completed) != 1 {
+		t.Fatalf("have %d failed and %d completed, want 1 each", len(dc.Failed), len(dc.Completed))
+	}
+
+	failed := dc.Failed[0]
+	if have, want := failed.LastStatus, "Error"; have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	if failed.Acknowledged.IsZero() {
+		t.Error("expected failed command to have an Acknowledged time")
+	}
+	var have mdm.ErrorChain
+	if err := json.Unmarshal(failed.FailureMessage, &have); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(have, chain) {
+		t.Errorf("have %v, want %v", have, chain)
+	}
+
+	completed := dc.Completed[0]
+	if have, want := completed.LastStatus, "Acknowledged"; have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	if completed.TimesSent != 1 || completed.Acknowledged.IsZero() {
+		t.Errorf("expected completed command to be sent once and acknowledged, have %#v", completed)
+	}
+}
+
+func TestNext_PriorityAndAfter(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, Command{UUID: "profile1"})
+	dc.Commands = append(dc.Commands, Command{UUID: "profile3", After: []string{"profile2"}})
+	dc.Commands = append(dc.Commands, Command{UUID: "profile2", After: []string{"profile1"}})
+	dc.Commands = append(dc.Commands, Command{UUID: "lock", Priority: 10})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	resp := mdm.Response{UDID: dc.DeviceUDID, Status: "Idle"}
+	var order []string
+	for {
+		cmd, err := store.Next(ctx, resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd == nil {
+			break
+		}
+		order = append(order, cmd.UUID)
+		resp = mdm.Response{UDID: dc.DeviceUDID, CommandUUID: cmd.UUID, Status: "Acknowledged"}
+	}
+
+	if want := []string{"lock", "profile1", "profile2", "profile3"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("have %v, want %v", order, want)
+	}
+}
+
+func TestNext_AfterFailedOrUnknown(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, Command{UUID: "profile1"})
+	dc.Commands = append(dc.Commands, Command{UUID: "profile2", After: []string{"profile1"}})
+	dc.Commands = append(dc.Commands, Command{UUID: "profile3", After: []string{"profile2"}})
+	dc.Commands = append(dc.Commands, Command{UUID: "app", After: []string{"unknown"}})
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cmd, err := store.Next(ctx, mdm.Response{UDID: dc.DeviceUDID, Status: "Idle"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd == nil || cmd.UUID != "profile1" {
+		t.Fatalf("have next command %v, want profile1", cmd)
+	}
+	cmd, err = store.Next(ctx, mdm.Response{UDID: dc.DeviceUDID, CommandUUID: "profile1", Status: "Error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd != nil {
+		t.Errorf("have next command %s, want none", cmd.UUID)
+	}
+
+	history, err := store.History(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(history.Failed), []string{"profile1", "profile2", "profile3"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have failed commands %v, want %v", have, want)
+	}
+	queued, err := store.DeviceCommand(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(queued.Commands), []string{"app"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have pending commands %v, want %v", have, want)
+	}
+}
+
+// TestPollCommands_Restart checks that a restarted queue does not queue the
+// commands it queued before the restart again.
+func TestPollCommands_Restart(t *testing.T) {
+	f, _ := ioutil.TempFile("", "bolt-")
+	f.Close()
+	defer os.Remove(f.Name())
+
+	// start opens the database and subscribes a new queue, like a server start.
+	start := func() (*bolt.DB, *Store, pubsub.PublishSubscriber, <-chan pubsub.Event) {
+		db, err := bolt.Open(f.Name(), 0777, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ps, err := pubsubbuiltin.NewPubSub(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		queued, err := ps.Subscribe(context.Background(), "test", CommandQueuedTopic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store, err := NewQueue(db, ps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return db, store, ps, queued
+	}
+	publish := func(ps pubsub.Publisher, uuid string) {
+		ev := command.NewEvent(mdm.Payload{
+			CommandUUID: uuid,
+			Command:     &mdm.Command{RequestType: "DeviceLock"},
+		}, "udid")
+		msg, err := command.MarshalEvent(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ps.Publish(context.Background(), command.CommandTopic, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitQueued := func(events <-chan pubsub.Event) string {
+		select {
+		case ev := <-events:
+			ev.Ack()
+			cq, err := UnmarshalQueuedCommand(ev.Message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return cq.CommandUUID
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a queued command")
+			return ""
+		}
+	}
+
+	db, _, ps, queued := start()
+	publish(ps, "lock-1")
+	if have := waitQueued(queued); have != "lock-1" {
+		t.Fatalf("have queued %s, want lock-1", have)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, store, ps, queued := start()
+	defer db.Close()
+	publish(ps, "lock-2")
+	if have := waitQueued(queued); have != "lock-2" {
+		t.Fatalf("have queued %s after the restart, want lock-2", have)
+	}
+	dc, err := store.DeviceCommand("udid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(dc.Commands), []string{"lock-1", "lock-2"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have queued commands %v, want %v", have, want)
+	}
+}
+
+func TestQueueCommand_ConcurrentNext(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	const n = 20
+	pub := inmem.NewPubSub()
+	errs := make(chan error, 2*n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		msg, err := command.MarshalEvent(command.NewEvent(mdm.Payload{
+			CommandUUID: fmt.Sprintf("cmd-%d", i),
+			Command:     &mdm.Command{RequestType: "DeviceInformation"},
+		}, "udid"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- store.queueCommand(pub, pubsub.Event{Topic: command.CommandTopic, Message: msg})
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := store.Next(context.Background(), mdm.Response{UDID: "udid", Status: "Idle"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dc, err := store.DeviceCommand("udid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := len(dc.Commands); have != n {
+		t.Errorf("have %d queued commands, want %d", have, n)
+	}
+}
@@ -0,0 +1,95 @@
+package queue
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// RetryCommand moves a failed or expired command back to the end of the
+// pending queue. The delivery history and expiry of the command are reset.
+func (svc *QueueService) RetryCommand(ctx context.Context, udid, uuid string) error {
+	err := svc.store.Requeue(udid, uuid, func(cmd *Command) {
+		cmd.LastSentAt = time.Time{}
+		cmd.Acknowledged = time.Time{}
+		cmd.TimesSent = 0
+		cmd.LastStatus = ""
+		cmd.FailureMessage = nil
+		cmd.ExpiresAt = time.Time{}
+		cmd.NotNowCount = 0
+	})
+	if err != nil {
+		return err
+	}
+	// notify the device of the requeued command.
+	queued, err := MarshalQueuedCommand(&QueueCommandQueued{DeviceUDID: udid, CommandUUID: uuid})
+	if err != nil {
+		return errors.Wrap(err, "marshal queued command")
+	}
+	if err := svc.publisher.Publish(ctx, CommandQueuedTopic, queued); err != nil {
+		return errors.Wrapf(err, "publish queued command on topic: %s", CommandQueuedTopic)
+	}
+	return svc.publishQueueEvent(ctx, NewQueueEvent(ActionRetry, udid, uuid))
+}
+
+type retryCommandRequest struct {
+	UDID string
+	UUID string
+}
+
+type retryCommandResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r retryCommandResponse) Failed() error { return r.Err }
+
+func decodeRetryCommandRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var errBadRoute = errors.New("bad route")
+	vars := mux.Vars(r)
+	udid, ok := vars["udid"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	uuid, ok := vars["uuid"]
+	if !ok {
+		return 0, errBadRoute
+	}
+	return retryCommandRequest{UDID: udid, UUID: uuid}, nil
+}
+
+func encodeRetryCommandRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(retryCommandRequest)
+	udid, uuid := url.QueryEscape(req.UDID), url.QueryEscape(req.UUID)
+	r.Method, r.URL.Path = "POST", "/v1/devices/"+udid+"/commands/"+uuid+"/retry"
+	return nil
+}
+
+func decodeRetryCommandResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp retryCommandResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeRetryCommandEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(retryCommandRequest)
+		err = svc.RetryCommand(ctx, req.UDID, req.UUID)
+		return retryCommandResponse{Err: err}, nil
+	}
+}
+
+func (e Endpoints) RetryCommand(ctx context.Context, udid, uuid string) error {
+	request := retryCommandRequest{UDID: udid, UUID: uuid}
+	resp, err := e.RetryCommandEndpoint(ctx, request)
+	if err != nil {
+		return err
+	}
+	return resp.(retryCommandResponse).Err
+}
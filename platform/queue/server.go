@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+type Endpoints struct {
+	GetCommandsEndpoint   endpoint.Endpoint
+	CancelCommandEndpoint endpoint.Endpoint
+	RetryCommandEndpoint  endpoint.Endpoint
+	ClearCommandsEndpoint endpoint.Endpoint
+	GetBatchEndpoint      endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		GetCommandsEndpoint:   MakeGetCommandsEndpoint(s),
+		CancelCommandEndpoint: MakeCancelCommandEndpoint(s),
+		RetryCommandEndpoint:  MakeRetryCommandEndpoint(s),
+		ClearCommandsEndpoint: MakeClearCommandsEndpoint(s),
+		GetBatchEndpoint:      MakeGetBatchEndpoint(s),
+	}
+}
+
+func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
+	r, options := httputil.NewRouter(logger)
+
+	// GET     /v1/devices/:udid/commands			get the queued and past commands of a device
+	// DELETE  /v1/devices/:udid/commands			remove all pending commands of a device
+	// DELETE  /v1/devices/:udid/commands/:uuid		cancel a pending command
+	// POST    /v1/devices/:udid/commands/:uuid/retry	requeue a failed command
+	// GET     /v1/batches/:id				get the progress of a batch of commands
+
+	r.Methods("GET").Path("/v1/devices/{udid}/commands").Handler(httptransport.NewServer(
+		e.GetCommandsEndpoint,
+		decodeGetCommandsRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("DELETE").Path("/v1/devices/{udid}/commands").Handler(httptransport.NewServer(
+		e.ClearCommandsEndpoint,
+		decodeClearCommandsRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("DELETE").Path("/v1/devices/{udid}/commands/{uuid}").Handler(httptransport.NewServer(
+		e.CancelCommandEndpoint,
+		decodeCancelCommandRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/v1/devices/{udid}/commands/{uuid}/retry").Handler(httptransport.NewServer(
+		e.RetryCommandEndpoint,
+		decodeRetryCommandRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/v1/batches/{id}").Handler(httptransport.NewServer(
+		e.GetBatchEndpoint,
+		decodeGetBatchRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	return r
+}
@@ -0,0 +1,39 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+type Service interface {
+	GetCommands(ctx context.Context, udid string, opt GetCommandsOption) ([]CommandDTO, error)
+	CancelCommand(ctx context.Context, udid, uuid string) error
+	RetryCommand(ctx context.Context, udid, uuid string) error
+	ClearCommands(ctx context.Context, udid string) error
+	GetBatch(ctx context.Context, id string) (*BatchDTO, error)
+}
+
+// CommandStore provides access to the per-device command queue.
+type CommandStore interface {
+	DeviceCommand(udid string) (*DeviceCommand, error)
+	History(udid string) (*DeviceCommand, error)
+
+	// UpdateDeviceCommand reads, updates and saves the pending commands
+	// of a device in a single transaction.
+	UpdateDeviceCommand(udid string, fn func(*DeviceCommand) error) error
+
+	// Requeue moves a Failed or Expired command from the history of a
+	// device back to its pending commands in a single transaction.
+	Requeue(udid, uuid string, reset func(*Command)) error
+}
+
+type QueueService struct {
+	store     CommandStore
+	publisher pubsub.Publisher
+	batches   BatchStore
+}
+
+func NewService(store CommandStore, publisher pubsub.Publisher, batches BatchStore) *QueueService {
+	return &QueueService{store: store, publisher: publisher, batches: batches}
+}
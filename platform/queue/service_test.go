@@ -0,0 +1,213 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/as/micromdm/platform/command"
+)
+
+func TestCancelRetryClear(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, testCommand(t, "xCmd", "EraseDevice", now))
+	dc.NotNow = append(dc.NotNow, testCommand(t, "yCmd", "InstallApplication", now))
+	failed := testCommand(t, "zCmd", "InstallProfile", now)
+	failed.TimesSent = 1
+	failed.LastStatus = "Error"
+	failed.FailureMessage = []byte(`[{"ErrorCode":12021}]`)
+	dc.Failed = append(dc.Failed, failed)
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	pub := new(recordingPublisher)
+	svc := NewService(store, pub, nil)
+	ctx := context.Background()
+
+	if err := svc.CancelCommand(ctx, dc.DeviceUDID, "xCmd"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.CancelCommand(ctx, dc.DeviceUDID, "xCmd"); !isNotFound(err) {
+		t.Errorf("expected not found error when cancelling twice, got %v", err)
+	}
+	if err := svc.RetryCommand(ctx, dc.DeviceUDID, "zCmd"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.DeviceCommand(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(got.Commands), []string{"zCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("pending: have %v, want %v", have, want)
+	}
+	history, err := store.History(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Failed) != 0 {
+		t.Errorf("expected retried command to be removed from failed, got %v", uuids(history.Failed))
+	}
+	if retried := got.Commands[0]; retried.TimesSent != 0 || retried.LastStatus != "" || retried.FailureMessage != nil {
+		t.Errorf("expected delivery history of retried command to be reset, got %+v", retried)
+	}
+
+	if err := svc.ClearCommands(ctx, dc.DeviceUDID); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.DeviceCommand(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Commands) != 0 || len(got.NotNow) != 0 {
+		t.Errorf("expected empty queue, got %v %v", uuids(got.Commands), uuids(got.NotNow))
+	}
+
+	var events []QueueEvent
+	for _, msg := range pub.messages[QueueChangedTopic] {
+		var ev QueueEvent
+		if err := UnmarshalQueueEvent(msg, &ev); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, ev)
+	}
+	want := []struct {
+		action string
+		uuids  []string
+	}{
+		{ActionCancel, []string{"xCmd"}},
+		{ActionRetry, []string{"zCmd"}},
+		{ActionClear, []string{"zCmd", "yCmd"}},
+	}
+	if have, want := len(events), len(want); have != want {
+		t.Fatalf("have %d queue events, want %d", have, want)
+	}
+	for i, ev := range events {
+		if ev.Action != want[i].action || !reflect.DeepEqual(ev.CommandUUIDs, want[i].uuids) {
+			t.Errorf("event %d: have %s %v, want %s %v", i, ev.Action, ev.CommandUUIDs, want[i].action, want[i].uuids)
+		}
+		if ev.DeviceUDID != dc.DeviceUDID {
+			t.Errorf("event %d: have udid %s, want %s", i, ev.DeviceUDID, dc.DeviceUDID)
+		}
+	}
+	if have, want := len(pub.messages[CommandQueuedTopic]), 1; have != want {
+		t.Errorf("have %d %s events, want %d", have, CommandQueuedTopic, want)
+	}
+}
+
+func TestGetBatch(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	first := &DeviceCommand{DeviceUDID: "FirstDevice"}
+	first.Completed = append(first.Completed, testCommand(t, "aCmd", "DeviceLock", now))
+	second := &DeviceCommand{DeviceUDID: "SecondDevice"}
+	second.NotNow = append(second.NotNow, testCommand(t, "bCmd", "DeviceLock", now))
+	for _, dc := range []*DeviceCommand{first, second} {
+		if err := store.Save(dc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	batches := batchStore{&command.Batch{
+		ID:          "TestBatch",
+		CreatedAt:   now,
+		RequestType: "DeviceLock",
+		Commands: []command.BatchCommand{
+			{UDID: "FirstDevice", CommandUUID: "aCmd"},
+			{UDID: "SecondDevice", CommandUUID: "bCmd"},
+			{UDID: "ThirdDevice", CommandUUID: "cCmd"},
+		},
+	}}
+	svc := NewService(store, nil, batches)
+
+	batch, err := svc.GetBatch(context.Background(), "TestBatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batch.Done {
+		t.Error("expected batch with pending commands not to be done")
+	}
+	wantCounts := map[string]int{StatusCompleted: 1, StatusNotNow: 1, StatusUnknown: 1}
+	if !reflect.DeepEqual(batch.Counts, wantCounts) {
+		t.Errorf("have counts %v, want %v", batch.Counts, wantCounts)
+	}
+	if have, want := batch.Total, 3; have != want {
+		t.Errorf("have total %d, want %d", have, want)
+	}
+
+	if _, err := svc.GetBatch(context.Background(), "NoBatch"); err == nil {
+		t.Error("expected error for unknown batch")
+	}
+}
+
+type batchStore []*command.Batch
+
+func (s batchStore) Batch(id string) (*command.Batch, error) {
+	for _, b := range s {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return nil, errors.New("batch not found")
+}
+
+type recordingPublisher struct {
+	messages map[string][][]byte
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, msg []byte) error {
+	if p.messages == nil {
+		p.messages = make(map[string][][]byte)
+	}
+	p.messages[topic] = append(p.messages[topic], msg)
+	return nil
+}
+
+func uuids(commands []Command) []string {
+	var u []string
+	for _, cmd := range commands {
+		u = append(u, cmd.UUID)
+	}
+	return u
+}
+
+func TestUpdateDeviceCommand_Rollback(t *testing.T) {
+	store, teardown := setupDB(t)
+	defer teardown()
+
+	now := time.Now().UTC()
+	dc := &DeviceCommand{DeviceUDID: "TestDevice"}
+	dc.Commands = append(dc.Commands, testCommand(t, "xCmd", "EraseDevice", now))
+	if err := store.Save(dc); err != nil {
+		t.Fatal(err)
+	}
+
+	errAbort := errors.New("abort")
+	err := store.UpdateDeviceCommand(dc.DeviceUDID, func(dc *DeviceCommand) error {
+		dc.Commands = nil
+		return errAbort
+	})
+	if err != errAbort {
+		t.Fatalf("have error %v, want %v", err, errAbort)
+	}
+	got, err := store.DeviceCommand(dc.DeviceUDID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uuids(got.Commands), []string{"xCmd"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("pending: have %v, want %v", have, want)
+	}
+
+	if err := store.Requeue(dc.DeviceUDID, "xCmd", func(*Command) {}); !isNotFound(err) {
+		t.Errorf("expected not found error for a pending command, got %v", err)
+	}
+}
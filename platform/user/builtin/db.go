@@ -206,45 +206,21 @@ func (db *DB) pollCheckin(pubsubSvc pubsub.PublishSubscriber) error {
 		return errors.Wrapf(err,
 			"subscribing devices to %s topic", checkin.TokenUpdateTopic)
 	}
+	replayEvents, err := pubsubSvc.Subscribe(context.TODO(), "users", checkin.ReplayTopic)
+	if err != nil {
+		return errors.Wrapf(err,
+			"subscribing users to %s topic", checkin.ReplayTopic)
+	}
 	go func() {
 		for {
+			var e pubsub.Event
 			select {
-			case e := <-tokenUpdateEvents:
-				event, err := unmarshalCheckin(e)
-				if err != nil {
-					level.Info(db.logger).Log("err", err, "msg", "unmarshal TokenUpdate event in user db")
-					break
-				}
-				if event.Command.UserID == "" {
-					break // only interested in user commands
-				}
-				newUser := new(user.User)
-				byGUID, err := db.UserByUserID(event.Command.UserID)
-				if err != nil && !isNotFound(err) {
-					level.Info(db.logger).Log("err", err, "msg", "get user from DB")
-					break
-				}
-				if err == nil && byGUID != nil {
-					newUser = byGUID
-				}
-				if newUser.UUID == "" {
-					if err := db.DeleteDeviceUsers(event.Command.UDID); err != nil {
-						level.Info(db.logger).Log(
-							"err", err,
-							"msg", "delete existing user before creating new one",
-						)
-					}
-					newUser.UUID = uuid.NewV4().String()
-				}
-				newUser.UDID = event.Command.UDID
-				newUser.UserID = event.Command.UserID
-				newUser.UserLongname = event.Command.UserLongName
-				newUser.UserShortname = event.Command.UserShortName
-				newUser.AuthToken = event.Command.Token.String()
-				if err := db.Save(newUser); err != nil {
-					level.Info(db.logger).Log("err", err, "msg", "update user from TokenUpdate")
-					break	// TODO(as): this does nothing (in original repo)
-				}
+			case e = <-tokenUpdateEvents:
+			case e = <-replayEvents:
+			}
+			db.handleTokenUpdate(e)
+			if err := e.Ack(); err != nil {
+				level.Info(db.logger).Log("err", err, "msg", "ack TokenUpdate event in user db")
 			}
 		}
 	}()
@@ -252,6 +228,45 @@ func (db *DB) pollCheckin(pubsubSvc pubsub.PublishSubscriber) error {
 	return nil
 }
 
+// handleTokenUpdate creates or updates the user of a user TokenUpdate.
+// Replayed checkins of other message types are ignored.
+func (db *DB) handleTokenUpdate(e pubsub.Event) {
+	event, err := unmarshalCheckin(e)
+	if err != nil {
+		level.Info(db.logger).Log("err", err, "msg", "unmarshal TokenUpdate event in user db")
+		return
+	}
+	if event.Command.MessageType != "TokenUpdate" || event.Command.UserID == "" {
+		return // only interested in user commands
+	}
+	newUser := new(user.User)
+	byGUID, err := db.UserByUserID(event.Command.UserID)
+	if err != nil && !isNotFound(err) {
+		level.Info(db.logger).Log("err", err, "msg", "get user from DB")
+		return
+	}
+	if err == nil && byGUID != nil {
+		newUser = byGUID
+	}
+	if newUser.UUID == "" {
+		if err := db.DeleteDeviceUsers(event.Command.UDID); err != nil {
+			level.Info(db.logger).Log(
+				"err", err,
+				"msg", "delete existing user before creating new one",
+			)
+		}
+		newUser.UUID = uuid.NewV4().String()
+	}
+	newUser.UDID = event.Command.UDID
+	newUser.UserID = event.Command.UserID
+	newUser.UserLongname = event.Command.UserLongName
+	newUser.UserShortname = event.Command.UserShortName
+	newUser.AuthToken = event.Command.Token.String()
+	if err := db.Save(newUser); err != nil {
+		level.Info(db.logger).Log("err", err, "msg", "update user from TokenUpdate")
+	}
+}
+
 func unmarshalCheckin(event pubsub.Event) (checkin.Event, error) {
 	var ev checkin.Event
 	if err := checkin.UnmarshalEvent(event.Message, &ev); err != nil {
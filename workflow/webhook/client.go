@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+func NewHTTPClient(instance, token string, logger log.Logger, opts ...httptransport.ClientOption) (Service, error) {
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var listFailedEndpoint endpoint.Endpoint
+	{
+		listFailedEndpoint = httptransport.NewClient(
+			"GET",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeListFailedRequest),
+			decodeListFailedResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	var replayFailedEndpoint endpoint.Endpoint
+	{
+		replayFailedEndpoint = httptransport.NewClient(
+			"POST",
+			httputil.CopyURL(u, ""), // empty path, modified by the encodeRequest func
+			httputil.EncodeRequestWithToken(token, encodeReplayFailedRequest),
+			decodeReplayFailedResponse,
+			opts...,
+		).Endpoint()
+	}
+
+	return Endpoints{
+		ListFailedEndpoint:   listFailedEndpoint,
+		ReplayFailedEndpoint: replayFailedEndpoint,
+	}, nil
+}
@@ -1,10 +1,8 @@
 package webhook
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/as/micromdm/mdm/connect"
 	"github.com/as/micromdm/platform/pubsub"
@@ -13,13 +11,17 @@ import (
 
 const contentType = "application/x-apple-aspen-mdm"
 
+// CommandTarget is the target ID of the command webhook, which is passed to
+// WithSecret.
+const CommandTarget = "command-webhook"
+
 type CommandWebhook struct {
 	Topic       string
 	CallbackURL string
-	HTTPClient  *http.Client
+	Sender      *Sender
 }
 
-func NewCommandWebhook(httpClient *http.Client, topic, callbackURL string) (*CommandWebhook, error) {
+func NewCommandWebhook(sender *Sender, topic, callbackURL string) (*CommandWebhook, error) {
 	if topic == "" {
 		return nil, errors.New("webhook: topic should not be empty")
 	}
@@ -28,7 +30,7 @@ func NewCommandWebhook(httpClient *http.Client, topic, callbackURL string) (*Com
 		return nil, errors.New("webhook: callbackURL should not be empty")
 	}
 
-	return &CommandWebhook{HTTPClient: httpClient, Topic: topic, CallbackURL: callbackURL}, nil
+	return &CommandWebhook{Sender: sender, Topic: topic, CallbackURL: callbackURL}, nil
 }
 
 func (cw CommandWebhook) StartListener(sub pubsub.Subscriber) error {
@@ -39,22 +41,32 @@ func (cw CommandWebhook) StartListener(sub pubsub.Subscriber) error {
 	}
 
 	go func() {
-		for {
-			select {
-			case event := <-connectEvents:
-				var ev connect.Event
-				if err := connect.UnmarshalEvent(event.Message, &ev); err != nil {
-					fmt.Println(err)
-					continue
-				}
-
-				_, err := cw.HTTPClient.Post(cw.CallbackURL, contentType, bytes.NewBuffer(ev.Raw))
-				if err != nil {
-					fmt.Printf("error sending command response: %s\n", err)
-				}
+		var acks pubsub.AckQueue
+		for event := range connectEvents {
+			if err := cw.send(event, acks.Add(event, 1)); err != nil {
+				fmt.Printf("error sending command response: %s\n", err)
 			}
 		}
 	}()
 
 	return nil
 }
+
+// send queues the command response, and calls done once it was sent or
+// saved to the dead-letter store.
+func (cw CommandWebhook) send(event pubsub.Event, done func(error)) error {
+	var ev connect.Event
+	if err := connect.UnmarshalEvent(event.Message, &ev); err != nil {
+		done(nil)
+		return err
+	}
+	cw.Sender.Deliver(&Delivery{
+		URL:         cw.CallbackURL,
+		Target:      CommandTarget,
+		ContentType: contentType,
+		Body:        ev.Raw,
+		Topic:       cw.Topic,
+		EventID:     ev.ID,
+	}, done)
+	return nil
+}
@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/workflow/webhook/internal/webhookproto"
+)
+
+// DeadLetterBucket is the *bolt.DB bucket where deliveries which failed after
+// every retry are kept until they are replayed.
+const DeadLetterBucket = "mdm.WebhookDeadLetter"
+
+// Delivery is a webhook request to a single target.
+type Delivery struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Target      string    `json:"target,omitempty"`
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+	Topic       string    `json:"topic"`
+	EventID     string    `json:"event_id,omitempty"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	FailedAt    time.Time `json:"failed_at,omitempty"`
+}
+
+// DeadLetterDB is a DeadLetterStore backed by BoltDB.
+type DeadLetterDB struct {
+	*bolt.DB
+}
+
+func NewDeadLetterDB(db *bolt.DB) (*DeadLetterDB, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(DeadLetterBucket))
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating %s bucket", DeadLetterBucket)
+	}
+	return &DeadLetterDB{DB: db}, nil
+}
+
+func (db *DeadLetterDB) Save(d *Delivery) error {
+	pb, err := MarshalDelivery(d)
+	if err != nil {
+		return errors.Wrap(err, "marshalling Delivery")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(DeadLetterBucket)).Put([]byte(d.ID), pb)
+	})
+	return errors.Wrapf(err, "save delivery %s", d.ID)
+}
+
+func (db *DeadLetterDB) Delivery(id string) (*Delivery, error) {
+	var d Delivery
+	err := db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(DeadLetterBucket)).Get([]byte(id))
+		if v == nil {
+			return &notFound{"Delivery", fmt.Sprintf("id %s", id)}
+		}
+		return UnmarshalDelivery(v, &d)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "webhook: get failed delivery")
+	}
+	return &d, nil
+}
+
+// List returns the failed deliveries, oldest failure first.
+func (db *DeadLetterDB) List() ([]Delivery, error) {
+	var deliveries []Delivery
+	err := db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(DeadLetterBucket)).ForEach(func(k, v []byte) error {
+			var d Delivery
+			if err := UnmarshalDelivery(v, &d); err != nil {
+				return errors.Wrapf(err, "unmarshal delivery %s", k)
+			}
+			deliveries = append(deliveries, d)
+			return nil
+		})
+	})
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].FailedAt.Before(deliveries[j].FailedAt)
+	})
+	return deliveries, errors.Wrap(err, "webhook: list failed deliveries")
+}
+
+func (db *DeadLetterDB) Delete(id string) error {
+	err := db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(DeadLetterBucket))
+		if b.Get([]byte(id)) == nil {
+			return &notFound{"Delivery", fmt.Sprintf("id %s", id)}
+		}
+		return b.Delete([]byte(id))
+	})
+	return errors.Wrapf(err, "delete delivery %s", id)
+}
+
+func MarshalDelivery(d *Delivery) ([]byte, error) {
+	pb := webhookproto.Delivery{
+		Id:          d.ID,
+		Url:         d.URL,
+		Target:      d.Target,
+		ContentType: d.ContentType,
+		Body:        d.Body,
+		Topic:       d.Topic,
+		EventId:     d.EventID,
+		Attempts:    int64(d.Attempts),
+		LastError:   d.LastError,
+		CreatedAt:   timeToNano(d.CreatedAt),
+		FailedAt:    timeToNano(d.FailedAt),
+	}
+	return proto.Marshal(&pb)
+}
+
+func UnmarshalDelivery(data []byte, d *Delivery) error {
+	var pb webhookproto.Delivery
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return errors.Wrap(err, "unmarshal proto to Delivery")
+	}
+	d.ID = pb.GetId()
+	d.URL = pb.GetUrl()
+	d.Target = pb.GetTarget()
+	d.ContentType = pb.GetContentType()
+	d.Body = pb.GetBody()
+	d.Topic = pb.GetTopic()
+	d.EventID = pb.GetEventId()
+	d.Attempts = int(pb.GetAttempts())
+	d.LastError = pb.GetLastError()
+	d.CreatedAt = nanoToTime(pb.GetCreatedAt())
+	d.FailedAt = nanoToTime(pb.GetFailedAt())
+	return nil
+}
+
+func timeToNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func nanoToTime(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+type notFound struct {
+	ResourceType string
+	Message      string
+}
+
+func (e *notFound) Error() string {
+	return fmt.Sprintf("not found: %s %s", e.ResourceType, e.Message)
+}
+
+// NotFound is returned by the store when a failed delivery does not exist.
+func (e *notFound) NotFound() bool {
+	return true
+}
@@ -0,0 +1,310 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/as/micromdm/pkg/backoff"
+)
+
+// SignatureHeader is the header of a webhook request which holds the
+// hex encoded HMAC-SHA256 of the TimestampHeader value, a "." and the request
+// body, keyed with the secret of the target, in the form "sha256=<hex>".
+const SignatureHeader = "X-Micromdm-Signature"
+
+// TimestampHeader is the header of a signed webhook request which holds the
+// Unix time in seconds at which the request was sent.
+const TimestampHeader = "X-Micromdm-Timestamp"
+
+// SignatureTolerance is how far the timestamp of a request may be from the
+// clock of the receiver for VerifySignature. Receivers reject older requests,
+// so a captured request can only be replayed within this window.
+const SignatureTolerance = 5 * time.Minute
+
+// EventIDHeader is the header of a webhook request which holds the ID of the
+// event. Receivers can use it to discard duplicate deliveries.
+const EventIDHeader = "X-Micromdm-Event-Id"
+
+// RetryPolicy controls how often a failed webhook request is sent again
+// before the delivery is moved to the dead-letter store.
+type RetryPolicy = backoff.Policy
+
+// DefaultRetryPolicy is used by a Sender created without WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+// DefaultQueueSize is the number of deliveries to a target which wait to be
+// sent by a Sender created without WithQueueSize.
+const DefaultQueueSize = 1000
+
+// DeadLetterStore persists deliveries which failed after every retry.
+type DeadLetterStore interface {
+	Save(*Delivery) error
+	Delivery(id string) (*Delivery, error)
+	List() ([]Delivery, error)
+	Delete(id string) error
+}
+
+// Sender signs and sends webhook requests.
+type Sender struct {
+	client      *http.Client
+	retry       RetryPolicy
+	deadLetters DeadLetterStore
+	secrets     map[string]string
+	sleep       func(context.Context, time.Duration) error
+	queueSize   int
+
+	mu     sync.Mutex
+	queues map[string]chan queued
+}
+
+// queued is a delivery waiting in the queue of its target.
+type queued struct {
+	d    *Delivery
+	done func(error)
+}
+
+type SenderOption func(*Sender)
+
+// WithRetryPolicy sets the retry policy of failed requests.
+func WithRetryPolicy(policy RetryPolicy) SenderOption {
+	return func(s *Sender) {
+		s.retry = policy
+	}
+}
+
+// WithDeadLetters saves the deliveries which failed after every retry
+// to the store.
+func WithDeadLetters(store DeadLetterStore) SenderOption {
+	return func(s *Sender) {
+		s.deadLetters = store
+	}
+}
+
+// WithQueueSize sets the number of deliveries to a target which wait to be
+// sent. Deliveries to a target with a full queue are moved to the dead-letter
+// store without being sent.
+func WithQueueSize(n int) SenderOption {
+	return func(s *Sender) {
+		s.queueSize = n
+	}
+}
+
+// WithSecret signs the requests to the target with the ID with the secret.
+func WithSecret(target, secret string) SenderOption {
+	return func(s *Sender) {
+		if secret != "" {
+			s.secrets[target] = secret
+		}
+	}
+}
+
+func NewSender(client *http.Client, opts ...SenderOption) *Sender {
+	s := &Sender{
+		client:    client,
+		retry:     DefaultRetryPolicy,
+		secrets:   make(map[string]string),
+		sleep:     backoff.Sleep,
+		queueSize: DefaultQueueSize,
+		queues:    make(map[string]chan queued),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Deliver queues the delivery and returns without waiting for it to be sent.
+// Every target has a worker which sends its deliveries in order with Send, so
+// a slow or unreachable target only delays its own deliveries. A delivery to
+// a target with a full queue is moved to the dead-letter store.
+//
+// Queued deliveries are not persisted. done is called once the delivery was
+// sent or saved to the dead-letter store with a nil error, or with the error
+// if it was neither. Pubsub consumers acknowledge their event in done, so a
+// durable pubsub delivers the event again if the server stops first.
+func (s *Sender) Deliver(d *Delivery, done func(error)) {
+	s.prepare(d)
+	select {
+	case s.queue(d.URL) <- queued{d: d, done: done}:
+	default:
+		err := fmt.Errorf("send %s event %s to %s: delivery queue full", d.Topic, d.EventID, d.URL)
+		saved, err := s.deadLetter(d, err)
+		fmt.Println(err)
+		done(settled(saved, err))
+	}
+}
+
+// queue returns the queue of the target, and starts its worker on first use.
+func (s *Sender) queue(url string) chan<- queued {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.queues[url]
+	if !ok {
+		q = make(chan queued, s.queueSize)
+		s.queues[url] = q
+		go s.worker(q)
+	}
+	return q
+}
+
+func (s *Sender) worker(queue <-chan queued) {
+	for q := range queue {
+		saved, err := s.send(context.Background(), q.d)
+		if err != nil {
+			fmt.Println(err)
+		}
+		q.done(settled(saved, err))
+	}
+}
+
+// settled returns nil if the delivery was sent or saved to the
+// dead-letter store.
+func settled(saved bool, err error) error {
+	if saved {
+		return nil
+	}
+	return err
+}
+
+// Send delivers the request, retrying with exponential backoff. A delivery
+// which fails after every retry is saved to the dead-letter store.
+// Send blocks until the delivery succeeded or every retry failed, use Deliver
+// to send from a pubsub consumer.
+func (s *Sender) Send(ctx context.Context, d *Delivery) error {
+	_, err := s.send(ctx, d)
+	return err
+}
+
+// send is Send, and also reports whether the delivery was saved to the
+// dead-letter store.
+func (s *Sender) send(ctx context.Context, d *Delivery) (bool, error) {
+	s.prepare(d)
+	var err error
+	for i := 0; i < s.retry.Attempts(); i++ {
+		if i > 0 {
+			if err := s.sleep(ctx, s.retry.Delay(i)); err != nil {
+				break
+			}
+		}
+		d.Attempts++
+		if err = s.post(ctx, d); err == nil {
+			return false, nil
+		}
+	}
+	return s.deadLetter(d, err)
+}
+
+func (s *Sender) prepare(d *Delivery) {
+	if d.ID == "" {
+		d.ID = uuid.NewV4().String()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now().UTC()
+	}
+}
+
+// deadLetter saves a delivery which failed with err to the dead-letter store,
+// and reports whether it was saved.
+func (s *Sender) deadLetter(d *Delivery, err error) (bool, error) {
+	d.LastError = err.Error()
+	d.FailedAt = time.Now().UTC()
+	if s.deadLetters == nil {
+		return false, err
+	}
+	if dlErr := s.deadLetters.Save(d); dlErr != nil {
+		return false, errors.Wrapf(dlErr, "save failed delivery %s: %s", d.ID, err)
+	}
+	return true, errors.Wrapf(err, "delivery %s moved to dead letters", d.ID)
+}
+
+// Replay sends a delivery from the dead-letter store once. The delivery
+// is removed from the store if it succeeds.
+func (s *Sender) Replay(ctx context.Context, id string) error {
+	if s.deadLetters == nil {
+		return errors.New("webhook: no dead-letter store")
+	}
+	d, err := s.deadLetters.Delivery(id)
+	if err != nil {
+		return err
+	}
+	d.Attempts++
+	if err := s.post(ctx, d); err != nil {
+		d.LastError = err.Error()
+		d.FailedAt = time.Now().UTC()
+		if saveErr := s.deadLetters.Save(d); saveErr != nil {
+			return saveErr
+		}
+		return err
+	}
+	return s.deadLetters.Delete(id)
+}
+
+func (s *Sender) post(ctx context.Context, d *Delivery) error {
+	req, err := http.NewRequest("POST", d.URL, bytes.NewReader(d.Body))
+	if err != nil {
+		return errors.Wrap(err, "create webhook request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", d.ContentType)
+	if d.EventID != "" {
+		req.Header.Set(EventIDHeader, d.EventID)
+	}
+	target := d.Target
+	if target == "" {
+		// deliveries saved before targets had an ID.
+		target = d.URL
+	}
+	if secret, ok := s.secrets[target]; ok {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, d.Body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "send %s event %s to %s", d.Topic, d.EventID, d.URL)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("send %s event %s to %s: %s", d.Topic, d.EventID, d.URL, resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the value of the SignatureHeader for the timestamp and body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is the SignatureHeader of the
+// timestamp and body, and the timestamp is within SignatureTolerance of now.
+func VerifySignature(secret, timestamp string, body []byte, signature string, now time.Time) bool {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if d := now.Sub(time.Unix(sec, 0)); d > SignatureTolerance || d < -SignatureTolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
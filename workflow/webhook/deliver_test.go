@@ -0,0 +1,247 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+func TestSender_RetriesAndSigns(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !VerifySignature("s3cret", r.Header.Get(TimestampHeader), body, r.Header.Get(SignatureHeader), time.Now()) {
+			t.Errorf("invalid signature %q", r.Header.Get(SignatureHeader))
+		}
+		if have, want := r.Header.Get(EventIDHeader), "event-1"; have != want {
+			t.Errorf("have event id %q, want %q", have, want)
+		}
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	sender := NewSender(http.DefaultClient,
+		WithSecret("target-1", "s3cret"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: time.Minute}),
+	)
+	sender.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	d := &Delivery{URL: srv.URL, Target: "target-1", ContentType: "application/json", Body: []byte(`{}`), EventID: "event-1"}
+	if err := sender.Send(context.Background(), d); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := d.Attempts, 3; have != want {
+		t.Errorf("have %d attempts, want %d", have, want)
+	}
+	if len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Errorf("have retry delays %v, want [1s 2s]", delays)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"a":1}`)
+	signature := Sign("s3cret", timestamp, body)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		body      []byte
+		now       time.Time
+		want      bool
+	}{
+		{name: "valid", timestamp: timestamp, body: body, now: now, want: true},
+		{name: "within tolerance", timestamp: timestamp, body: body, now: now.Add(SignatureTolerance - time.Second), want: true},
+		{name: "replayed", timestamp: timestamp, body: body, now: now.Add(SignatureTolerance + time.Second)},
+		{name: "changed timestamp", timestamp: strconv.FormatInt(now.Unix()+1, 10), body: body, now: now},
+		{name: "changed body", timestamp: timestamp, body: []byte(`{"a":2}`), now: now},
+		{name: "invalid timestamp", timestamp: "yesterday", body: body, now: now},
+	}
+	for _, tt := range tests {
+		if have := VerifySignature("s3cret", tt.timestamp, tt.body, signature, tt.now); have != tt.want {
+			t.Errorf("%s: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestSender_SecretsByTarget(t *testing.T) {
+	signatures := make(chan string, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		for _, secret := range []string{"secret-a", "secret-b"} {
+			if VerifySignature(secret, r.Header.Get(TimestampHeader), body, r.Header.Get(SignatureHeader), time.Now()) {
+				signatures <- secret
+				return
+			}
+		}
+		signatures <- ""
+	}))
+	defer srv.Close()
+
+	sender := NewSender(http.DefaultClient, WithSecret("a", "secret-a"), WithSecret("b", "secret-b"))
+	for _, target := range []string{"a", "b"} {
+		d := &Delivery{URL: srv.URL, Target: target, ContentType: "application/json", Body: []byte(`{}`)}
+		if err := sender.Send(context.Background(), d); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := <-signatures, "secret-"+target; have != want {
+			t.Errorf("have request to target %s signed with %q, want %q", target, have, want)
+		}
+	}
+}
+
+func TestSender_DeadLetterAndReplay(t *testing.T) {
+	store, teardown := setupDeadLetterDB(t)
+	defer teardown()
+
+	var healthy int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	sender := NewSender(http.DefaultClient,
+		WithDeadLetters(store),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2}),
+	)
+	sender.sleep = func(context.Context, time.Duration) error { return nil }
+
+	d := &Delivery{URL: srv.URL, ContentType: "application/json", Body: []byte(`{"a":1}`), Topic: "mdm.Connect"}
+	if err := sender.Send(context.Background(), d); err == nil {
+		t.Fatal("expected error after every retry failed")
+	}
+
+	svc, _ := NewService(store, sender)
+	failed, err := svc.ListFailedDeliveries(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failed) != 1 {
+		t.Fatalf("have %d failed deliveries, want 1", len(failed))
+	}
+	if have := failed[0]; have.ID != d.ID || have.Attempts != 2 || string(have.Body) != `{"a":1}` || have.LastError == "" {
+		t.Errorf("unexpected failed delivery %+v", have)
+	}
+
+	// replaying while the target still fails keeps the delivery.
+	results, err := svc.ReplayFailedDeliveries(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Err == "" {
+		t.Fatalf("expected failed replay, got %+v", results)
+	}
+
+	atomic.StoreInt32(&healthy, 1)
+	results, err = svc.ReplayFailedDeliveries(context.Background(), []string{d.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Err != "" {
+		t.Fatalf("expected successful replay, got %+v", results)
+	}
+	failed, err = store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failed) != 0 {
+		t.Errorf("have %d failed deliveries after replay, want 0", len(failed))
+	}
+}
+
+func TestSender_DeliverDoesNotBlock(t *testing.T) {
+	store, teardown := setupDeadLetterDB(t)
+	defer teardown()
+
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	sender := NewSender(http.DefaultClient, WithDeadLetters(store), WithQueueSize(1))
+	deliver := func(id string) <-chan error {
+		done := make(chan error, 1)
+		returned := make(chan struct{})
+		go func() {
+			sender.Deliver(&Delivery{ID: id, URL: srv.URL, ContentType: "application/json", Body: []byte(`{}`)},
+				func(err error) { done <- err })
+			close(returned)
+		}()
+		select {
+		case <-returned:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("delivery %s blocked on an unresponsive target", id)
+		}
+		return done
+	}
+
+	sending := deliver("sending")
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the first delivery")
+	}
+	queued := deliver("queued")
+	select {
+	case err := <-deliver("overflow"):
+		if err != nil {
+			t.Fatalf("expected the overflow delivery to be saved, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the overflow delivery to be done without being sent")
+	}
+	select {
+	case <-sending:
+		t.Fatal("delivery done before the target responded")
+	case <-queued:
+		t.Fatal("queued delivery done before it was sent")
+	default:
+	}
+
+	failed, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failed) != 1 || failed[0].ID != "overflow" || failed[0].Attempts != 0 {
+		t.Errorf("have failed deliveries %+v, want the overflow delivery", failed)
+	}
+}
+
+func setupDeadLetterDB(t *testing.T) (*DeadLetterDB, func()) {
+	f, _ := ioutil.TempFile("", "bolt-")
+	teardown := func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+
+	db, err := bolt.Open(f.Name(), 0777, nil)
+	if err != nil {
+		t.Fatalf("couldn't open bolt, err %s\n", err)
+	}
+	store, err := NewDeadLetterDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, teardown
+}
@@ -0,0 +1,3 @@
+package webhookproto
+
+//go:generate protoc --go_out=. webhook.proto
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+func (svc *DeadLetterService) ListFailedDeliveries(ctx context.Context) ([]Delivery, error) {
+	return svc.store.List()
+}
+
+type listFailedRequest struct{}
+
+type listFailedResponse struct {
+	Deliveries []Delivery `json:"deliveries"`
+	Err        error      `json:"err,omitempty"`
+}
+
+func (r listFailedResponse) Failed() error { return r.Err }
+
+func decodeListFailedRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return listFailedRequest{}, nil
+}
+
+func encodeListFailedRequest(_ context.Context, r *http.Request, request interface{}) error {
+	r.Method, r.URL.Path = "GET", "/v1/webhooks/failed"
+	return nil
+}
+
+func decodeListFailedResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp listFailedResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeListFailedEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		deliveries, err := svc.ListFailedDeliveries(ctx)
+		return listFailedResponse{Deliveries: deliveries, Err: err}, nil
+	}
+}
+
+func (e Endpoints) ListFailedDeliveries(ctx context.Context) ([]Delivery, error) {
+	resp, err := e.ListFailedEndpoint(ctx, listFailedRequest{})
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(listFailedResponse)
+	return response.Deliveries, response.Err
+}
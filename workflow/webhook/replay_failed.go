@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+// ReplayResult is the outcome of replaying a failed delivery.
+type ReplayResult struct {
+	ID  string `json:"id"`
+	Err string `json:"error,omitempty"`
+}
+
+// ReplayFailedDeliveries sends the failed deliveries again. All failed
+// deliveries are replayed if ids is empty.
+func (svc *DeadLetterService) ReplayFailedDeliveries(ctx context.Context, ids []string) ([]ReplayResult, error) {
+	if len(ids) == 0 {
+		deliveries, err := svc.store.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+	}
+	results := make([]ReplayResult, 0, len(ids))
+	for _, id := range ids {
+		result := ReplayResult{ID: id}
+		if err := svc.sender.Replay(ctx, id); err != nil {
+			result.Err = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+type replayFailedRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type replayFailedResponse struct {
+	Results []ReplayResult `json:"results"`
+	Err     error          `json:"err,omitempty"`
+}
+
+func (r replayFailedResponse) Failed() error { return r.Err }
+
+func decodeReplayFailedRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req replayFailedRequest
+	err := httputil.DecodeJSONRequest(r, &req)
+	return req, err
+}
+
+func encodeReplayFailedRequest(ctx context.Context, r *http.Request, request interface{}) error {
+	r.Method, r.URL.Path = "POST", "/v1/webhooks/failed/replay"
+	return httptransport.EncodeJSONRequest(ctx, r, request)
+}
+
+func decodeReplayFailedResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var resp replayFailedResponse
+	err := httputil.DecodeJSONResponse(r, &resp)
+	return resp, err
+}
+
+func MakeReplayFailedEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(replayFailedRequest)
+		results, err := svc.ReplayFailedDeliveries(ctx, req.IDs)
+		return replayFailedResponse{Results: results, Err: err}, nil
+	}
+}
+
+func (e Endpoints) ReplayFailedDeliveries(ctx context.Context, ids []string) ([]ReplayResult, error) {
+	resp, err := e.ReplayFailedEndpoint(ctx, replayFailedRequest{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(replayFailedResponse)
+	return response.Results, response.Err
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+
+	"github.com/as/micromdm/pkg/httputil"
+)
+
+type Endpoints struct {
+	ListFailedEndpoint   endpoint.Endpoint
+	ReplayFailedEndpoint endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		ListFailedEndpoint:   MakeListFailedEndpoint(s),
+		ReplayFailedEndpoint: MakeReplayFailedEndpoint(s),
+	}
+}
+
+func MakeHTTPHandler(e Endpoints, logger log.Logger) *mux.Router {
+	r, options := httputil.NewRouter(logger)
+
+	// GET		/v1/webhooks/failed			list webhook deliveries which failed after every retry
+	// POST		/v1/webhooks/failed/replay		send failed webhook deliveries again
+
+	r.Methods("GET").Path("/v1/webhooks/failed").Handler(httptransport.NewServer(
+		e.ListFailedEndpoint,
+		decodeListFailedRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/v1/webhooks/failed/replay").Handler(httptransport.NewServer(
+		e.ReplayFailedEndpoint,
+		decodeReplayFailedRequest,
+		httputil.EncodeJSONResponse,
+		options...,
+	))
+
+	return r
+}
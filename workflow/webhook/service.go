@@ -0,0 +1,19 @@
+package webhook
+
+import "context"
+
+// Service lists and replays the webhook deliveries which failed after every retry.
+type Service interface {
+	ListFailedDeliveries(ctx context.Context) ([]Delivery, error)
+	ReplayFailedDeliveries(ctx context.Context, ids []string) ([]ReplayResult, error)
+}
+
+type DeadLetterService struct {
+	store  DeadLetterStore
+	sender *Sender
+}
+
+// NewService creates a Service for the dead letters of the sender.
+func NewService(store DeadLetterStore, sender *Sender) (*DeadLetterService, error) {
+	return &DeadLetterService{store: store, sender: sender}, nil
+}
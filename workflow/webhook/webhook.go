@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/as/micromdm/platform/events/envelope"
+	"github.com/as/micromdm/platform/pubsub"
+)
+
+// Target is a URL which receives the events of the selected topics
+// as JSON envelopes.
+type Target struct {
+	// ID identifies the target, and defaults to the URL.
+	// Targets with the same URL need distinct IDs.
+	ID string `json:"id,omitempty"`
+
+	URL string `json:"url"`
+
+	// Topics selects the events sent to the target.
+	// All envelope.Topics are sent if Topics is empty.
+	Topics []string `json:"topics,omitempty"`
+
+	// Secret is the key of the SignatureHeader. Requests are not signed
+	// if Secret is empty.
+	Secret string `json:"secret,omitempty"`
+}
+
+func (t Target) wants(topic string) bool {
+	if len(t.Topics) == 0 {
+		return true
+	}
+	for _, tt := range t.Topics {
+		if tt == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadTargets reads a JSON list of targets from a file.
+func LoadTargets(path string) ([]Target, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read webhook targets")
+	}
+	var targets []Target
+	if err := json.Unmarshal(data, &targets); err != nil {
+		return nil, errors.Wrapf(err, "decode webhook targets from %s", path)
+	}
+	for i := range targets {
+		if targets[i].ID == "" {
+			targets[i].ID = targets[i].URL
+		}
+	}
+	return targets, nil
+}
+
+// EventWebhook posts JSON envelopes of lifecycle events to its targets.
+type EventWebhook struct {
+	Targets []Target
+	Sender  *Sender
+}
+
+// NewEventWebhook creates an EventWebhook. The secrets of the targets must
+// be passed to the sender with WithSecret, keyed by the target ID.
+func NewEventWebhook(sender *Sender, targets ...Target) (*EventWebhook, error) {
+	targets = append([]Target{}, targets...)
+	ids := make(map[string]bool)
+	for i, t := range targets {
+		if t.URL == "" {
+			return nil, errors.New("webhook: target URL should not be empty")
+		}
+		if t.ID == "" {
+			t.ID = t.URL
+			targets[i].ID = t.URL
+		}
+		if t.ID == CommandTarget {
+			return nil, fmt.Errorf("webhook: target ID %s is reserved for the command webhook", t.ID)
+		}
+		if ids[t.ID] {
+			return nil, fmt.Errorf("webhook: duplicate target ID %s", t.ID)
+		}
+		ids[t.ID] = true
+		for _, topic := range t.Topics {
+			if !envelope.IsTopic(topic) {
+				return nil, fmt.Errorf("webhook: target %s has unsupported topic %s", t.URL, topic)
+			}
+		}
+	}
+	return &EventWebhook{Sender: sender, Targets: targets}, nil
+}
+
+// StartListener subscribes to every topic wanted by a target.
+func (w *EventWebhook) StartListener(sub pubsub.Subscriber) error {
+	for _, topic := range envelope.Topics {
+		targets := w.targets(topic)
+		if len(targets) == 0 {
+			continue
+		}
+		events, err := sub.Subscribe(context.TODO(), "eventWebhook", topic)
+		if err != nil {
+			return errors.Wrapf(err,
+				"subscribing eventWebhook to %s topic", topic)
+		}
+		go w.listen(events, targets)
+	}
+	return nil
+}
+
+func (w *EventWebhook) targets(topic string) []Target {
+	var targets []Target
+	for _, t := range w.Targets {
+		if t.wants(topic) {
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+func (w *EventWebhook) listen(events <-chan pubsub.Event, targets []Target) {
+	var acks pubsub.AckQueue
+	for event := range events {
+		env, err := envelope.New(event.Topic, event.Message)
+		if err != nil {
+			fmt.Println(err)
+			acks.Add(event, 0)
+			continue
+		}
+		body, err := json.Marshal(env)
+		if err != nil {
+			fmt.Println(errors.Wrapf(err, "encode %s event %s", env.Topic, env.EventID))
+			acks.Add(event, 0)
+			continue
+		}
+		done := acks.Add(event, len(targets))
+		for _, t := range targets {
+			w.Sender.Deliver(&Delivery{
+				URL:         t.URL,
+				Target:      t.ID,
+				ContentType: "application/json",
+				Body:        body,
+				Topic:       env.Topic,
+				EventID:     env.EventID,
+			}, done)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/as/micromdm/mdm"
+	"github.com/as/micromdm/mdm/checkin"
+	"github.com/as/micromdm/mdm/connect"
+	"github.com/as/micromdm/platform/events/envelope"
+	"github.com/as/micromdm/platform/pubsub"
+	"github.com/as/micromdm/platform/pubsub/inmem"
+)
+
+func TestEventWebhook_TopicFilters(t *testing.T) {
+	all, allEnvelopes := newTarget(t)
+	defer all.Close()
+	connects, connectEnvelopes := newTarget(t)
+	defer connects.Close()
+
+	hook, err := NewEventWebhook(NewSender(http.DefaultClient),
+		Target{URL: all.URL},
+		Target{URL: connects.URL, Topics: []string{connect.ConnectTopic}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := inmem.NewPubSub()
+	if err := hook.StartListener(ps); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := mdm.CheckinCommand{MessageType: "Authenticate", UDID: "udid-1"}
+	cmd.SerialNumber = "C02XXXXXXXXX"
+	authEvent := checkin.NewEvent(cmd)
+	msg, err := checkin.MarshalEvent(authEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := ps.Publish(ctx, checkin.AuthenticateTopic, msg); err != nil {
+		t.Fatal(err)
+	}
+	connectEvent := connect.NewEvent(connect.MDMConnectRequest{
+		MDMResponse: mdm.Response{UDID: "udid-1", Status: "Acknowledged", CommandUUID: "cmd-1"},
+	})
+	msg, err = connect.MarshalEvent(connectEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.Publish(ctx, connect.ConnectTopic, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	received := map[string]map[string]interface{}{}
+	for i := 0; i < 2; i++ {
+		env := receive(t, allEnvelopes)
+		received[env["topic"].(string)] = env
+	}
+	auth := received[checkin.AuthenticateTopic]
+	if auth == nil {
+		t.Fatal("Authenticate event not sent to the target without topic filter")
+	}
+	if have, want := auth["event_id"], authEvent.ID; have != want {
+		t.Errorf("have event_id %v, want %v", have, want)
+	}
+	if have, want := auth["udid"], "udid-1"; have != want {
+		t.Errorf("have udid %v, want %v", have, want)
+	}
+	if have, want := auth["version"], float64(envelope.Version); have != want {
+		t.Errorf("have version %v, want %v", have, want)
+	}
+	payload := auth["payload"].(map[string]interface{})
+	if have, want := payload["serial_number"], "C02XXXXXXXXX"; have != want {
+		t.Errorf("have serial_number %v, want %v", have, want)
+	}
+
+	env := receive(t, connectEnvelopes)
+	if have, want := env["event_id"], connectEvent.ID; have != want {
+		t.Errorf("have connect event_id %v, want %v", have, want)
+	}
+	response := env["payload"].(map[string]interface{})["response"].(map[string]interface{})
+	if have, want := response["CommandUUID"], "cmd-1"; have != want {
+		t.Errorf("have CommandUUID %v, want %v", have, want)
+	}
+	select {
+	case env := <-connectEnvelopes:
+		t.Errorf("unexpected %v event for connect target", env["topic"])
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestNewEventWebhook_UnknownTopic(t *testing.T) {
+	_, err := NewEventWebhook(NewSender(http.DefaultClient), Target{URL: "http://localhost", Topics: []string{"mdm.Unknown"}})
+	if err == nil {
+		t.Fatal("expected error for unknown topic")
+	}
+}
+
+func newTarget(t *testing.T) (*httptest.Server, <-chan map[string]interface{}) {
+	envelopes := make(chan map[string]interface{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("have Content-Type %s, want application/json", ct)
+		}
+		var env map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&env); err != nil {
+			t.Error(err)
+		}
+		envelopes <- env
+	}))
+	return srv, envelopes
+}
+
+func receive(t *testing.T, envelopes <-chan map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	select {
+	case env := <-envelopes:
+		return env
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for webhook")
+	}
+	return nil
+}
+
+func TestCommandWebhook_AckAfterDelivery(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	hook, err := NewCommandWebhook(NewSender(http.DefaultClient), connect.ConnectTopic, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make(chan pubsub.Event)
+	acked := make(chan int, 2)
+	sub := subscriberFunc(func() (<-chan pubsub.Event, error) { return events, nil })
+	if err := hook.StartListener(sub); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		i := i
+		msg, err := connect.MarshalEvent(connect.NewEvent(connect.MDMConnectRequest{
+			MDMResponse: mdm.Response{UDID: "udid-1", Status: "Acknowledged"},
+			Raw:         []byte("response"),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		events <- pubsub.Event{Topic: connect.ConnectTopic, Message: msg}.WithAck(func() error {
+			acked <- i
+			return nil
+		})
+	}
+
+	select {
+	case i := <-acked:
+		t.Fatalf("event %d acknowledged before it was sent", i)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	for want := 0; want < 2; want++ {
+		select {
+		case have := <-acked:
+			if have != want {
+				t.Errorf("have event %d acknowledged, want %d", have, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the acknowledgement")
+		}
+	}
+}
+
+type subscriberFunc func() (<-chan pubsub.Event, error)
+
+func (f subscriberFunc) Subscribe(context.Context, string, string) (<-chan pubsub.Event, error) {
+	return f()
+}
+
+func TestNewEventWebhook_TargetIDs(t *testing.T) {
+	sender := NewSender(http.DefaultClient)
+	url := "https://example.com/hook"
+	if _, err := NewEventWebhook(sender, Target{URL: url}, Target{URL: url}); err == nil {
+		t.Error("expected an error for two targets with the same URL and no ID")
+	}
+	if _, err := NewEventWebhook(sender, Target{URL: url}, Target{ID: "other", URL: url}); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewEventWebhook(sender, Target{ID: CommandTarget, URL: url}); err == nil {
+		t.Error("expected an error for the reserved command webhook target ID")
+	}
+}